@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package benchmarks
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// BenchmarkConsolePrimitiveFields shows that the console encoder, like the
+// JSON encoder, doesn't allocate when logging primitive fields. The fields
+// are built once, since a variadic slice passed to the logger escapes to the
+// heap regardless of the encoder.
+func BenchmarkConsolePrimitiveFields(b *testing.B) {
+	b.Logf("Logging primitive fields with the console encoder.")
+	b.Run("Zap", func(b *testing.B) {
+		logger := newZapConsoleLogger(zap.DebugLevel)
+		fields := fakePrimitiveFields()
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(getMessage(0), fields...)
+			}
+		})
+	})
+	b.Run("Zap.Check", func(b *testing.B) {
+		logger := newZapConsoleLogger(zap.DebugLevel)
+		fields := fakePrimitiveFields()
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if ce := logger.Check(zap.InfoLevel, getMessage(0)); ce != nil {
+					ce.Write(fields...)
+				}
+			}
+		})
+	})
+	b.Run("Zap.AccumulatedContext", func(b *testing.B) {
+		logger := newZapConsoleLogger(zap.DebugLevel).With(fakePrimitiveFields()...)
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(getMessage(0))
+			}
+		})
+	})
+}
@@ -115,6 +115,18 @@ func newZapLogger(lvl zapcore.Level) *zap.Logger {
 	))
 }
 
+func newZapConsoleLogger(lvl zapcore.Level) *zap.Logger {
+	ec := zap.NewProductionEncoderConfig()
+	ec.EncodeDuration = zapcore.NanosDurationEncoder
+	ec.EncodeTime = zapcore.EpochNanosTimeEncoder
+	enc := zapcore.NewConsoleEncoder(ec)
+	return zap.New(zapcore.NewCore(
+		enc,
+		&ztest.Discarder{},
+		lvl,
+	))
+}
+
 func newSampledLogger(lvl zapcore.Level) *zap.Logger {
 	return zap.New(zapcore.NewSamplerWithOptions(
 		newZapLogger(zap.DebugLevel).Core(),
@@ -139,6 +151,17 @@ func fakeFields() []zap.Field {
 	}
 }
 
+func fakePrimitiveFields() []zap.Field {
+	return []zap.Field{
+		zap.Int("int", _tenInts[0]),
+		zap.String("string", _tenStrings[0]),
+		zap.Bool("bool", true),
+		zap.Float64("float", 3.14),
+		zap.Duration("duration", time.Second),
+		zap.Time("time", _tenTimes[0]),
+	}
+}
+
 func fakeSugarFields() []interface{} {
 	return []interface{}{
 		"int", _tenInts[0],
@@ -105,6 +105,16 @@ func (b *Buffer) Reset() {
 	b.bs = b.bs[:0]
 }
 
+// Truncate discards all but the first n bytes of the buffer. Subsequent
+// writes re-use the slice's backing array. It panics if n is negative or
+// greater than the length of the buffer.
+func (b *Buffer) Truncate(n int) {
+	if n < 0 || n > len(b.bs) {
+		panic("buffer: truncation out of range")
+	}
+	b.bs = b.bs[:n]
+}
+
 // Write implements io.Writer.
 func (b *Buffer) Write(bs []byte) (int, error) {
 	b.bs = append(b.bs, bs...)
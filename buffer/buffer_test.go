@@ -50,6 +50,7 @@ func TestBufferWrites(t *testing.T) {
 		{"AppendTime", func() { buf.AppendTime(time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC), time.RFC3339) }, "2000-01-02T03:04:05Z"},
 		{"WriteByte", func() { buf.WriteByte('v') }, "v"},
 		{"WriteString", func() { buf.WriteString("foo") }, "foo"},
+		{"Truncate", func() { buf.AppendString("foobar"); buf.Truncate(3) }, "foo"},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +66,18 @@ func TestBufferWrites(t *testing.T) {
 	}
 }
 
+func TestBufferTruncateOutOfRange(t *testing.T) {
+	buf := NewPool().Get()
+	defer buf.Free()
+
+	buf.AppendString("foobar")
+	buf.Truncate(3)
+	for _, n := range []int{-1, 4, buf.Cap() + 1} {
+		assert.Panics(t, func() { buf.Truncate(n) }, "Expected a panic truncating to %d bytes.", n)
+	}
+	assert.Equal(t, "foo", buf.String(), "Expected a failed truncation to leave the buffer unchanged.")
+}
+
 func BenchmarkBuffers(b *testing.B) {
 	// Because we use the strconv.AppendFoo functions so liberally, we can't
 	// use the standard library's bytes.Buffer anyways (without incurring a
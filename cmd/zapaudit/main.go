@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// zapaudit verifies audit logs written by zapaudit cores.
+//
+// Usage:
+//
+//	zapaudit -key id=file [-key id=file ...] [log]
+//
+// zapaudit reads the log, or standard input if none is named, checks each
+// entry's MAC with the keys given, and reports the first broken link in the
+// chain along with any gaps, reordered entries, and other problems. Each
+// key file holds a hex-encoded secret. For example,
+//
+//	zapaudit -key 2024-01=/etc/audit/2024-01.key -key 2024-02=/etc/audit/2024-02.key audit.log
+//
+// zapaudit exits with status 1 if the log has problems, and 2 if it can't
+// be checked.
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap/zapaudit"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	var problems problemsError
+	switch {
+	case err == nil:
+	case errors.As(err, &problems):
+		fmt.Fprintln(os.Stderr, "zapaudit:", err)
+		os.Exit(1)
+	default:
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "zapaudit:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// problemsError is returned when a log was checked and has problems.
+type problemsError int
+
+func (e problemsError) Error() string {
+	if e == 1 {
+		return "found 1 problem"
+	}
+	return fmt.Sprintf("found %d problems", int(e))
+}
+
+// keysFlag collects secrets by key ID.
+type keysFlag map[string][]byte
+
+func (k keysFlag) String() string {
+	ids := make([]string, 0, len(k))
+	for id := range k {
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, ",")
+}
+
+func (k keysFlag) Set(s string) error {
+	id, path, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("key %q isn't of the form id=file", s)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	secret, err := hex.DecodeString(string(bytes.TrimSpace(contents)))
+	if err != nil {
+		return fmt.Errorf("key %q: %v", id, err)
+	}
+	if len(secret) == 0 {
+		return fmt.Errorf("key %q is empty", id)
+	}
+	k[id] = secret
+	return nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	keys := make(keysFlag)
+	fs := flag.NewFlagSet("zapaudit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: zapaudit -key id=file [-key id=file ...] [log]")
+		fs.PrintDefaults()
+	}
+	fs.Var(keys, "key", "ID and path of a file holding a hex-encoded secret; may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New("no keys given")
+	}
+
+	in := stdin
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	default:
+		return errors.New("only one log can be checked at a time")
+	}
+
+	report, err := zapaudit.Verify(in, keys)
+	if err != nil {
+		return err
+	}
+	for _, p := range report.Problems {
+		fmt.Fprintln(stdout, p)
+	}
+	if report.Entries == 0 {
+		fmt.Fprintln(stdout, "no entries")
+	} else {
+		fmt.Fprintf(stdout, "%d entries, seq %d to %d\n", report.Entries, report.FirstSequence, report.LastSequence)
+	}
+	if !report.OK() {
+		return problemsError(len(report.Problems))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapaudit"
+	"go.uber.org/zap/zapcore"
+)
+
+var _secret = []byte("secret")
+
+// writeKey writes a key file, returning its -key flag.
+func writeKey(t *testing.T, id string, secret []byte) string {
+	path := filepath.Join(t.TempDir(), id+".key")
+	require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(secret)+"\n"), 0o600), "Failed to write key.")
+	return "-key=" + id + "=" + path
+}
+
+// writeLog writes an audit log with the given messages, returning its path.
+func writeLog(t *testing.T, msgs ...string) string {
+	chain, err := zapaudit.NewChain(zapaudit.Key{ID: "k1", Secret: _secret})
+	require.NoError(t, err, "Unexpected error creating chain.")
+
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	logger := zap.New(zapaudit.NewCore(enc, zapcore.AddSync(&buf), zapcore.InfoLevel, chain))
+	for _, msg := range msgs {
+		logger.Info(msg)
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600), "Failed to write log.")
+	return path
+}
+
+func TestRun(t *testing.T) {
+	path := writeLog(t, "one", "two", "three")
+	key := writeKey(t, "k1", _secret)
+
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{key, path}, nil, &stdout, &bytes.Buffer{}), "Unexpected error verifying log.")
+	assert.Equal(t, "3 entries, seq 1 to 3\n", stdout.String(), "Unexpected output.")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Failed to read log.")
+	stdout.Reset()
+	err = run([]string{key}, strings.NewReader(strings.Replace(string(contents), "two", "TWO", 1)), &stdout, &bytes.Buffer{})
+	var problems problemsError
+	require.True(t, errors.As(err, &problems), "Expected problems, got %v.", err)
+	assert.EqualError(t, err, "found 1 problem", "Unexpected error.")
+	assert.Equal(t,
+		"line 2 (seq 2): broken link: MAC doesn't match\n"+
+			"3 entries, seq 1 to 3\n",
+		stdout.String(),
+		"Unexpected output.",
+	)
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	notHex := filepath.Join(dir, "bad.key")
+	require.NoError(t, os.WriteFile(notHex, []byte("not hex"), 0o600), "Failed to write key.")
+
+	key := writeKey(t, "k1", _secret)
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{desc: "no keys", args: nil, want: "no keys given"},
+		{desc: "key without ID", args: []string{"-key", "k1"}, want: `key "k1" isn't of the form id=file`},
+		{desc: "missing key", args: []string{"-key", "k1=" + filepath.Join(dir, "missing")}, want: "no such file"},
+		{desc: "bad key", args: []string{"-key", "k1=" + notHex}, want: `key "k1": encoding/hex`},
+		{desc: "missing log", args: []string{key, filepath.Join(dir, "missing")}, want: "no such file"},
+		{desc: "two logs", args: []string{key, "a", "b"}, want: "only one log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := run(tt.args, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+
+	err := run([]string{"-h"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, flag.ErrHelp, "Expected -h to return ErrHelp.")
+}
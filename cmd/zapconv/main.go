@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// zapconv converts logs between the formats written by zap's encoders.
+//
+// Usage:
+//
+//	zapconv -from console -to json [flags] [file ...]
+//
+// zapconv reads entries from the named files, or from standard input if
+// there are none, and re-encodes them with any registered encoder. Input
+// is processed as a stream, so files of any size can be converted.
+//
+// Input can be written by the "json", "console", "cbor", or "msgpack"
+// encoders, or by the "gcp", "ecs", and "datadog" presets of the JSON
+// encoder. The encoder configuration of the input and output is chosen
+// with -from-preset and -to-preset; by default, console output uses the
+// development configuration and everything else the production one.
+//
+// Field types are kept as far as the input allows: JSON and console output
+// keep numbers, booleans, strings, objects, and arrays apart, while CBOR
+// and MessagePack also keep times, durations, and binary data. Lines that
+// aren't entries are dropped, or copied unchanged with -passthrough.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// _presets are the encoder configurations that input may be written with,
+// and output written with.
+var _presets = map[string]func() zapcore.EncoderConfig{
+	"production":  zap.NewProductionEncoderConfig,
+	"development": zap.NewDevelopmentEncoderConfig,
+	"gcp":         zap.NewGCPEncoderConfig,
+	"ecs":         zap.NewECSEncoderConfig,
+	"datadog":     zap.NewDatadogEncoderConfig,
+}
+
+// _sources are the encodings that input may be written with, and the
+// preset each uses by default.
+var _sources = map[string]struct {
+	newSource func(io.Reader, zapcore.EncoderConfig) source
+	preset    string
+}{
+	"json":    {newJSONSource, "production"},
+	"gcp":     {newJSONSource, "gcp"},
+	"ecs":     {newJSONSource, "ecs"},
+	"datadog": {newJSONSource, "datadog"},
+	"console": {newConsoleSource, "development"},
+	"cbor":    {newCBORSource, "production"},
+	"msgpack": {newMsgpackSource, "production"},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdin, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "zapconv:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+type options struct {
+	from, fromPreset string
+	to, toPreset     string
+	output           string
+	passthrough      bool
+}
+
+func parseFlags(args []string, stderr io.Writer) (*options, []string, error) {
+	var opts options
+
+	fs := flag.NewFlagSet("zapconv", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: zapconv -from encoding -to encoding [flags] [file ...]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.from, "from", "json", "encoding of the input: "+strings.Join(names(_sources), ", "))
+	fs.StringVar(&opts.fromPreset, "from-preset", "", "encoder configuration of the input: "+strings.Join(names(_presets), ", "))
+	fs.StringVar(&opts.to, "to", "json", "registered encoder to write output with")
+	fs.StringVar(&opts.toPreset, "to-preset", "", "encoder configuration of the output")
+	fs.StringVar(&opts.output, "o", "stdout", "path or sink URL to write to")
+	fs.BoolVar(&opts.passthrough, "passthrough", false, "copy lines that aren't entries to the output")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	src, ok := _sources[opts.from]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown input encoding %q", opts.from)
+	}
+	if opts.fromPreset == "" {
+		opts.fromPreset = src.preset
+	}
+	if opts.toPreset == "" {
+		opts.toPreset = "production"
+		if opts.to == "console" || opts.to == "pretty" {
+			opts.toPreset = "development"
+		}
+	}
+	for _, preset := range []string{opts.fromPreset, opts.toPreset} {
+		if _, ok := _presets[preset]; !ok {
+			return nil, nil, fmt.Errorf("unknown preset %q", preset)
+		}
+	}
+	return &opts, fs.Args(), nil
+}
+
+func names[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stderr io.Writer) (retErr error) {
+	opts, files, err := parseFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	c, err := newConverter(opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = multierr.Append(retErr, c.Close())
+		if c.skipped > 0 {
+			fmt.Fprintf(stderr, "zapconv: skipped %d lines that weren't entries\n", c.skipped)
+		}
+	}()
+
+	if len(files) == 0 {
+		return c.convert(ctx, stdin)
+	}
+	for _, name := range files {
+		if err := c.convertFile(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// converter re-encodes the entries read from sources.
+type converter struct {
+	newSource   func(io.Reader) source
+	core        zapcore.Core
+	raw         zapcore.WriteSyncer
+	closeRaw    func()
+	passthrough bool
+	skipped     int
+}
+
+func newConverter(opts *options) (*converter, error) {
+	enc, err := zap.NewEncoder(opts.to, _presets[opts.toPreset]())
+	if err != nil {
+		return nil, err
+	}
+	// Converted entries and passed-through lines share the output, so it's
+	// only opened once.
+	raw, closeRaw, err := zap.Open(opts.output)
+	if err != nil {
+		return nil, err
+	}
+
+	from, fromCfg := _sources[opts.from].newSource, _presets[opts.fromPreset]()
+	return &converter{
+		newSource:   func(r io.Reader) source { return from(r, fromCfg) },
+		core:        zapcore.NewCore(enc, raw, zapcore.DebugLevel),
+		raw:         raw,
+		closeRaw:    closeRaw,
+		passthrough: opts.passthrough,
+	}, nil
+}
+
+func (c *converter) convertFile(ctx context.Context, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.convert(ctx, f)
+}
+
+func (c *converter) convert(ctx context.Context, r io.Reader) error {
+	src := c.newSource(r)
+	for ctx.Err() == nil {
+		rec, err := src.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if rec.raw != nil {
+			if !c.passthrough {
+				if len(bytes.TrimSpace(rec.raw)) > 0 {
+					c.skipped++
+				}
+				continue
+			}
+			if _, err := c.raw.Write(rec.raw); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.core.Write(rec.ent, rec.fields); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *converter) Close() error {
+	err := multierr.Combine(c.core.Sync(), c.raw.Sync())
+	c.closeRaw()
+	return err
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _epoch = time.Date(2018, 6, 19, 16, 33, 42, 0, time.UTC)
+
+// convert runs zapconv with the given arguments and input, returning its
+// output and anything written to stderr.
+func convert(t *testing.T, input []byte, args ...string) (string, string) {
+	out := filepath.Join(t.TempDir(), "out")
+	var stderr bytes.Buffer
+	args = append([]string{"-o", out}, args...)
+	require.NoError(t, run(context.Background(), args, bytes.NewReader(input), &stderr), "Unexpected error running zapconv.")
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err, "Failed to read output.")
+	return string(got), stderr.String()
+}
+
+// encode encodes entries, each with the same fields.
+func encode(t *testing.T, enc zapcore.Encoder, ents []zapcore.Entry, fields ...zapcore.Field) []byte {
+	var out []byte
+	for _, ent := range ents {
+		buf, err := enc.EncodeEntry(ent, fields)
+		require.NoError(t, err, "Unexpected encoding error.")
+		out = append(out, buf.Bytes()...)
+		buf.Free()
+	}
+	return out
+}
+
+func TestConvertJSONToConsole(t *testing.T) {
+	input := `{"level":"info","ts":1529426022.5,"logger":"main","caller":"foo.go:42","msg":"hello","n":1,"obj":{"a":[true,null]}}` + "\n" +
+		"not an entry\n" +
+		"\n" +
+		`{"level":"error","ts":1529426023,"msg":"failed","stacktrace":"main.main\n\tmain.go:1"}` + "\n"
+
+	out, stderr := convert(t, []byte(input), "-to", "console", "-to-preset", "production")
+	assert.Equal(t,
+		"1.5294260225e+09\tinfo\tmain\tfoo.go:42\thello\t"+`{"n": 1, "obj": {"a": [true, null]}}`+"\n"+
+			"1.529426023e+09\terror\tfailed\nmain.main\n\tmain.go:1\n",
+		out,
+		"Unexpected output.",
+	)
+	assert.Equal(t, "zapconv: skipped 1 lines that weren't entries\n", stderr, "Unexpected warning.")
+
+	out, stderr = convert(t, []byte(input), "-to", "console", "-to-preset", "production", "-passthrough")
+	assert.Contains(t, out, "\nnot an entry\n\n", "Expected lines that aren't entries to be copied.")
+	assert.Empty(t, stderr, "Unexpected warning.")
+}
+
+func TestConvertConsoleToJSON(t *testing.T) {
+	caller := zapcore.EntryCaller{Defined: true, File: "/src/foo.go", Line: 42, Function: "main.Foo"}
+	tests := []struct {
+		preset string
+		ents   []zapcore.Entry
+	}{
+		{
+			preset: "development",
+			ents: []zapcore.Entry{
+				{
+					Level:      zapcore.WarnLevel,
+					Time:       _epoch,
+					LoggerName: "main",
+					Caller:     caller,
+					Message:    "tab\tseparated \"message\" with \\ backslash",
+					Stack:      "main.main\n\t/src/main.go:1",
+				},
+				{Level: zapcore.InfoLevel, Time: _epoch, Message: "only a message"},
+				{Level: zapcore.DebugLevel, Time: _epoch, LoggerName: "name", Message: "42"},
+				{Level: zapcore.ErrorLevel, Message: "{not context}"},
+			},
+		},
+		{
+			// The production configuration escapes messages.
+			preset: "production",
+			ents: []zapcore.Entry{
+				{
+					Level:   zapcore.ErrorLevel,
+					Time:    _epoch,
+					Caller:  caller,
+					Message: "multi\nline \x1b[31m\"message\"\t\\",
+					Stack:   "main.main\n\t/src/main.go:1",
+				},
+				{Level: zapcore.InfoLevel, Time: _epoch, Message: "only a message"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			enc := zapcore.NewConsoleEncoder(_presets[tt.preset]())
+			input := encode(t, enc, tt.ents, zap.Int("n", 1), zap.String("msg", "field"), zap.Any("obj", map[string]interface{}{"a": "x\ty"}))
+
+			out, _ := convert(t, input, "-from", "console", "-from-preset", tt.preset, "-to", "json")
+			src := zapcore.NewJSONDecoder(strings.NewReader(out), zap.NewProductionEncoderConfig())
+			for _, want := range tt.ents {
+				ent, fields, err := src.Decode()
+				require.NoError(t, err, "Unexpected error decoding output %q.", out)
+
+				if want.Caller.Defined {
+					want.Caller.File = "src/foo.go" // trimmed by ShortCallerEncoder
+					want.Caller.Function = ""       // not written by the presets
+				}
+				assert.True(t, want.Time.Equal(ent.Time), "Unexpected time %v.", ent.Time)
+				want.Time, ent.Time = time.Time{}, time.Time{}
+				assert.Equal(t, want, ent, "Unexpected entry.")
+				assert.Equal(t, []zapcore.Field{
+					zap.Int64("n", 1),
+					zap.String("msg", "field"),
+					zap.Object("obj", zapcore.DecodedObject{zap.String("a", "x\ty")}),
+				}, fields, "Unexpected fields.")
+			}
+			_, _, err := src.Decode()
+			assert.True(t, errors.Is(err, io.EOF), "Expected no more entries.")
+		})
+	}
+}
+
+func TestConvertBinaryToJSON(t *testing.T) {
+	cfg := zap.NewProductionEncoderConfig()
+	ents := []zapcore.Entry{{Level: zapcore.InfoLevel, Time: _epoch, LoggerName: "main", Message: "hello"}}
+	fields := []zapcore.Field{
+		zap.Time("at", _epoch),
+		zap.Duration("took", time.Second),
+		zap.Binary("bin", []byte{1, 2}),
+		zap.Float32("f", 1.5),
+		zap.Uint64("u", 1<<63),
+		zap.Strings("strs", []string{"a"}),
+		zap.Object("obj", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("b", "2")
+			enc.AddInt("a", 1)
+			return nil
+		})),
+	}
+	want := `{"level":"info","ts":1529426022,"logger":"main","msg":"hello","at":1529426022,"bin":"AQI=",` +
+		`"f":1.5,"obj":{"a":1,"b":"2"},"strs":["a"],"took":1,"u":9223372036854775808}` + "\n"
+
+	for _, tt := range []struct {
+		name string
+		enc  zapcore.Encoder
+	}{
+		{"cbor", zapcore.NewCBOREncoder(cfg)},
+		{"msgpack", zapcore.NewMsgpackEncoder(cfg)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _ := convert(t, encode(t, tt.enc, ents, fields...), "-from", tt.name)
+			assert.Equal(t, want, out, "Unexpected output.")
+		})
+	}
+}
+
+func TestConvertFiles(t *testing.T) {
+	dir := t.TempDir()
+	var args []string
+	for i, msg := range []string{"one", "two"} {
+		name := filepath.Join(dir, msg)
+		line := `{"level":"info","msg":"` + msg + `"}` + "\n"
+		require.NoError(t, os.WriteFile(name, []byte(line), 0o644), "Failed to write input %d.", i)
+		args = append(args, name)
+	}
+
+	out, _ := convert(t, nil, args...)
+	assert.Equal(t, `{"level":"info","msg":"one"}`+"\n"+`{"level":"info","msg":"two"}`+"\n", out, "Unexpected output.")
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		args  []string
+		input string
+		want  string
+	}{
+		{desc: "unknown input", args: []string{"-from", "xml"}, want: `unknown input encoding "xml"`},
+		{desc: "unknown output", args: []string{"-to", "xml"}, want: `no encoder registered for name "xml"`},
+		{desc: "unknown preset", args: []string{"-from-preset", "nope"}, want: `unknown preset "nope"`},
+		{desc: "missing file", args: []string{filepath.Join(t.TempDir(), "missing")}, want: "no such file"},
+		{desc: "corrupt input", args: []string{"-from", "cbor"}, input: "\x82", want: "cbor: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			args := append([]string{"-o", filepath.Join(t.TempDir(), "out")}, tt.args...)
+			err := run(context.Background(), args, strings.NewReader(tt.input), &bytes.Buffer{})
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+
+	err := run(context.Background(), []string{"-h"}, nil, &bytes.Buffer{})
+	assert.ErrorIs(t, err, flag.ErrHelp, "Expected -h to return ErrHelp.")
+}
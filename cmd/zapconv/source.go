@@ -0,0 +1,358 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// record is an entry read from a source, or a line that isn't one.
+type record struct {
+	ent    zapcore.Entry
+	fields []zapcore.Field
+	raw    []byte
+}
+
+// source reads records from a log stream, returning io.EOF at its end.
+type source interface {
+	next() (record, error)
+}
+
+// readLine returns the next line of r, including its line ending.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadBytes('\n')
+	if len(line) > 0 {
+		return line, nil
+	}
+	return nil, err
+}
+
+// jsonSource reads the output of the JSON encoder.
+type jsonSource struct {
+	r   *bufio.Reader
+	dec *zapcore.JSONDecoder
+}
+
+func newJSONSource(r io.Reader, cfg zapcore.EncoderConfig) source {
+	return &jsonSource{
+		r: bufio.NewReader(r),
+		// Lines are read by the source, and decoded one at a time.
+		dec: zapcore.NewJSONDecoder(nil, cfg),
+	}
+}
+
+func (s *jsonSource) next() (record, error) {
+	line, err := readLine(s.r)
+	if err != nil {
+		return record{}, err
+	}
+	ent, fields, err := s.dec.DecodeEntry(line)
+	if err != nil {
+		return record{raw: line}, nil
+	}
+	return record{ent: ent, fields: fields}, nil
+}
+
+// consoleSource reads the output of the console encoder: the entry's
+// metadata and message separated by the ConsoleSeparator, the context as
+// JSON, and the stack trace, if any, on the following lines.
+//
+// Since lines are split on the separator, it must not appear in logger
+// names or callers. Messages may only contain it if the entry has a caller
+// to tell them apart from the logger name.
+type consoleSource struct {
+	r   *bufio.Reader
+	cfg zapcore.EncoderConfig
+	sep string
+
+	// meta decodes metadata, and context decodes the JSON context without
+	// mistaking any of its keys for metadata.
+	meta    *zapcore.JSONDecoder
+	context *zapcore.JSONDecoder
+
+	// pending is an entry whose stack trace may continue on the next line.
+	pending *record
+}
+
+func newConsoleSource(r io.Reader, cfg zapcore.EncoderConfig) source {
+	sep := cfg.ConsoleSeparator
+	if sep == "" {
+		sep = "\t"
+	}
+	return &consoleSource{
+		r:       bufio.NewReader(r),
+		cfg:     cfg,
+		sep:     sep,
+		meta:    zapcore.NewJSONDecoder(nil, cfg),
+		context: zapcore.NewJSONDecoder(nil, zapcore.EncoderConfig{}),
+	}
+}
+
+func (s *consoleSource) next() (record, error) {
+	for {
+		line, err := readLine(s.r)
+		if err != nil {
+			if err == io.EOF && s.pending != nil {
+				rec := *s.pending
+				s.pending = nil
+				return rec, nil
+			}
+			return record{}, err
+		}
+
+		rec, ok := s.parse(line)
+		if s.cfg.StacktraceKey == "" {
+			// Entries can't have stack traces, so there's no need to
+			// look ahead.
+			if !ok {
+				rec = record{raw: line}
+			}
+			return rec, nil
+		}
+
+		switch {
+		case !ok && s.pending == nil:
+			return record{raw: line}, nil
+		case !ok:
+			text := strings.TrimRight(string(line), "\r\n")
+			if s.pending.ent.Stack != "" {
+				text = s.pending.ent.Stack + "\n" + text
+			}
+			s.pending.ent.Stack = text
+		case s.pending == nil:
+			s.pending = &rec
+		default:
+			prev := *s.pending
+			s.pending = &rec
+			return prev, nil
+		}
+	}
+}
+
+// parse parses a line that starts an entry.
+func (s *consoleSource) parse(line []byte) (record, bool) {
+	parts := strings.Split(strings.TrimRight(string(line), "\r\n"), s.sep)
+	var meta []zapcore.Field
+
+	// The time is omitted for entries without one, but the level is always
+	// written, so it's what identifies entries.
+	i := 0
+	if s.cfg.TimeKey != "" && s.cfg.EncodeTime != nil {
+		if f, ok := s.metadata(consoleField(s.cfg.TimeKey, parts[i])); ok {
+			meta = append(meta, f)
+			i++
+		}
+	}
+	if s.cfg.LevelKey == "" || s.cfg.EncodeLevel == nil || i == len(parts) {
+		return record{}, false
+	}
+	f, ok := s.metadata(consoleField(s.cfg.LevelKey, parts[i]))
+	if !ok {
+		return record{}, false
+	}
+	meta = append(meta, f)
+	i++
+
+	// The context is the JSON object at the end of the line.
+	var fields []zapcore.Field
+	end := len(parts)
+	for j := i; j < len(parts); j++ {
+		if j == i && s.cfg.MessageKey != "" {
+			// The message comes first.
+			continue
+		}
+		if !strings.HasPrefix(parts[j], "{") {
+			continue
+		}
+		if _, f, err := s.context.DecodeEntry([]byte(strings.Join(parts[j:], s.sep))); err == nil {
+			fields, end = f, j
+			break
+		}
+	}
+
+	meta = append(meta, s.middle(parts[i:end])...)
+	ent, _ := s.meta.DecodeFields(meta)
+	return record{ent: ent, fields: fields}, true
+}
+
+// middle returns the logger name, caller, function, and message written
+// between the level and the context.
+func (s *consoleSource) middle(parts []string) []zapcore.Field {
+	var meta []zapcore.Field
+	msgParts := parts
+	if s.cfg.MessageKey == "" {
+		msgParts = nil
+	}
+
+	// Callers are easy to recognize, and the name may only precede them.
+	caller := -1
+	if s.cfg.CallerKey != "" && s.cfg.EncodeCaller != nil {
+		for j := 0; j < len(parts) && j < 2; j++ {
+			if f, ok := s.metadata(zap.String(s.cfg.CallerKey, parts[j])); ok {
+				caller = j
+				meta = append(meta, f)
+				break
+			}
+		}
+	}
+	switch {
+	case caller >= 0:
+		if caller == 1 && s.cfg.NameKey != "" {
+			meta = append(meta, zap.String(s.cfg.NameKey, parts[0]))
+		}
+		rest := parts[caller+1:]
+		if s.cfg.FunctionKey != "" && len(rest) > 0 && (len(rest) > 1 || s.cfg.MessageKey == "") {
+			meta = append(meta, zap.String(s.cfg.FunctionKey, rest[0]))
+			rest = rest[1:]
+		}
+		if msgParts != nil {
+			msgParts = rest
+		}
+	case s.cfg.NameKey != "" && (len(parts) > 1 || s.cfg.MessageKey == "") && len(parts) > 0:
+		meta = append(meta, zap.String(s.cfg.NameKey, parts[0]))
+		if msgParts != nil {
+			msgParts = parts[1:]
+		}
+	}
+
+	if s.cfg.MessageKey != "" {
+		meta = append(meta, zap.String(s.cfg.MessageKey, s.unescape(strings.Join(msgParts, s.sep))))
+	}
+	return meta
+}
+
+// metadata reports whether f holds entry metadata that can be decoded.
+func (s *consoleSource) metadata(f zapcore.Field) (zapcore.Field, bool) {
+	_, rest := s.meta.DecodeFields([]zapcore.Field{f})
+	return f, len(rest) == 0
+}
+
+// unescape reverses EscapeStrict, which escapes backslashes so that its
+// escapes are unambiguous.
+func (s *consoleSource) unescape(msg string) string {
+	if s.cfg.ConsoleEscaping != zapcore.EscapeStrict || !strings.Contains(msg, `\`) {
+		return msg
+	}
+	unquoted, err := strconv.Unquote(`"` + strings.ReplaceAll(msg, `"`, `\"`) + `"`)
+	if err != nil {
+		return msg
+	}
+	return unquoted
+}
+
+// consoleField returns a field holding metadata formatted by fmt.Sprint.
+func consoleField(key, text string) zapcore.Field {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return zap.Int64(key, i)
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return zap.Float64(key, f)
+	}
+	return zap.String(key, text)
+}
+
+// binarySource reads the output of the CBOR and MessagePack encoders.
+type binarySource struct {
+	decode func() (map[string]interface{}, error)
+	dec    *zapcore.JSONDecoder
+}
+
+func newCBORSource(r io.Reader, cfg zapcore.EncoderConfig) source {
+	return &binarySource{
+		decode: zapcore.NewCBORDecoder(r).Decode,
+		dec:    zapcore.NewJSONDecoder(nil, cfg),
+	}
+}
+
+func newMsgpackSource(r io.Reader, cfg zapcore.EncoderConfig) source {
+	return &binarySource{
+		decode: zapcore.NewMsgpackDecoder(r).Decode,
+		dec:    zapcore.NewJSONDecoder(nil, cfg),
+	}
+}
+
+func (s *binarySource) next() (record, error) {
+	m, err := s.decode()
+	if err != nil {
+		return record{}, err
+	}
+	ent, fields := s.dec.DecodeFields(mapFields(m))
+	return record{ent: ent, fields: fields}, nil
+}
+
+// mapFields converts a decoded map to fields, sorted by key since maps
+// don't keep the order they were written in.
+func mapFields(m map[string]interface{}) []zapcore.Field {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]zapcore.Field, len(keys))
+	for i, k := range keys {
+		fields[i] = valueField(k, m[k])
+	}
+	return fields
+}
+
+func valueField(key string, v interface{}) zapcore.Field {
+	switch v := v.(type) {
+	case nil:
+		return zapcore.Field{Key: key, Type: zapcore.ReflectType}
+	case bool:
+		return zap.Bool(key, v)
+	case int64:
+		return zap.Int64(key, v)
+	case uint64:
+		return zap.Uint64(key, v)
+	case float32:
+		return zap.Float32(key, v)
+	case float64:
+		return zap.Float64(key, v)
+	case string:
+		return zap.String(key, v)
+	case []byte:
+		return zap.Binary(key, v)
+	case time.Time:
+		return zap.Time(key, v)
+	case time.Duration:
+		return zap.Duration(key, v)
+	case map[string]interface{}:
+		return zap.Object(key, zapcore.DecodedObject(mapFields(v)))
+	case []interface{}:
+		arr := make(zapcore.DecodedArray, len(v))
+		for i, e := range v {
+			arr[i] = valueField("", e)
+		}
+		return zap.Array(key, arr)
+	default:
+		return zap.Any(key, v)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// filter selects the entries to show.
+type filter struct {
+	level        zapcore.Level
+	logger       string
+	since, until time.Time
+	where        []condition
+}
+
+func (f *filter) match(dec *zapcore.JSONDecoder, ent zapcore.Entry, fields []zapcore.Field) bool {
+	if !f.level.Enabled(ent.Level) || !strings.HasPrefix(ent.LoggerName, f.logger) {
+		return false
+	}
+	if !f.since.IsZero() || !f.until.IsZero() {
+		if ent.Time.IsZero() {
+			return false
+		}
+		if !f.since.IsZero() && ent.Time.Before(f.since) {
+			return false
+		}
+		if !f.until.IsZero() && ent.Time.After(f.until) {
+			return false
+		}
+	}
+	for _, c := range f.where {
+		if !c.match(dec, fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTime parses an RFC 3339 time, or a duration before now.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: want RFC 3339 or a duration", s)
+	}
+	return t, nil
+}
+
+// condition compares a field with a value, like latency>100ms.
+type condition struct {
+	path  string
+	op    string
+	value string
+
+	// The value, parsed as each type it may be compared as.
+	number     float64
+	isNumber   bool
+	duration   time.Duration
+	isDuration bool
+}
+
+func parseCondition(s string) (condition, error) {
+	i := strings.IndexAny(s, "=!<>")
+	if i <= 0 {
+		return condition{}, fmt.Errorf("invalid expression %q: want a field, an operator, and a value", s)
+	}
+	c := condition{path: s[:i]}
+
+	rest := s[i:]
+	switch {
+	case strings.HasPrefix(rest, "!="), strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="):
+		c.op = rest[:2]
+	case strings.HasPrefix(rest, "=="):
+		c.op = "="
+		rest = rest[1:]
+	case rest[0] == '!':
+		return condition{}, fmt.Errorf("invalid expression %q: unknown operator", s)
+	default:
+		c.op = rest[:1]
+	}
+	c.value = rest[len(c.op):]
+
+	if n, err := strconv.ParseFloat(c.value, 64); err == nil {
+		c.number, c.isNumber = n, true
+	} else if d, err := time.ParseDuration(c.value); err == nil {
+		c.duration, c.isDuration = d, true
+	}
+	return c, nil
+}
+
+func (c condition) match(dec *zapcore.JSONDecoder, fields []zapcore.Field) bool {
+	f, ok := lookup(fields, c.path)
+	if !ok {
+		return false
+	}
+	cmp, err := c.compare(dec, f)
+	if err != nil {
+		// Values that can't be compared aren't equal.
+		return c.op == "!="
+	}
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+var errIncomparable = errors.New("incomparable values")
+
+// compare compares the field with the condition's value, returning -1, 0,
+// or +1.
+func (c condition) compare(dec *zapcore.JSONDecoder, f zapcore.Field) (int, error) {
+	if c.isDuration {
+		if d, ok := dec.DecodeDuration(f); ok {
+			return compareOrdered(d, c.duration), nil
+		}
+	}
+	if c.isNumber {
+		if n, ok := number(f); ok {
+			return compareOrdered(n, c.number), nil
+		}
+	}
+	if s, ok := text(f); ok {
+		return strings.Compare(s, c.value), nil
+	}
+	return 0, errIncomparable
+}
+
+func compareOrdered[T ~int64 | ~float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// lookup finds a field by its dot-separated path. Since keys may contain
+// dots themselves, whole keys are tried before splitting the path.
+func lookup(fields []zapcore.Field, path string) (zapcore.Field, bool) {
+	for _, f := range fields {
+		if f.Key == path {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		rest := strings.TrimPrefix(path, f.Key+".")
+		if rest == path {
+			continue
+		}
+		if obj, ok := f.Interface.(zapcore.DecodedObject); ok {
+			if found, ok := lookup(obj, rest); ok {
+				return found, true
+			}
+		}
+	}
+	return zapcore.Field{}, false
+}
+
+func number(f zapcore.Field) (float64, bool) {
+	switch f.Type {
+	case zapcore.Int64Type:
+		return float64(f.Integer), true
+	case zapcore.Uint64Type:
+		return float64(uint64(f.Integer)), true
+	case zapcore.Float64Type:
+		return math.Float64frombits(uint64(f.Integer)), true
+	}
+	return 0, false
+}
+
+func text(f zapcore.Field) (string, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String, true
+	case zapcore.BoolType:
+		return strconv.FormatBool(f.Integer == 1), true
+	case zapcore.Int64Type:
+		return strconv.FormatInt(f.Integer, 10), true
+	case zapcore.Uint64Type:
+		return strconv.FormatUint(uint64(f.Integer), 10), true
+	case zapcore.Float64Type:
+		return strconv.FormatFloat(math.Float64frombits(uint64(f.Integer)), 'g', -1, 64), true
+	case zapcore.ReflectType:
+		if f.Interface == nil {
+			return "null", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr  string
+		path  string
+		op    string
+		value string
+	}{
+		{"a=1", "a", "=", "1"},
+		{"a==1", "a", "=", "1"},
+		{"a.b!=x", "a.b", "!=", "x"},
+		{"a<1", "a", "<", "1"},
+		{"a<=1", "a", "<=", "1"},
+		{"a>100ms", "a", ">", "100ms"},
+		{"a>=", "a", ">=", ""},
+		{"a=b=c", "a", "=", "b=c"},
+	}
+
+	for _, tt := range tests {
+		c, err := parseCondition(tt.expr)
+		require.NoError(t, err, "Unexpected error parsing %q.", tt.expr)
+		assert.Equal(t, tt.path, c.path, "Unexpected path in %q.", tt.expr)
+		assert.Equal(t, tt.op, c.op, "Unexpected operator in %q.", tt.expr)
+		assert.Equal(t, tt.value, c.value, "Unexpected value in %q.", tt.expr)
+	}
+
+	for _, expr := range []string{"", "a", "=1", "a!1"} {
+		_, err := parseCondition(expr)
+		assert.Error(t, err, "Expected an error parsing %q.", expr)
+	}
+}
+
+func TestConditionMatch(t *testing.T) {
+	dec := zapcore.NewJSONDecoder(nil, zap.NewProductionEncoderConfig())
+	fields := []zapcore.Field{
+		zap.String("name", "bob"),
+		zap.Int64("count", 10),
+		zap.Float64("latency", 0.25),
+		zap.Bool("ok", true),
+		{Key: "nil", Type: zapcore.ReflectType},
+		zap.Object("user", zapcore.DecodedObject{zap.Int64("id", 42)}),
+		zap.Object("http", zapcore.DecodedObject{zap.Int64("status.code", 200)}),
+		zap.Int64("http.status", 404),
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"name=bob", true},
+		{"name!=bob", false},
+		{"name<carol", true},
+		{"count=10", true},
+		{"count=10.0", true},
+		{"count>9", true},
+		{"count<=9", false},
+		{"latency>100ms", true},
+		{"latency<250ms", false},
+		{"latency<=250ms", true},
+		{"latency=0.25", true},
+		{"ok=true", true},
+		{"nil=null", true},
+		{"user.id=42", true},
+		{"user.id!=42", false},
+		{"user=42", false},
+		{"user!=42", true},
+		{"http.status.code=200", true},
+		{"http.status=404", true},
+		{"missing=1", false},
+		{"missing!=1", false},
+	}
+
+	for _, tt := range tests {
+		c, err := parseCondition(tt.expr)
+		require.NoError(t, err, "Unexpected error parsing %q.", tt.expr)
+		assert.Equal(t, tt.want, c.match(dec, fields), "Unexpected match for %q.", tt.expr)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	dec := zapcore.NewJSONDecoder(nil, zap.NewProductionEncoderConfig())
+	now := time.Date(2018, 6, 19, 16, 33, 42, 0, time.UTC)
+	ent := zapcore.Entry{Level: zapcore.WarnLevel, LoggerName: "http.server", Time: now}
+
+	tests := []struct {
+		desc   string
+		filter filter
+		ent    zapcore.Entry
+		want   bool
+	}{
+		{"empty", filter{}, ent, true},
+		{"level", filter{level: zapcore.ErrorLevel}, ent, false},
+		{"logger", filter{logger: "http"}, ent, true},
+		{"other logger", filter{logger: "db"}, ent, false},
+		{"since", filter{since: now.Add(-time.Second)}, ent, true},
+		{"since later", filter{since: now.Add(time.Second)}, ent, false},
+		{"until", filter{until: now}, ent, true},
+		{"until earlier", filter{until: now.Add(-time.Second)}, ent, false},
+		{"no time", filter{until: now}, zapcore.Entry{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(dec, tt.ent, nil), "Unexpected match.")
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2018, 6, 19, 16, 33, 42, 0, time.UTC)
+
+	got, err := parseTime("1h30m", now)
+	require.NoError(t, err, "Unexpected error parsing a duration.")
+	assert.Equal(t, now.Add(-90*time.Minute), got, "Expected durations to be relative to now.")
+
+	got, err = parseTime("2018-06-19T16:33:42.5+01:00", now)
+	require.NoError(t, err, "Unexpected error parsing a time.")
+	assert.True(t, got.Equal(now.Add(-time.Hour+500*time.Millisecond)), "Unexpected time %v.", got)
+
+	_, err = parseTime("yesterday", now)
+	assert.True(t, err != nil && strings.Contains(err.Error(), "RFC 3339"), "Expected an error for an invalid time.")
+}
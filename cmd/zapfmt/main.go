@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// zapfmt pretty-prints and filters logs written by zap's JSON encoder.
+//
+// Usage:
+//
+//	zapfmt [flags] [file ...]
+//
+// zapfmt reads entries from the named files, or from standard input if
+// there are none, and renders them with the console encoder or any other
+// registered encoder. Lines that aren't JSON are passed through unchanged.
+//
+// Entries can be filtered by level, logger name prefix, time range, and
+// field expressions. Field expressions compare a field, named by its
+// dot-separated path, with =, !=, <, <=, >, or >=. Durations like 100ms
+// are compared with fields written by the input's DurationEncoder, numbers
+// numerically, and anything else as text. For example,
+//
+//	zapfmt -level warn -logger http -since 1h -where user.id=42 -where 'latency>100ms' app.log
+//
+// With -f, zapfmt keeps reading files as they grow, like tail -f.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/color"
+	"go.uber.org/zap/zapcore"
+)
+
+// _followInterval is how often files are checked for new data with -f.
+const _followInterval = 250 * time.Millisecond
+
+// _presets are the encoder configurations that input may be written with.
+var _presets = map[string]func() zapcore.EncoderConfig{
+	"production":  zap.NewProductionEncoderConfig,
+	"development": zap.NewDevelopmentEncoderConfig,
+	"gcp":         zap.NewGCPEncoderConfig,
+	"ecs":         zap.NewECSEncoderConfig,
+	"datadog":     zap.NewDatadogEncoderConfig,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "zapfmt:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+type options struct {
+	preset   string
+	encoding string
+	output   string
+	color    zapcore.ColorMode
+	follow   bool
+	utc      bool
+	filter   filter
+}
+
+func parseFlags(args []string, stderr io.Writer) (*options, []string, error) {
+	opts := options{filter: filter{level: zapcore.DebugLevel}}
+
+	fs := flag.NewFlagSet("zapfmt", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: zapfmt [flags] [file ...]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.preset, "preset", "production", "encoder configuration of the input: "+strings.Join(presetNames(), ", "))
+	fs.StringVar(&opts.encoding, "encoding", "console", "registered encoder to render entries with")
+	fs.StringVar(&opts.output, "o", "stdout", "path or sink URL to write to")
+	fs.TextVar(&opts.color, "color", zapcore.ColorAuto, "whether to color output: auto, always, or never")
+	fs.BoolVar(&opts.follow, "f", false, "keep reading files as they grow")
+	fs.BoolVar(&opts.utc, "utc", false, "show times in UTC rather than local time")
+	fs.Var(&opts.filter.level, "level", "minimum level to show")
+	fs.StringVar(&opts.filter.logger, "logger", "", "only show loggers whose names start with this prefix")
+	fs.Func("since", "only show entries at or after this time, as RFC 3339 or a duration ago", func(s string) (err error) {
+		opts.filter.since, err = parseTime(s, time.Now())
+		return err
+	})
+	fs.Func("until", "only show entries at or before this time, as RFC 3339 or a duration ago", func(s string) (err error) {
+		opts.filter.until, err = parseTime(s, time.Now())
+		return err
+	})
+	fs.Func("where", "only show entries whose fields match an expression like user.id=42; may be repeated", func(s string) error {
+		c, err := parseCondition(s)
+		if err != nil {
+			return err
+		}
+		opts.filter.where = append(opts.filter.where, c)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := _presets[opts.preset]; !ok {
+		return nil, nil, fmt.Errorf("unknown preset %q", opts.preset)
+	}
+	return &opts, fs.Args(), nil
+}
+
+func presetNames() []string {
+	names := make([]string, 0, len(_presets))
+	for name := range _presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stderr io.Writer) (retErr error) {
+	opts, files, err := parseFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	f, err := newFormatter(opts)
+	if err != nil {
+		return err
+	}
+	defer func() { retErr = multierr.Append(retErr, f.Close()) }()
+
+	if len(files) == 0 {
+		return f.format(stdin)
+	}
+
+	if !opts.follow {
+		for _, name := range files {
+			if err := f.formatFile(ctx, name, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Followed files never end, so read them concurrently.
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	for _, name := range files {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if err := f.formatFile(ctx, name, true); err != nil {
+				mu.Lock()
+				errs = multierr.Append(errs, err)
+				mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+	return errs
+}
+
+// formatter renders decoded entries with a zap core, and writes lines that
+// aren't entries straight to the same output.
+type formatter struct {
+	dec    *zapcore.JSONDecoder
+	filter filter
+	utc    bool
+
+	mu       sync.Mutex // guards writes to core and raw
+	core     zapcore.Core
+	raw      zapcore.WriteSyncer
+	closeRaw func()
+}
+
+func newFormatter(opts *options) (*formatter, error) {
+	encCfg := zap.NewDevelopmentEncoderConfig()
+	// Input may come from anywhere, so don't let it control the terminal.
+	encCfg.ConsoleEscaping = zapcore.EscapeStrict
+	encCfg.ConsoleColor = zapcore.ColorNever
+	if opts.color == zapcore.ColorAlways || (opts.color == zapcore.ColorAuto && outputsToTerminal(opts.output)) {
+		encCfg.ConsoleColor = zapcore.ColorAlways
+		encCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	enc, err := zap.NewEncoder(opts.encoding, encCfg)
+	if err != nil {
+		return nil, err
+	}
+	// Entries and raw lines share the output, so it's only opened once.
+	raw, closeRaw, err := zap.Open(opts.output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &formatter{
+		// Lines are read by the formatter, and decoded one at a time.
+		dec:      zapcore.NewJSONDecoder(nil, _presets[opts.preset]()),
+		filter:   opts.filter,
+		utc:      opts.utc,
+		core:     zapcore.NewCore(enc, raw, zapcore.DebugLevel),
+		raw:      raw,
+		closeRaw: closeRaw,
+	}, nil
+}
+
+func outputsToTerminal(output string) bool {
+	return output == "stdout" && color.Enabled(os.Stdout)
+}
+
+func (f *formatter) formatFile(ctx context.Context, name string, follow bool) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if follow {
+		r = &followReader{ctx: ctx, r: file, interval: _followInterval}
+	}
+	return f.format(r)
+}
+
+func (f *formatter) format(r io.Reader) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if werr := f.formatLine(line); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (f *formatter) formatLine(line []byte) error {
+	ent, fields, err := f.dec.DecodeEntry(line)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil {
+		_, err := f.raw.Write(line)
+		return err
+	}
+	if !f.filter.match(f.dec, ent, fields) {
+		return nil
+	}
+	if f.utc {
+		ent.Time = ent.Time.UTC()
+	}
+	return f.core.Write(ent, fields)
+}
+
+func (f *formatter) Close() error {
+	err := multierr.Combine(f.core.Sync(), f.raw.Sync())
+	f.closeRaw()
+	return err
+}
+
+// followReader reads from r like tail -f: at the end of r, it waits for
+// more data until ctx is done.
+type followReader struct {
+	ctx      context.Context
+	r        io.Reader
+	interval time.Duration
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+		select {
+		case <-f.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(f.interval):
+		}
+	}
+}
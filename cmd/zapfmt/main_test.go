@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _input = `{"level":"debug","ts":1529425922,"logger":"http","msg":"starting"}
+panic: not a log line
+{"level":"info","ts":1529425923,"logger":"http.server","msg":"served","user":{"id":42},"latency":0.25}
+{"level":"warn","ts":1529425924,"logger":"db","msg":"slow query","latency":1.5}
+
+{"level":"error","ts":1529425925,"logger":"http.server","msg":"failed","user":{"id":7},"error":"boom"}
+`
+
+// runZapfmt runs zapfmt with the given arguments and input, returning its
+// output.
+func runZapfmt(t *testing.T, input string, args ...string) string {
+	out := filepath.Join(t.TempDir(), "out")
+	var stderr bytes.Buffer
+	args = append([]string{"-o", out, "-utc"}, args...)
+	require.NoError(t, run(context.Background(), args, strings.NewReader(input), &stderr), "Unexpected error running zapfmt.")
+	assert.Empty(t, stderr.String(), "Unexpected output to stderr.")
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err, "Failed to read output.")
+	return string(got)
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{
+			desc: "all",
+			want: "2018-06-19T16:32:02.000Z\tDEBUG\thttp\tstarting\n" +
+				"panic: not a log line\n" +
+				"2018-06-19T16:32:03.000Z\tINFO\thttp.server\tserved\t" + `{"user": {"id": 42}, "latency": 0.25}` + "\n" +
+				"2018-06-19T16:32:04.000Z\tWARN\tdb\tslow query\t" + `{"latency": 1.5}` + "\n" +
+				"\n" +
+				"2018-06-19T16:32:05.000Z\tERROR\thttp.server\tfailed\t" + `{"user": {"id": 7}, "error": "boom"}` + "\n",
+		},
+		{
+			desc: "level",
+			args: []string{"-level", "warn"},
+			want: "panic: not a log line\n" +
+				"2018-06-19T16:32:04.000Z\tWARN\tdb\tslow query\t" + `{"latency": 1.5}` + "\n" +
+				"\n" +
+				"2018-06-19T16:32:05.000Z\tERROR\thttp.server\tfailed\t" + `{"user": {"id": 7}, "error": "boom"}` + "\n",
+		},
+		{
+			desc: "logger and fields",
+			args: []string{"-logger", "http.", "-where", "user.id=42", "-encoding", "json"},
+			want: "panic: not a log line\n" +
+				`{"L":"INFO","T":"2018-06-19T16:32:03.000Z","N":"http.server","M":"served","user":{"id":42},"latency":0.25}` + "\n" +
+				"\n",
+		},
+		{
+			desc: "time range and duration",
+			args: []string{"-since", "2018-06-19T16:32:03Z", "-until", "2018-06-19T16:32:04Z", "-where", "latency>=1s"},
+			want: "panic: not a log line\n" +
+				"2018-06-19T16:32:04.000Z\tWARN\tdb\tslow query\t" + `{"latency": 1.5}` + "\n" +
+				"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, runZapfmt(t, _input, tt.args...), "Unexpected output.")
+		})
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	var args []string
+	for i, line := range strings.SplitAfter(strings.TrimSpace(_input), "\n")[:2] {
+		name := filepath.Join(dir, string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(name, []byte(line), 0o644), "Failed to write input.")
+		args = append(args, name)
+	}
+
+	assert.Equal(t,
+		"2018-06-19T16:32:02.000Z\tDEBUG\thttp\tstarting\npanic: not a log line\n",
+		runZapfmt(t, "", args...),
+		"Unexpected output.",
+	)
+}
+
+func TestRunFollow(t *testing.T) {
+	dir := t.TempDir()
+	in, out := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+	require.NoError(t, os.WriteFile(in, nil, 0o644), "Failed to create input.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"-o", out, "-f", in}, nil, &bytes.Buffer{})
+	}()
+
+	f, err := os.OpenFile(in, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err, "Failed to open input.")
+	defer f.Close()
+	_, err = f.WriteString(`{"level":"info","ts":1529425922,"msg":"one"}` + "\n")
+	require.NoError(t, err, "Failed to write input.")
+
+	assert.Eventually(t, func() bool {
+		got, _ := os.ReadFile(out)
+		return strings.Contains(string(got), "\tone\n")
+	}, 5*time.Second, 10*time.Millisecond, "Expected appended lines to be formatted.")
+
+	cancel()
+	assert.NoError(t, <-done, "Unexpected error following input.")
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{"unknown preset", []string{"-preset", "nope"}, `unknown preset "nope"`},
+		{"unknown encoding", []string{"-encoding", "nope"}, `no encoder registered for name "nope"`},
+		{"bad level", []string{"-level", "loud"}, `invalid value "loud" for flag -level`},
+		{"bad time", []string{"-since", "yesterday"}, `invalid time "yesterday"`},
+		{"bad expression", []string{"-where", "=1"}, `invalid expression "=1"`},
+		{"missing file", []string{filepath.Join(t.TempDir(), "missing")}, "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			args := append([]string{"-o", filepath.Join(t.TempDir(), "out")}, tt.args...)
+			err := run(context.Background(), args, strings.NewReader(""), &bytes.Buffer{})
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+
+	err := run(context.Background(), []string{"-h"}, nil, &bytes.Buffer{})
+	assert.ErrorIs(t, err, flag.ErrHelp, "Expected -h to return ErrHelp.")
+}
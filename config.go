@@ -22,9 +22,11 @@ package zap
 
 import (
 	"errors"
+	"os"
 	"sort"
 	"time"
 
+	"go.uber.org/zap/internal/color"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -72,8 +74,9 @@ type Config struct {
 	DisableStacktrace bool `json:"disableStacktrace" yaml:"disableStacktrace"`
 	// Sampling sets a sampling policy. A nil SamplingConfig disables sampling.
 	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
-	// Encoding sets the logger's encoding. Valid values are "json" and
-	// "console", as well as any third-party encodings registered via
+	// Encoding sets the logger's encoding. Valid values are "json",
+	// "console", "pretty", "cbor", "msgpack", "otlp", "gcp", "ecs", and
+	// "datadog", as well as any third-party encodings registered via
 	// RegisterEncoder.
 	Encoding string `json:"encoding" yaml:"encoding"`
 	// EncoderConfig sets options for the chosen encoder. See
@@ -135,6 +138,9 @@ func NewProductionEncoderConfig() zapcore.EncoderConfig {
 		EncodeTime:     zapcore.EpochTimeEncoder,
 		EncodeDuration: zapcore.SecondsDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
+		// Has no effect on the JSON encoder, but keeps user input in
+		// messages from forging lines if the encoding is changed to console.
+		ConsoleEscaping: zapcore.EscapeStrict,
 	}
 }
 
@@ -237,6 +243,14 @@ func NewDevelopmentConfig() Config {
 
 // Build constructs a logger from the Config and Options.
 func (cfg Config) Build(opts ...Option) (*Logger, error) {
+	if cfg.EncoderConfig.ConsoleColor == zapcore.ColorAuto {
+		// Unlike the encoder, we know where its output goes.
+		cfg.EncoderConfig.ConsoleColor = zapcore.ColorNever
+		if cfg.outputsToTerminal() {
+			cfg.EncoderConfig.ConsoleColor = zapcore.ColorAlways
+		}
+	}
+
 	enc, err := cfg.buildEncoder()
 	if err != nil {
 		return nil, err
@@ -261,6 +275,24 @@ func (cfg Config) Build(opts ...Option) (*Logger, error) {
 	return log, nil
 }
 
+// outputsToTerminal reports whether all of the logger's output goes to
+// standard output or standard error, and those are terminals.
+func (cfg Config) outputsToTerminal() bool {
+	for _, path := range cfg.OutputPaths {
+		var f *os.File
+		switch path {
+		case "stdout":
+			f = os.Stdout
+		case "stderr":
+			f = os.Stderr
+		}
+		if !color.Enabled(f) {
+			return false
+		}
+	}
+	return len(cfg.OutputPaths) > 0
+}
+
 func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
 	opts := []Option{ErrorOutput(errSink)}
 
@@ -326,5 +358,5 @@ func (cfg Config) openSinks() (zapcore.WriteSyncer, zapcore.WriteSyncer, error)
 }
 
 func (cfg Config) buildEncoder() (zapcore.Encoder, error) {
-	return newEncoder(cfg.Encoding, cfg.EncoderConfig)
+	return NewEncoder(cfg.Encoding, cfg.EncoderConfig)
 }
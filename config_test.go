@@ -23,6 +23,7 @@ package zap
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -201,3 +202,40 @@ func TestConfigWithSamplingHook(t *testing.T) {
 	assert.Equal(t, int64(expectDropped), dcount.Load())
 	assert.Equal(t, int64(expectSampled), scount.Load())
 }
+
+func TestConfigPrettyColor(t *testing.T) {
+	temp := filepath.Join(t.TempDir(), "log")
+	cfg := NewDevelopmentConfig()
+	cfg.Encoding = "pretty"
+	cfg.OutputPaths = []string{temp}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Warn("hello")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	out, err := os.ReadFile(temp)
+	require.NoError(t, err, "Failed to read log file.")
+	assert.NotContains(t, string(out), "\x1b[", "Expected no color in output that isn't a terminal.")
+	assert.Contains(t, string(out), " WARN  ", "Unexpected output.")
+
+	assert.False(t, Config{}.outputsToTerminal(), "Expected no terminal without outputs.")
+	assert.False(t, Config{OutputPaths: []string{"stderr", temp}}.outputsToTerminal(), "Expected no terminal with a file output.")
+}
+
+func TestConfigProductionConsoleEscaping(t *testing.T) {
+	temp := filepath.Join(t.TempDir(), "log")
+	cfg := NewProductionConfig()
+	cfg.Encoding = "console"
+	cfg.OutputPaths = []string{temp}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Info("user input\n\x1b[31mforged")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	out, err := os.ReadFile(temp)
+	require.NoError(t, err, "Failed to read log file.")
+	assert.Equal(t, 1, strings.Count(string(out), "\n"), "Expected a single line of output.")
+	assert.Contains(t, string(out), `user input\n\x1b[31mforged`, "Expected the message to be escaped.")
+}
@@ -32,19 +32,41 @@ var (
 	errNoEncoderNameSpecified = errors.New("no encoder name specified")
 
 	_encoderNameToConstructor = map[string]func(zapcore.EncoderConfig) (zapcore.Encoder, error){
+		"cbor": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewCBOREncoder(encoderConfig), nil
+		},
 		"console": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 			return zapcore.NewConsoleEncoder(encoderConfig), nil
 		},
+		"datadog": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewJSONEncoder(datadogEncoderConfig(encoderConfig)), nil
+		},
+		"ecs": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return newECSEncoder(encoderConfig), nil
+		},
+		"gcp": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewJSONEncoder(gcpEncoderConfig(encoderConfig)), nil
+		},
 		"json": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 			return zapcore.NewJSONEncoder(encoderConfig), nil
 		},
+		"msgpack": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewMsgpackEncoder(encoderConfig), nil
+		},
+		"otlp": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewOTLPEncoder(encoderConfig, zapcore.OTLPConfig{}), nil
+		},
+		"pretty": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewPrettyEncoder(encoderConfig), nil
+		},
 	}
 	_encoderMutex sync.RWMutex
 )
 
 // RegisterEncoder registers an encoder constructor, which the Config struct
-// can then reference. By default, the "json" and "console" encoders are
-// registered.
+// can then reference. By default, the "json", "console", "pretty", "cbor",
+// "msgpack", and "otlp" encoders are registered, along with the "gcp",
+// "ecs", and "datadog" presets of the JSON encoder.
 //
 // Attempting to register an encoder whose name is already taken returns an
 // error.
@@ -61,7 +83,9 @@ func RegisterEncoder(name string, constructor func(zapcore.EncoderConfig) (zapco
 	return nil
 }
 
-func newEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+// NewEncoder builds the encoder registered under the given name, as Config
+// does for its Encoding field.
+func NewEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 	if encoderConfig.TimeKey != "" && encoderConfig.EncodeTime == nil {
 		return nil, errors.New("missing EncodeTime in EncoderConfig")
 	}
@@ -29,7 +29,7 @@ import (
 )
 
 func TestRegisterDefaultEncoders(t *testing.T) {
-	testEncodersRegistered(t, "console", "json")
+	testEncodersRegistered(t, "cbor", "console", "datadog", "ecs", "gcp", "json", "msgpack", "otlp", "pretty")
 }
 
 func TestRegisterEncoder(t *testing.T) {
@@ -53,19 +53,19 @@ func TestRegisterEncoderNoName(t *testing.T) {
 func TestNewEncoder(t *testing.T) {
 	testEncoders(func() {
 		assert.NoError(t, RegisterEncoder("foo", newNilEncoder), "expected to be able to register the encoder foo")
-		encoder, err := newEncoder("foo", zapcore.EncoderConfig{})
+		encoder, err := NewEncoder("foo", zapcore.EncoderConfig{})
 		assert.NoError(t, err, "could not create an encoder for the registered name foo")
 		assert.Nil(t, encoder, "the encoder from newNilEncoder is not nil")
 	})
 }
 
 func TestNewEncoderNotRegistered(t *testing.T) {
-	_, err := newEncoder("foo", zapcore.EncoderConfig{})
+	_, err := NewEncoder("foo", zapcore.EncoderConfig{})
 	assert.Error(t, err, "expected an error when trying to create an encoder of an unregistered name")
 }
 
 func TestNewEncoderNoName(t *testing.T) {
-	_, err := newEncoder("", zapcore.EncoderConfig{})
+	_, err := NewEncoder("", zapcore.EncoderConfig{})
 	assert.Equal(t, errNoEncoderNameSpecified, err, "expected an error when creating an encoder with no name")
 }
 
@@ -21,7 +21,16 @@
 // Package color adds coloring functionality for TTY output.
 package color
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
+
+// Text attributes.
+const (
+	Bold  Color = 1
+	Faint Color = 2
+)
 
 // Foreground colors.
 const (
@@ -35,10 +44,21 @@ const (
 	White
 )
 
-// Color represents a text color.
+// Color represents a text color or attribute.
 type Color uint8
 
 // Add adds the coloring to the given string.
 func (c Color) Add(s string) string {
 	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", uint8(c), s)
 }
+
+// Enabled reports whether colored output should be written to f: it must be
+// a terminal, and neither the NO_COLOR convention nor TERM=dumb may ask for
+// plain text.
+func Enabled(f *os.File) bool {
+	if f == nil || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
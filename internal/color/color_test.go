@@ -21,6 +21,8 @@
 package color
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,3 +36,17 @@ func TestColorFormatting(t *testing.T) {
 		"Unexpected colored output.",
 	)
 }
+
+func TestEnabled(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if !assert.NoError(t, err, "Failed to create file.") {
+		return
+	}
+	defer f.Close()
+
+	assert.False(t, Enabled(nil), "Expected no color without a file.")
+	assert.False(t, Enabled(f), "Expected no color for a regular file.")
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, Enabled(os.Stderr), "Expected NO_COLOR to disable color.")
+}
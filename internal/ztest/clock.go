@@ -30,7 +30,7 @@ import (
 // It implements standard time operations,
 // but allows the user to control the passage of time.
 //
-// Use the [Add] method to progress time.
+// Use the [Add] or [AdvanceTo] methods to progress time.
 type MockClock struct {
 	mu  sync.RWMutex
 	now time.Time
@@ -50,6 +50,14 @@ func NewMockClock() *MockClock {
 	}
 }
 
+// NewMockClockAt builds a new mock clock
+// using the given time as the initial time.
+func NewMockClockAt(t time.Time) *MockClock {
+	return &MockClock{
+		now: t,
+	}
+}
+
 // Now reports the current time.
 func (c *MockClock) Now() time.Time {
 	c.mu.RLock()
@@ -117,12 +125,34 @@ func (c *MockClock) Add(d time.Duration) {
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.advance(c.now.Add(d))
+}
+
+// AdvanceTo progresses time to the given instant,
+// resolving other operations waiting for the time to advance
+// as [Add] does.
+//
+// Panics if the instant is before the current time.
+func (c *MockClock) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Check and advance under the same lock,
+	// so that a concurrent Add can't make us overshoot.
+	if t.Before(c.now) {
+		panic("cannot move time backwards")
+	}
+	c.advance(t)
+}
 
+// advance progresses time to newTime, resolving waiters in range.
+// It must be called with c.mu held,
+// and releases it temporarily while waiters run.
+func (c *MockClock) advance(newTime time.Time) {
 	sort.Slice(c.waiters, func(i, j int) bool {
 		return c.waiters[i].until.Before(c.waiters[j].until)
 	})
 
-	newTime := c.now.Add(d)
 	// newTime won't be recorded until the end of this method.
 	// This ensures that any waiters that are resolved
 	// are resolved at the time they were expecting.
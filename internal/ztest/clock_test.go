@@ -78,3 +78,18 @@ func TestMockClock_Add_negative(t *testing.T) {
 	clock := NewMockClock()
 	assert.Panics(t, func() { clock.Add(-1) })
 }
+
+func TestMockClock_AdvanceTo(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewMockClockAt(start)
+	assert.Equal(t, start, clock.Now())
+
+	ticker := clock.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	clock.AdvanceTo(start.Add(90 * time.Second))
+	assert.Equal(t, start.Add(90*time.Second), clock.Now())
+	assert.Equal(t, start.Add(time.Minute), <-ticker.C)
+
+	assert.Panics(t, func() { clock.AdvanceTo(start) })
+}
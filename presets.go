@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// ECSVersion is the version of the Elastic Common Schema that the "ecs"
+// encoding conforms to, which it reports in the ecs.version field.
+const ECSVersion = "1.6.0"
+
+// NewGCPEncoderConfig returns an opinionated EncoderConfig for the structured
+// logs of Google Cloud Logging. Entries carry a "severity" and "message",
+// callers are encoded as a "logging.googleapis.com/sourceLocation" object,
+// and stacktraces go in "stack_trace", where Error Reporting looks for them.
+//
+// The "gcp" encoding is a JSON encoder using this configuration.
+func NewGCPEncoderConfig() zapcore.EncoderConfig {
+	return gcpEncoderConfig(NewProductionEncoderConfig())
+}
+
+// NewECSEncoderConfig returns an opinionated EncoderConfig for logs in the
+// Elastic Common Schema. Entries carry an "@timestamp", "log.level",
+// "log.logger", and "message", callers are encoded as a nested "log.origin"
+// object, and stacktraces go in "error.stack_trace".
+//
+// The "ecs" encoding is a JSON encoder using this configuration, which also
+// adds the required ecs.version field to every entry, and writes errors
+// logged with Error as ECS error objects, with their message and type.
+// Encoders built from this configuration by other means should add the
+// version themselves, with
+//
+//	zap.String("ecs.version", zap.ECSVersion)
+func NewECSEncoderConfig() zapcore.EncoderConfig {
+	return ecsEncoderConfig(NewProductionEncoderConfig())
+}
+
+// NewDatadogEncoderConfig returns an opinionated EncoderConfig for logs
+// collected by Datadog. Entries carry a "timestamp", "status", and "message",
+// and the logger's name, function name, and stacktraces go in the standard
+// "logger.name", "logger.method_name", and "error.stack" attributes.
+// Durations are in nanoseconds, as Datadog's duration attribute expects.
+//
+// The "datadog" encoding is a JSON encoder using this configuration.
+func NewDatadogEncoderConfig() zapcore.EncoderConfig {
+	return datadogEncoderConfig(NewProductionEncoderConfig())
+}
+
+// The presets' encodings apply them on top of the configured EncoderConfig,
+// replacing its keys and encoders but keeping its other options.
+
+func gcpEncoderConfig(cfg zapcore.EncoderConfig) zapcore.EncoderConfig {
+	cfg.TimeKey = "time"
+	cfg.LevelKey = "severity"
+	cfg.NameKey = "logger"
+	cfg.CallerKey = "logging.googleapis.com/sourceLocation"
+	cfg.FunctionKey = zapcore.OmitKey
+	cfg.MessageKey = "message"
+	cfg.StacktraceKey = "stack_trace"
+	cfg.EncodeLevel = zapcore.GCPSeverityEncoder
+	cfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	cfg.EncodeDuration = zapcore.SecondsDurationEncoder
+	cfg.EncodeCaller = zapcore.GCPSourceLocationEncoder
+	cfg.EncodeName = zapcore.FullNameEncoder
+	return cfg
+}
+
+func ecsEncoderConfig(cfg zapcore.EncoderConfig) zapcore.EncoderConfig {
+	cfg.TimeKey = "@timestamp"
+	cfg.LevelKey = "log.level"
+	cfg.NameKey = "log.logger"
+	cfg.CallerKey = "log.origin"
+	cfg.FunctionKey = zapcore.OmitKey
+	cfg.MessageKey = "message"
+	cfg.StacktraceKey = "error.stack_trace"
+	cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	cfg.EncodeDuration = zapcore.NanosDurationEncoder
+	cfg.EncodeCaller = zapcore.ECSCallerEncoder
+	cfg.EncodeName = zapcore.FullNameEncoder
+	return cfg
+}
+
+func datadogEncoderConfig(cfg zapcore.EncoderConfig) zapcore.EncoderConfig {
+	cfg.TimeKey = "timestamp"
+	cfg.LevelKey = "status"
+	cfg.NameKey = "logger.name"
+	cfg.CallerKey = "caller"
+	cfg.FunctionKey = "logger.method_name"
+	cfg.MessageKey = "message"
+	cfg.StacktraceKey = "error.stack"
+	cfg.EncodeLevel = zapcore.DatadogStatusEncoder
+	cfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	cfg.EncodeDuration = zapcore.NanosDurationEncoder
+	cfg.EncodeCaller = zapcore.ShortCallerEncoder
+	cfg.EncodeName = zapcore.FullNameEncoder
+	return cfg
+}
+
+func newECSEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	enc := zapcore.NewJSONEncoder(ecsEncoderConfig(cfg))
+	enc.AddString("ecs.version", ECSVersion)
+	return ecsEncoder{enc}
+}
+
+// ecsEncoder writes the errors of log entries as ECS error objects.
+type ecsEncoder struct {
+	zapcore.Encoder
+}
+
+func (enc ecsEncoder) Clone() zapcore.Encoder {
+	return ecsEncoder{enc.Encoder.Clone()}
+}
+
+func (enc ecsEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	copied := false
+	for i, f := range fields {
+		err, ok := f.Interface.(error)
+		if f.Type != zapcore.ErrorType || f.Key != "error" || !ok {
+			continue
+		}
+		if !copied {
+			// Don't modify the caller's fields.
+			fields = append([]zapcore.Field(nil), fields...)
+			copied = true
+		}
+		fields[i] = Object(f.Key, ecsError{err})
+	}
+	return enc.Encoder.EncodeEntry(ent, fields)
+}
+
+type ecsError struct {
+	err error
+}
+
+func (e ecsError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", e.err.Error())
+	enc.AddString("type", fmt.Sprintf("%T", e.err))
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var _updateGolden = flag.Bool("update", false, "update .golden files")
+
+func TestEncoderPresetsGolden(t *testing.T) {
+	caller := zapcore.EntryCaller{
+		Defined:  true,
+		PC:       1,
+		File:     "/src/app/handler.go",
+		Line:     42,
+		Function: "app.(*Handler).ServeHTTP",
+	}
+	ts := time.Date(2026, 3, 14, 15, 9, 26, 535897932, time.UTC)
+	entries := []struct {
+		ent    zapcore.Entry
+		fields []zapcore.Field
+	}{
+		{
+			ent: zapcore.Entry{Level: DebugLevel, Time: ts, Message: "debug"},
+		},
+		{
+			ent: zapcore.Entry{
+				Level:      InfoLevel,
+				Time:       ts,
+				LoggerName: "app.http",
+				Message:    "served request",
+				Caller:     caller,
+			},
+			fields: []zapcore.Field{
+				String("path", "/users"),
+				Int("status", 200),
+				Duration("latency", 1500*time.Microsecond),
+			},
+		},
+		{
+			ent: zapcore.Entry{Level: WarnLevel, Time: ts, Message: "slow", Caller: caller},
+		},
+		{
+			ent: zapcore.Entry{
+				Level:   ErrorLevel,
+				Time:    ts,
+				Message: "failed",
+				Caller:  caller,
+				Stack:   "app.(*Handler).ServeHTTP\n\t/src/app/handler.go:42",
+			},
+			fields: []zapcore.Field{Error(errors.New("boom"))},
+		},
+		{ent: zapcore.Entry{Level: DPanicLevel, Time: ts, Message: "dpanic"}},
+		{ent: zapcore.Entry{Level: PanicLevel, Time: ts, Message: "panic"}},
+		{ent: zapcore.Entry{Level: FatalLevel, Time: ts, Message: "fatal"}},
+	}
+
+	for _, name := range []string{"gcp", "ecs", "datadog"} {
+		t.Run(name, func(t *testing.T) {
+			// Presets replace the configured keys, so start from a
+			// configuration that differs from all of them.
+			enc, err := NewEncoder(name, NewDevelopmentEncoderConfig())
+			require.NoError(t, err, "Unexpected error constructing encoder.")
+
+			var got bytes.Buffer
+			for _, e := range entries {
+				buf, err := enc.EncodeEntry(e.ent, e.fields)
+				require.NoError(t, err, "Unexpected encoding error.")
+				got.Write(buf.Bytes())
+				buf.Free()
+			}
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *_updateGolden {
+				require.NoError(t, os.WriteFile(golden, got.Bytes(), 0o644), "Failed to update golden file.")
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err, "Failed to read golden file.")
+			assert.Equal(t, string(want), got.String(), "Output doesn't match %v.", golden)
+		})
+	}
+}
+
+func TestEncoderPresetConfigs(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  zapcore.EncoderConfig
+	}{
+		{"gcp", NewGCPEncoderConfig()},
+		{"ecs", NewECSEncoderConfig()},
+		{"datadog", NewDatadogEncoderConfig()},
+	}
+
+	ent := zapcore.Entry{
+		Level:      WarnLevel,
+		Time:       time.Unix(0, 0),
+		LoggerName: "name",
+		Message:    "msg",
+		Caller:     zapcore.EntryCaller{Defined: true, File: "file.go", Line: 1, Function: "f"},
+		Stack:      "stack",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registered, err := NewEncoder(tt.name, NewProductionEncoderConfig())
+			require.NoError(t, err, "Unexpected error constructing encoder.")
+			if tt.name == "ecs" {
+				// The registered encoding adds the version itself.
+				registered = zapcore.NewJSONEncoder(tt.cfg)
+			}
+
+			want, err := registered.EncodeEntry(ent, nil)
+			require.NoError(t, err, "Unexpected encoding error.")
+			got, err := zapcore.NewJSONEncoder(tt.cfg).EncodeEntry(ent, nil)
+			require.NoError(t, err, "Unexpected encoding error.")
+			assert.Equal(t, want.String(), got.String(), "Expected the constructor to match the registered encoding.")
+		})
+	}
+}
@@ -27,13 +27,19 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 )
 
-const schemeFile = "file"
+const (
+	schemeFile     = "file"
+	schemeFailover = "failover"
+)
 
 var _sinkRegistry = newSinkRegistry()
 
@@ -68,6 +74,7 @@ func newSinkRegistry() *sinkRegistry {
 	}
 	// Infallible operation: the registry is empty, so we can't have a conflict.
 	_ = sr.RegisterSink(schemeFile, sr.newFileSinkFromURL)
+	_ = sr.RegisterSink(schemeFailover, sr.newFailoverSink)
 	return sr
 }
 
@@ -121,8 +128,8 @@ func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
 //
 // All schemes must be ASCII, valid under section 0.1 of RFC 3986
 // (https://tools.ietf.org/html/rfc3983#section-3.1), and must not already
-// have a factory registered. Zap automatically registers a factory for the
-// "file" scheme.
+// have a factory registered. Zap automatically registers factories for the
+// "file" and "failover" schemes.
 func RegisterSink(scheme string, factory func(*url.URL) (Sink, error)) error {
 	return _sinkRegistry.RegisterSink(scheme, factory)
 }
@@ -158,6 +165,71 @@ func (sr *sinkRegistry) newFileSinkFromPath(path string) (Sink, error) {
 	return sr.openFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
 }
 
+type failoverSink struct {
+	*zapcore.FailoverWriteSyncer
+	primary, secondary Sink
+}
+
+func (s failoverSink) Close() error {
+	return multierr.Append(s.primary.Close(), s.secondary.Close())
+}
+
+// newFailoverSink opens a sink that fails over from one sink to another,
+// given a URL like
+//
+//	failover://?primary=<url>&secondary=<url>&threshold=3&probe=5s
+func (sr *sinkRegistry) newFailoverSink(u *url.URL) (Sink, error) {
+	if u.User != nil || u.Host != "" || u.Path != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("failover URLs only allow query parameters: got %v", u)
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse failover query %q: %v", u.RawQuery, err)
+	}
+	for key, vals := range query {
+		switch key {
+		case "primary", "secondary", "threshold", "probe":
+		default:
+			return nil, fmt.Errorf("unknown failover parameter %q", key)
+		}
+		if len(vals) > 1 {
+			return nil, fmt.Errorf("failover parameter %q given more than once", key)
+		}
+	}
+
+	ws := &zapcore.FailoverWriteSyncer{}
+	if v := query.Get("threshold"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("failover threshold must be a positive integer: got %q", v)
+		}
+		ws.FailureThreshold = n
+	}
+	if v := query.Get("probe"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("failover probe interval must be a positive duration: got %q", v)
+		}
+		ws.ProbeInterval = d
+	}
+
+	primaryURL, secondaryURL := query.Get("primary"), query.Get("secondary")
+	if primaryURL == "" || secondaryURL == "" {
+		return nil, fmt.Errorf("failover URLs need primary and secondary sinks: got %v", u)
+	}
+	primary, err := sr.newSink(primaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("open primary sink %q: %w", primaryURL, err)
+	}
+	secondary, err := sr.newSink(secondaryURL)
+	if err != nil {
+		_ = primary.Close()
+		return nil, fmt.Errorf("open secondary sink %q: %w", secondaryURL, err)
+	}
+	ws.Primary, ws.Secondary = primary, secondary
+	return failoverSink{ws, primary, secondary}, nil
+}
+
 func normalizeScheme(s string) (string, error) {
 	// https://tools.ietf.org/html/rfc3986#section-3.1
 	s = strings.ToLower(s)
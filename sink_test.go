@@ -22,6 +22,7 @@ package zap
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/url"
 	"strings"
@@ -105,3 +106,61 @@ func TestRegisterSinkErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestFailoverSink(t *testing.T) {
+	stubSinkRegistry(t)
+
+	var primary, secondary bytes.Buffer
+	down := true
+	require.NoError(t, RegisterSink("primary", func(*url.URL) (Sink, error) {
+		return nopCloserSink{zapcore.AddSync(writerFunc(func(p []byte) (int, error) {
+			if down {
+				return 0, errors.New("unavailable")
+			}
+			return primary.Write(p)
+		}))}, nil
+	}), "Failed to register primary scheme.")
+	require.NoError(t, RegisterSink("secondary", func(*url.URL) (Sink, error) {
+		return nopCloserSink{zapcore.AddSync(&secondary)}, nil
+	}), "Failed to register secondary scheme.")
+
+	sink, closeSink, err := Open("failover://?primary=primary://agent&secondary=" + url.QueryEscape("secondary://local?x=1") + "&threshold=1&probe=1h")
+	require.NoError(t, err, "Unexpected error opening failover sink.")
+	defer closeSink()
+
+	_, err = sink.Write([]byte("a"))
+	require.NoError(t, err, "Unexpected error writing to failover sink.")
+	down = false
+	_, err = sink.Write([]byte("b"))
+	require.NoError(t, err, "Unexpected error writing to failover sink.")
+	assert.Empty(t, primary.String(), "Expected the primary to stay unhealthy until the next probe.")
+	assert.Equal(t, "ab", secondary.String(), "Expected writes to fail over to the secondary.")
+}
+
+func TestFailoverSinkErrors(t *testing.T) {
+	tests := []struct {
+		url string
+		err string
+	}{
+		{"failover://host?primary=stdout&secondary=stderr", "only allow query parameters"},
+		{"failover://?primary=stdout", "need primary and secondary"},
+		{"failover://?primary=stdout&secondary=stderr&extra=1", `unknown failover parameter "extra"`},
+		{"failover://?primary=stdout&primary=stderr&secondary=stderr", `"primary" given more than once`},
+		{"failover://?primary=stdout&secondary=stderr&threshold=0", "threshold must be a positive integer"},
+		{"failover://?primary=stdout&secondary=stderr&probe=soon", "probe interval must be a positive duration"},
+		{"failover://?primary=nope://x&secondary=stderr", `open primary sink "nope://x"`},
+		{"failover://?primary=stdout&secondary=nope://x", `open secondary sink "nope://x"`},
+		{"failover://?primary=%zz", "can't parse failover query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, err := newSinkRegistry().newSink(tt.url)
+			assert.ErrorContains(t, err, tt.err, "Unexpected error.")
+		})
+	}
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
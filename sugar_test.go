@@ -600,3 +600,95 @@ func BenchmarkLnSugarSingleStrArg(b *testing.B) {
 		}
 	})
 }
+
+func FuzzSweetenFields(f *testing.F) {
+	// Each byte of kinds picks the type of one argument: a string, an int,
+	// an error, a strongly-typed field, nil, or a slice (an invalid key).
+	f.Add([]byte{0, 1}, "foo")
+	f.Add([]byte{3, 0, 1, 2}, "")
+	f.Add([]byte{2, 2, 0}, "bar")
+	f.Add([]byte{1, 0, 5, 4, 0, 3, 2}, "baz")
+	f.Add([]byte{0, 2, 4, 5, 5, 3, 0}, "\x00\n")
+
+	f.Fuzz(func(t *testing.T, kinds []byte, s string) {
+		if len(kinds) > 64 {
+			kinds = kinds[:64]
+		}
+		args := make([]interface{}, len(kinds))
+		for i, k := range kinds {
+			switch k % 6 {
+			case 0:
+				args[i] = s
+			case 1:
+				args[i] = i
+			case 2:
+				args[i] = fmt.Errorf("error %d", i)
+			case 3:
+				args[i] = Int("field", i)
+			case 4:
+				args[i] = nil
+			case 5:
+				args[i] = []string{s}
+			}
+		}
+
+		// Work out what sweetenFields should do with these arguments.
+		var (
+			wantFields, wantMultipleErrs, wantInvalid int
+			seenError, wantDangling                   bool
+		)
+		for i := 0; i < len(args); {
+			switch args[i].(type) {
+			case Field:
+				wantFields++
+				i++
+				continue
+			case error:
+				if seenError {
+					wantMultipleErrs++
+				} else {
+					seenError = true
+					wantFields++
+				}
+				i++
+				continue
+			}
+			if i == len(args)-1 {
+				wantDangling = true
+				break
+			}
+			if _, ok := args[i].(string); ok {
+				wantFields++
+			} else {
+				wantInvalid++
+			}
+			i += 2
+		}
+
+		withSugar(t, DebugLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+			logger.Infow("msg", args...)
+
+			main := logs.FilterMessage("msg").AllUntimed()
+			require.Equal(t, 1, len(main), "Expected exactly one entry with the logged message.")
+			assert.Equal(t, wantFields, len(main[0].Context), "Unexpected number of fields.")
+
+			assert.Equal(t, wantMultipleErrs, logs.FilterMessage(_multipleErrMsg).Len(),
+				"Unexpected number of errors logged for extra errors.")
+
+			dangling := logs.FilterMessage(_oddNumberErrMsg).Len()
+			assert.Equal(t, wantDangling, dangling > 0, "Unexpected error logged for a dangling key.")
+			assert.LessOrEqual(t, dangling, 1, "Expected at most one error for a dangling key.")
+
+			invalid := logs.FilterMessage(_nonStringKeyErrMsg).AllUntimed()
+			if wantInvalid == 0 {
+				assert.Empty(t, invalid, "Unexpected error logged for invalid key-value pairs.")
+				return
+			}
+			require.Equal(t, 1, len(invalid), "Expected one error for invalid key-value pairs.")
+			require.Equal(t, 1, len(invalid[0].Context), "Expected one field in error entry context.")
+			enc := zapcore.NewMapObjectEncoder()
+			invalid[0].Context[0].AddTo(enc)
+			assert.Len(t, enc.Fields["invalid"], wantInvalid, "Unexpected number of invalid key-value pairs.")
+		})
+	})
+}
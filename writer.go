@@ -47,6 +47,13 @@ import (
 // a scheme, the special paths "stdout" and "stderr" are interpreted as
 // os.Stdout and os.Stderr. When specified without a scheme, relative file
 // paths also work.
+//
+// URLs with the "failover" scheme write to a primary sink, falling back to
+// a secondary sink while the primary is failing. Both are given as
+// query-escaped URLs, along with optional settings for the underlying
+// zapcore.FailoverWriteSyncer:
+//
+//	failover://?primary=<url>&secondary=<url>&threshold=3&probe=5s
 func Open(paths ...string) (zapcore.WriteSyncer, func(), error) {
 	writers, closeAll, err := open(paths)
 	if err != nil {
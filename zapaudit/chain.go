@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapaudit provides tamper-evident audit logging.
+//
+// An audit core writes each entry with a sequence number, the ID of the key
+// used to sign it, and an HMAC chaining it to the entry before. Without the
+// key, entries can't be modified, inserted, removed, or reordered without
+// Verify noticing. Truncating the end of a log can't be detected from the
+// log alone, so compare the last sequence number Verify reports with one
+// recorded elsewhere if that matters.
+//
+// Audit cores sign the output of JSON encoders, including the "gcp", "ecs",
+// and "datadog" presets.
+//
+// Write audit events with a Logger rather than a zap.Logger to make sure
+// they're never sampled and are on disk before the application proceeds.
+package zapaudit // import "go.uber.org/zap/zapaudit"
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the fields that audit cores add to each entry.
+const (
+	SequenceKey = "audit.seq"
+	KeyIDKey    = "audit.kid"
+	MACKey      = "audit.mac"
+)
+
+var (
+	_pool = buffer.NewPool()
+
+	errNoSecret = errors.New("audit key has no secret")
+	errNotJSON  = errors.New("audit entries must be encoded as JSON objects")
+)
+
+// A Key signs audit entries. Its ID is written with each entry so that
+// verifiers can find the secret, which lets keys be rotated without
+// breaking the chain.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// A Chain links the entries written by audit cores. Cores sharing a chain
+// must write to the same output, since the chain's order is the order
+// entries are written in.
+type Chain struct {
+	mu  sync.Mutex
+	key Key
+	seq uint64
+	mac []byte
+}
+
+// NewChain starts a chain whose entries are signed with the given key.
+func NewChain(key Key) (*Chain, error) {
+	c := &Chain{}
+	if err := c.Rotate(key); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Rotate signs the following entries with a new key.
+func (c *Chain) Rotate(key Key) error {
+	if len(key.Secret) == 0 {
+		return errNoSecret
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.key = key
+	return nil
+}
+
+// Resume continues the chain of an existing log, as reported by Verify, so
+// that entries appended to the log link to the ones already there. A chain
+// that isn't resumed starts over at sequence number 1, which Verify reports
+// as a restart.
+func (c *Chain) Resume(seq uint64, mac []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq = seq
+	c.mac = append([]byte(nil), mac...)
+}
+
+// closingBrace returns the index of the brace closing a line's JSON object,
+// or -1 if the line isn't a JSON object followed by a line ending.
+func closingBrace(line []byte) int {
+	if !bytes.HasPrefix(bytes.TrimSpace(line), []byte("{")) {
+		return -1
+	}
+	end := bytes.LastIndexByte(line, '}')
+	if end < 0 || len(bytes.TrimSpace(line[end+1:])) > 0 {
+		return -1
+	}
+	return end
+}
+
+// sign computes the MAC of an entry, given the MAC of the entry before.
+func sign(secret, prev, entry []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(prev)
+	h.Write(entry)
+	return h.Sum(nil)
+}
+
+type core struct {
+	zapcore.LevelEnabler
+	enc   zapcore.Encoder
+	out   zapcore.WriteSyncer
+	chain *Chain
+}
+
+// NewCore creates a Core that writes entries signed by the chain. The
+// encoder must write each entry as a JSON object.
+func NewCore(enc zapcore.Encoder, ws zapcore.WriteSyncer, enab zapcore.LevelEnabler, chain *Chain) zapcore.Core {
+	return &core{
+		LevelEnabler: enab,
+		enc:          enc,
+		out:          ws,
+		chain:        chain,
+	}
+}
+
+func (c *core) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := c.clone()
+	for i := range fields {
+		fields[i].AddTo(clone.enc)
+	}
+	return clone
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+
+	seq, key := c.chain.seq+1, c.chain.key
+	fields = append(fields[:len(fields):len(fields)],
+		zap.Uint64(SequenceKey, seq),
+		zap.String(KeyIDKey, key.ID),
+	)
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	// Sign everything before the object's closing brace, and add the MAC
+	// as the last field.
+	line := buf.Bytes()
+	end := closingBrace(line)
+	if end < 0 {
+		return errNotJSON
+	}
+	mac := sign(key.Secret, c.chain.mac, line[:end])
+
+	sealed := _pool.Get()
+	defer sealed.Free()
+	_, _ = sealed.Write(line[:end])
+	sealed.AppendString(`,"` + MACKey + `":"`)
+	var hexMAC [2 * sha256.Size]byte
+	hex.Encode(hexMAC[:], mac)
+	_, _ = sealed.Write(hexMAC[:])
+	sealed.AppendString(`"`)
+	_, _ = sealed.Write(line[end:])
+
+	if _, err := c.out.Write(sealed.Bytes()); err != nil {
+		// The entry may not have been written, so don't link to it.
+		return err
+	}
+	c.chain.seq, c.chain.mac = seq, mac
+
+	if ent.Level > zapcore.ErrorLevel {
+		// Since we may be crashing the program, sync the output.
+		// Ignore Sync errors, pending a clean solution to issue #370.
+		_ = c.Sync()
+	}
+	return nil
+}
+
+func (c *core) Sync() error {
+	return c.out.Sync()
+}
+
+func (c *core) clone() *core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		enc:          c.enc.Clone(),
+		out:          c.out,
+		chain:        c.chain,
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+var (
+	_key1 = Key{ID: "k1", Secret: []byte("first secret")}
+	_key2 = Key{ID: "k2", Secret: []byte("second secret")}
+	_keys = map[string][]byte{"k1": _key1.Secret, "k2": _key2.Secret}
+)
+
+func testEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+}
+
+func newTestChain(t testing.TB, key Key) *Chain {
+	chain, err := NewChain(key)
+	require.NoError(t, err, "Unexpected error creating chain.")
+	return chain
+}
+
+// writeLog logs each message to buf through an audit core.
+func writeLog(t testing.TB, buf *bytes.Buffer, chain *Chain, msgs ...string) {
+	logger := zap.New(NewCore(testEncoder(), zapcore.AddSync(buf), zapcore.DebugLevel, chain))
+	for _, msg := range msgs {
+		logger.Info(msg)
+	}
+}
+
+func TestCoreSealsEntries(t *testing.T) {
+	var buf bytes.Buffer
+	chain := newTestChain(t, _key1)
+	logger := zap.New(NewCore(testEncoder(), zapcore.AddSync(&buf), zapcore.InfoLevel, chain))
+
+	logger.Debug("dropped")
+	logger.Info("first", zap.String("user", "alice"))
+	logger.With(zap.String("request", "r1")).Warn("second")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "Expected an entry for each enabled message.")
+	for i, line := range lines {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), "Expected entry %d to be valid JSON.", i)
+		assert.Equal(t, float64(i+1), fields[SequenceKey], "Unexpected sequence number.")
+		assert.Equal(t, "k1", fields[KeyIDKey], "Unexpected key ID.")
+		assert.Regexp(t, `,"audit.mac":"[0-9a-f]{64}"}$`, line, "Expected the MAC to be the last field.")
+	}
+	assert.Contains(t, lines[0], `"user":"alice"`, "Expected fields in the entry.")
+	assert.Contains(t, lines[1], `"request":"r1"`, "Expected context in the entry.")
+
+	report, err := Verify(&buf, _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+	assert.True(t, report.OK(), "Unexpected problems: %v", report.Problems)
+	assert.Equal(t, 2, report.Entries, "Unexpected number of entries.")
+}
+
+func TestCoreRotate(t *testing.T) {
+	var buf bytes.Buffer
+	chain := newTestChain(t, _key1)
+	writeLog(t, &buf, chain, "one", "two")
+	require.NoError(t, chain.Rotate(_key2), "Unexpected error rotating key.")
+	writeLog(t, &buf, chain, "three")
+
+	report, err := Verify(bytes.NewReader(buf.Bytes()), _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+	assert.True(t, report.OK(), "Unexpected problems: %v", report.Problems)
+
+	report, err = Verify(bytes.NewReader(buf.Bytes()), map[string][]byte{"k1": _key1.Secret})
+	require.NoError(t, err, "Unexpected error verifying log.")
+	require.Len(t, report.Problems, 1, "Expected a problem verifying without the rotated key.")
+	assert.Equal(t, UnknownKey, report.Problems[0].Kind, "Unexpected problem.")
+	assert.Equal(t, 3, report.Problems[0].Line, "Unexpected line.")
+
+	assert.Error(t, chain.Rotate(Key{ID: "k3"}), "Expected an error rotating to a key without a secret.")
+}
+
+func TestCoreResume(t *testing.T) {
+	var buf bytes.Buffer
+	writeLog(t, &buf, newTestChain(t, _key1), "one", "two")
+
+	report, err := Verify(bytes.NewReader(buf.Bytes()), _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+
+	chain := newTestChain(t, _key1)
+	chain.Resume(report.LastSequence, report.LastMAC)
+	writeLog(t, &buf, chain, "three")
+
+	report, err = Verify(bytes.NewReader(buf.Bytes()), _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+	assert.True(t, report.OK(), "Unexpected problems: %v", report.Problems)
+	assert.Equal(t, uint64(3), report.LastSequence, "Unexpected last sequence number.")
+}
+
+func TestCoreWriteErrors(t *testing.T) {
+	t.Run("not JSON", func(t *testing.T) {
+		enc := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+		core := NewCore(enc, zapcore.AddSync(&bytes.Buffer{}), zapcore.DebugLevel, newTestChain(t, _key1))
+		assert.Equal(t, errNotJSON, core.Write(zapcore.Entry{Message: "hello"}, nil), "Expected an error for console output.")
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		var buf bytes.Buffer
+		failing := true
+		ws := zapcore.AddSync(writerFunc(func(p []byte) (int, error) {
+			if failing {
+				return 0, errors.New("disk full")
+			}
+			return buf.Write(p)
+		}))
+		chain := newTestChain(t, _key1)
+		core := NewCore(testEncoder(), ws, zapcore.DebugLevel, chain)
+
+		assert.Error(t, core.Write(zapcore.Entry{Message: "lost"}, nil), "Expected the write error.")
+		failing = false
+		require.NoError(t, core.Write(zapcore.Entry{Message: "kept"}, nil), "Unexpected error.")
+
+		// The lost entry isn't part of the chain.
+		report, err := Verify(&buf, _keys)
+		require.NoError(t, err, "Unexpected error verifying log.")
+		assert.True(t, report.OK(), "Unexpected problems: %v", report.Problems)
+		assert.Equal(t, uint64(1), report.LastSequence, "Unexpected last sequence number.")
+	})
+
+	t.Run("no secret", func(t *testing.T) {
+		_, err := NewChain(Key{ID: "empty"})
+		assert.Equal(t, errNoSecret, err, "Expected an error for a key without a secret.")
+	})
+}
+
+func TestCoreConcurrentWrites(t *testing.T) {
+	var buf zaptest.Buffer
+	chain := newTestChain(t, _key1)
+	logger := zap.New(NewCore(testEncoder(), &buf, zapcore.DebugLevel, chain))
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for j := 0; j < 50; j++ {
+				logger.Info("concurrent")
+			}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+
+	report, err := Verify(strings.NewReader(buf.String()), _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+	assert.True(t, report.OK(), "Unexpected problems: %v", report.Problems)
+	assert.Equal(t, 200, report.Entries, "Unexpected number of entries.")
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapaudit"
+	"go.uber.org/zap/zapcore"
+)
+
+func Example() {
+	key := zapaudit.Key{ID: "2024-01", Secret: []byte("keep me somewhere safe")}
+	chain, err := zapaudit.NewChain(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	logger := zap.New(zapaudit.NewCore(enc, zapcore.AddSync(&out), zapcore.InfoLevel, chain))
+	logger.Info("granted access", zap.String("user", "alice"))
+	logger.Info("revoked access", zap.String("user", "alice"))
+
+	// Change who lost access.
+	tampered := strings.Replace(out.String(), `"revoked access","user":"alice"`, `"revoked access","user":"bob"`, 1)
+
+	report, err := zapaudit.Verify(strings.NewReader(tampered), map[string][]byte{key.ID: key.Secret})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if p, ok := report.FirstBrokenLink(); ok {
+		fmt.Println(p)
+	}
+	// Output:
+	// line 2 (seq 2): broken link: MAC doesn't match
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// A Logger writes audit events, returning only once they're durable.
+//
+// Unlike a zap.Logger, a Logger writes entries directly to its core,
+// bypassing Check, so they're never sampled or filtered by level. After
+// writing an entry it syncs the core, which flushes any buffers and fsyncs
+// files, and returns any error from either step to the caller rather than
+// to an ErrorOutput. A nil error means the event is on disk.
+type Logger struct {
+	core   zapcore.Core
+	clock  zapcore.Clock
+	commit *committer
+}
+
+// An Option configures a Logger.
+type Option interface {
+	apply(*Logger)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*Logger)
+
+func (f optionFunc) apply(log *Logger) {
+	f(log)
+}
+
+// GroupCommit lets concurrent writes share a sync. After an entry is
+// written, the Logger waits up to the window for others to join before
+// syncing them all, trading latency for fewer fsyncs. Writes that arrive
+// while a sync is in progress always share the next one, even with a zero
+// window.
+//
+// Without GroupCommit, each entry is synced on its own.
+func GroupCommit(window time.Duration) Option {
+	return optionFunc(func(log *Logger) {
+		log.commit.window = window
+		log.commit.grouped = true
+	})
+}
+
+// WithClock specifies the clock used to timestamp entries.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(log *Logger) {
+		log.clock = clock
+	})
+}
+
+// NewLogger creates a Logger that writes to the given core, which is
+// usually created by NewCore.
+func NewLogger(core zapcore.Core, opts ...Option) *Logger {
+	log := &Logger{
+		core:   core,
+		clock:  zapcore.DefaultClock,
+		commit: &committer{sync: core.Sync},
+	}
+	log.commit.cond.L = &log.commit.mu
+	for _, opt := range opts {
+		opt.apply(log)
+	}
+	return log
+}
+
+// With creates a child logger and adds structured context to it. Fields
+// added to the child don't affect the parent, and vice versa. Children
+// share their parent's syncs.
+func (log *Logger) With(fields ...zapcore.Field) *Logger {
+	if len(fields) == 0 {
+		return log
+	}
+	l := *log
+	l.core = log.core.With(fields)
+	return &l
+}
+
+// Log writes an entry at the given level, returning once it's durable.
+func (log *Logger) Log(lvl zapcore.Level, msg string, fields ...zapcore.Field) error {
+	ent := zapcore.Entry{
+		Level:   lvl,
+		Time:    log.clock.Now(),
+		Message: msg,
+	}
+	if err := log.core.Write(ent, fields); err != nil {
+		return err
+	}
+	return log.commit.wait()
+}
+
+// Info writes an entry at InfoLevel, returning once it's durable.
+func (log *Logger) Info(msg string, fields ...zapcore.Field) error {
+	return log.Log(zapcore.InfoLevel, msg, fields...)
+}
+
+// Warn writes an entry at WarnLevel, returning once it's durable.
+func (log *Logger) Warn(msg string, fields ...zapcore.Field) error {
+	return log.Log(zapcore.WarnLevel, msg, fields...)
+}
+
+// Error writes an entry at ErrorLevel, returning once it's durable.
+func (log *Logger) Error(msg string, fields ...zapcore.Field) error {
+	return log.Log(zapcore.ErrorLevel, msg, fields...)
+}
+
+// committer syncs written entries, grouping them if asked to.
+type committer struct {
+	sync    func() error
+	grouped bool
+	window  time.Duration
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	syncing bool
+	next    *commit // entries waiting for a sync that hasn't started
+}
+
+// A commit is a group of entries synced together.
+type commit struct {
+	done bool
+	err  error
+}
+
+// wait returns once entries written so far are synced.
+func (c *committer) wait() error {
+	if !c.grouped {
+		return c.sync()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next == nil {
+		c.next = &commit{}
+	}
+	b := c.next
+	for !b.done {
+		if c.syncing {
+			c.cond.Wait()
+			continue
+		}
+
+		// Nobody's syncing, so lead this commit.
+		c.syncing = true
+		c.mu.Unlock()
+		if c.window > 0 {
+			time.Sleep(c.window)
+		}
+		c.mu.Lock()
+		c.next = nil
+		c.mu.Unlock()
+
+		err := c.sync()
+
+		c.mu.Lock()
+		b.done, b.err = true, err
+		c.syncing = false
+		c.cond.Broadcast()
+	}
+	return b.err
+}
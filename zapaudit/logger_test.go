@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+// syncCounter is a WriteSyncer that counts syncs.
+type syncCounter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	synced  int // bytes written before the last sync
+	syncs   int
+	delay   time.Duration
+	syncErr error
+}
+
+func (s *syncCounter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncCounter) Sync() error {
+	time.Sleep(s.delay)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncs++
+	s.synced = s.buf.Len()
+	return s.syncErr
+}
+
+func (s *syncCounter) lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Split(strings.TrimSuffix(s.buf.String(), "\n"), "\n")
+}
+
+func newTestLogger(ws zapcore.WriteSyncer, opts ...Option) *Logger {
+	core := zapcore.NewCore(testEncoder(), ws, zapcore.ErrorLevel)
+	return NewLogger(core, opts...)
+}
+
+func TestLoggerSyncsEachEntry(t *testing.T) {
+	ws := &syncCounter{}
+	clock := ztest.NewMockClock()
+	logger := newTestLogger(ws, WithClock(clock)).With(zap.String("app", "test"))
+
+	require.NoError(t, logger.Info("one"), "Unexpected error logging.")
+	assert.Equal(t, 1, ws.syncs, "Expected a sync after the entry.")
+	assert.Equal(t, ws.buf.Len(), ws.synced, "Expected the entry to be written before the sync.")
+
+	require.NoError(t, logger.Warn("two"), "Unexpected error logging.")
+	require.NoError(t, logger.Error("three"), "Unexpected error logging.")
+	require.NoError(t, logger.Log(zapcore.DebugLevel, "four"), "Unexpected error logging.")
+	assert.Equal(t, 4, ws.syncs, "Expected a sync after each entry.")
+
+	// Entries bypass the core's level.
+	assert.Equal(t, []string{
+		`{"level":"info","msg":"one","app":"test"}`,
+		`{"level":"warn","msg":"two","app":"test"}`,
+		`{"level":"error","msg":"three","app":"test"}`,
+		`{"level":"debug","msg":"four","app":"test"}`,
+	}, ws.lines(), "Unexpected output.")
+}
+
+func TestLoggerClock(t *testing.T) {
+	clock := ztest.NewMockClock()
+	clock.Add(time.Hour)
+
+	var ent zapcore.Entry
+	core := zapcore.RegisterHooks(zapcore.NewNopCore(), func(e zapcore.Entry) error {
+		ent = e
+		return nil
+	})
+	require.NoError(t, NewLogger(core, WithClock(clock)).Info("tick"), "Unexpected error logging.")
+	assert.Equal(t, clock.Now(), ent.Time, "Expected the entry to be timestamped by the clock.")
+}
+
+func TestLoggerErrors(t *testing.T) {
+	t.Run("write", func(t *testing.T) {
+		ws := &ztest.FailWriter{}
+		assert.Error(t, newTestLogger(ws).Info("lost"), "Expected the write error.")
+		assert.False(t, ws.Called(), "Expected no sync after a failed write.")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		ws := &syncCounter{syncErr: errors.New("fsync failed")}
+		assert.Equal(t, ws.syncErr, newTestLogger(ws).Info("maybe"), "Expected the sync error.")
+	})
+
+	t.Run("group sync", func(t *testing.T) {
+		ws := &syncCounter{syncErr: errors.New("fsync failed")}
+		assert.Equal(t, ws.syncErr, newTestLogger(ws, GroupCommit(0)).Info("maybe"), "Expected the sync error.")
+	})
+}
+
+func TestLoggerGroupCommit(t *testing.T) {
+	ws := &syncCounter{delay: 10 * time.Millisecond}
+	logger := newTestLogger(ws, GroupCommit(time.Millisecond))
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = logger.Info("grouped")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err, "Unexpected error logging.")
+	}
+	assert.Len(t, ws.lines(), n, "Expected every entry to be written.")
+	assert.Less(t, ws.syncs, n, "Expected writes to share syncs.")
+	assert.Equal(t, ws.buf.Len(), ws.synced, "Expected every entry to be synced.")
+}
+
+func TestLoggerGroupCommitWaitsForItsSync(t *testing.T) {
+	ws := &syncCounter{}
+	logger := newTestLogger(ws, GroupCommit(0))
+
+	// Each entry must be covered by a sync that started after it was
+	// written, even when another commit is in progress.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				msg := fmt.Sprintf("entry %d-%d", i, j)
+				assert.NoError(t, logger.Info(msg), "Unexpected error logging.")
+
+				ws.mu.Lock()
+				end := bytes.Index(ws.buf.Bytes(), []byte(msg)) + len(msg)
+				synced := ws.synced
+				ws.mu.Unlock()
+				assert.GreaterOrEqual(t, synced, end, "Expected %q to be synced before returning.", msg)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, ws.buf.Len(), ws.synced, "Expected every entry to be synced.")
+}
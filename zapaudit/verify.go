@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// A ProblemKind classifies the problems Verify finds.
+type ProblemKind int
+
+const (
+	// BrokenLink means an entry's MAC doesn't match its contents and the
+	// entry before it: the entry was modified, or entries were inserted or
+	// removed before it.
+	BrokenLink ProblemKind = iota + 1
+	// Gap means entries are missing before this one.
+	Gap
+	// Reordered means an entry appears after one with a later sequence
+	// number.
+	Reordered
+	// UnknownKey means the entry was signed with a key Verify wasn't given.
+	UnknownKey
+	// Malformed means the line isn't an audit entry.
+	Malformed
+	// Restart means a new chain starts partway through the log. Restarts
+	// are expected when an application doesn't resume the chain, but they
+	// can also hide removed entries.
+	Restart
+)
+
+// String returns a lower-case description of the problem kind.
+func (k ProblemKind) String() string {
+	switch k {
+	case BrokenLink:
+		return "broken link"
+	case Gap:
+		return "gap"
+	case Reordered:
+		return "reordered"
+	case UnknownKey:
+		return "unknown key"
+	case Malformed:
+		return "malformed"
+	case Restart:
+		return "restart"
+	default:
+		return fmt.Sprintf("ProblemKind(%d)", k)
+	}
+}
+
+// A Problem is something wrong with an audit log.
+type Problem struct {
+	Kind ProblemKind
+	// Line is the 1-based line number of the entry.
+	Line int
+	// Sequence is the entry's sequence number, if it has one.
+	Sequence uint64
+	// Detail describes the problem.
+	Detail string
+}
+
+func (p Problem) String() string {
+	if p.Sequence == 0 {
+		return fmt.Sprintf("line %d: %v: %s", p.Line, p.Kind, p.Detail)
+	}
+	return fmt.Sprintf("line %d (seq %d): %v: %s", p.Line, p.Sequence, p.Kind, p.Detail)
+}
+
+// A Report describes an audit log checked by Verify.
+type Report struct {
+	// Entries is the number of audit entries in the log.
+	Entries int
+	// FirstSequence and LastSequence are the sequence numbers of the first
+	// and last entries. A FirstSequence other than 1 means the log continues
+	// an earlier one, which Verify can't check.
+	FirstSequence, LastSequence uint64
+	// LastMAC is the MAC of the last entry. Pass it and LastSequence to
+	// Chain.Resume to append to the log.
+	LastMAC []byte
+	// Problems lists the problems found, in order.
+	Problems []Problem
+}
+
+// OK reports whether the log has no problems.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// FirstBrokenLink returns the first entry whose MAC doesn't verify, if any.
+func (r *Report) FirstBrokenLink() (Problem, bool) {
+	for _, p := range r.Problems {
+		if p.Kind == BrokenLink {
+			return p, true
+		}
+	}
+	return Problem{}, false
+}
+
+// Verify checks the chain of an audit log, given the secrets of the keys
+// that signed it by ID. It only returns an error if the log can't be read;
+// problems with the log itself are listed in the report.
+func Verify(r io.Reader, keys map[string][]byte) (*Report, error) {
+	v := verifier{keys: keys, report: &Report{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			v.check(line, scanner.Bytes())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return v.report, nil
+}
+
+type verifier struct {
+	keys   map[string][]byte
+	report *Report
+}
+
+// auditFields are the fields audit cores add to each entry.
+type auditFields struct {
+	Seq *uint64 `json:"audit.seq"`
+	KID *string `json:"audit.kid"`
+	MAC string  `json:"audit.mac"`
+}
+
+func (v *verifier) problem(kind ProblemKind, line int, seq uint64, format string, args ...interface{}) {
+	v.report.Problems = append(v.report.Problems, Problem{
+		Kind:     kind,
+		Line:     line,
+		Sequence: seq,
+		Detail:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *verifier) check(line int, entry []byte) {
+	var fields auditFields
+	if err := json.Unmarshal(entry, &fields); err != nil {
+		v.problem(Malformed, line, 0, "%v", err)
+		return
+	}
+	if fields.Seq == nil || fields.KID == nil || fields.MAC == "" {
+		v.problem(Malformed, line, 0, "missing audit fields")
+		return
+	}
+	seq := *fields.Seq
+	mac, err := hex.DecodeString(fields.MAC)
+	if err != nil {
+		v.problem(Malformed, line, seq, "invalid MAC: %v", err)
+		return
+	}
+	// The MAC must be the last field, and covers everything before it.
+	suffix := []byte(`,"` + MACKey + `":"` + fields.MAC + `"`)
+	end := closingBrace(entry)
+	if end < 0 || !bytes.HasSuffix(entry[:end], suffix) {
+		v.problem(Malformed, line, seq, "%s isn't the last field", MACKey)
+		return
+	}
+	signed := entry[:end-len(suffix)]
+
+	r := v.report
+	first := r.Entries == 0
+	prev, last := r.LastMAC, r.LastSequence
+	switch {
+	case first && seq != 1:
+		// The log continues an earlier one, so we can't check the link.
+	case first || seq == last+1:
+		v.verify(line, seq, *fields.KID, prev, signed, mac)
+	case seq == 1:
+		v.problem(Restart, line, seq, "chain restarts after seq %d", last)
+		v.verify(line, seq, *fields.KID, nil, signed, mac)
+	case seq > last+1:
+		if seq == last+2 {
+			v.problem(Gap, line, seq, "seq %d is missing", last+1)
+		} else {
+			v.problem(Gap, line, seq, "seqs %d to %d are missing", last+1, seq-1)
+		}
+	default:
+		v.problem(Reordered, line, seq, "follows seq %d", last)
+		r.Entries++
+		return
+	}
+
+	if first {
+		r.FirstSequence = seq
+	}
+	r.Entries++
+	r.LastSequence = seq
+	r.LastMAC = mac
+}
+
+func (v *verifier) verify(line int, seq uint64, kid string, prev, signed, mac []byte) {
+	secret, ok := v.keys[kid]
+	if !ok {
+		v.problem(UnknownKey, line, seq, "no secret for key %q", kid)
+		return
+	}
+	if !hmac.Equal(sign(secret, prev, signed), mac) {
+		v.problem(BrokenLink, line, seq, "MAC doesn't match")
+	}
+}
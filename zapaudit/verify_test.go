@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	var buf bytes.Buffer
+	writeLog(t, &buf, newTestChain(t, _key1), "one", "two", "three", "four", "five")
+	good := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	var restarted bytes.Buffer
+	writeLog(t, &restarted, newTestChain(t, _key1), "again")
+
+	tests := []struct {
+		desc   string
+		tamper func(lines []string) []string
+		want   []Problem // Detail is ignored
+	}{
+		{
+			desc:   "untouched",
+			tamper: func(lines []string) []string { return lines },
+		},
+		{
+			desc: "modified",
+			tamper: func(lines []string) []string {
+				lines[2] = strings.Replace(lines[2], "three", "THREE", 1)
+				return lines
+			},
+			want: []Problem{{Kind: BrokenLink, Line: 3, Sequence: 3}},
+		},
+		{
+			desc: "removed",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[3:]...)
+			},
+			want: []Problem{{Kind: Gap, Line: 2, Sequence: 4}},
+		},
+		{
+			desc: "swapped",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			want: []Problem{
+				{Kind: Gap, Line: 2, Sequence: 3},
+				{Kind: Reordered, Line: 3, Sequence: 2},
+			},
+		},
+		{
+			desc: "continues an earlier log",
+			tamper: func(lines []string) []string {
+				return lines[2:]
+			},
+		},
+		{
+			desc: "restarted",
+			tamper: func(lines []string) []string {
+				return append(lines, strings.TrimSuffix(restarted.String(), "\n"))
+			},
+			want: []Problem{{Kind: Restart, Line: 6, Sequence: 1}},
+		},
+		{
+			desc: "inserted",
+			tamper: func(lines []string) []string {
+				return append(lines[:2], append([]string{`{"msg":"forged"}`, ""}, lines[2:]...)...)
+			},
+			want: []Problem{{Kind: Malformed, Line: 3}},
+		},
+		{
+			desc: "MAC moved",
+			tamper: func(lines []string) []string {
+				lines[0] = strings.Replace(lines[0], `{"level"`, `{"extra":1,"level"`, 1) + " "
+				lines[1] = strings.Replace(lines[1], `"}`, `","extra":1}`, 1)
+				return lines
+			},
+			want: []Problem{
+				{Kind: BrokenLink, Line: 1, Sequence: 1},
+				{Kind: Malformed, Line: 2, Sequence: 2},
+				{Kind: Gap, Line: 3, Sequence: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			lines := tt.tamper(append([]string(nil), good...))
+			report, err := Verify(strings.NewReader(strings.Join(lines, "\n")), _keys)
+			require.NoError(t, err, "Unexpected error verifying log.")
+
+			for i := range report.Problems {
+				assert.NotEmpty(t, report.Problems[i].Detail, "Expected problems to have details.")
+				report.Problems[i].Detail = ""
+			}
+			assert.Equal(t, tt.want, report.Problems, "Unexpected problems.")
+			assert.Equal(t, len(tt.want) == 0, report.OK(), "Unexpected OK.")
+
+			brokenLink, ok := report.FirstBrokenLink()
+			assert.Equal(t, tt.want != nil && tt.want[0].Kind == BrokenLink, ok, "Unexpected first broken link.")
+			if ok {
+				assert.Equal(t, tt.want[0], brokenLink, "Unexpected first broken link.")
+			}
+		})
+	}
+}
+
+func TestVerifyReport(t *testing.T) {
+	var buf bytes.Buffer
+	writeLog(t, &buf, newTestChain(t, _key1), "one", "two", "three")
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	report, err := Verify(strings.NewReader(lines[1]+lines[2]), _keys)
+	require.NoError(t, err, "Unexpected error verifying log.")
+	assert.Equal(t, 2, report.Entries, "Unexpected number of entries.")
+	assert.Equal(t, uint64(2), report.FirstSequence, "Unexpected first sequence number.")
+	assert.Equal(t, uint64(3), report.LastSequence, "Unexpected last sequence number.")
+	assert.Len(t, report.LastMAC, 32, "Unexpected MAC length.")
+}
+
+func TestProblemString(t *testing.T) {
+	tests := []struct {
+		problem Problem
+		want    string
+	}{
+		{Problem{Kind: BrokenLink, Line: 3, Sequence: 2, Detail: "MAC doesn't match"}, "line 3 (seq 2): broken link: MAC doesn't match"},
+		{Problem{Kind: Malformed, Line: 1, Detail: "missing audit fields"}, "line 1: malformed: missing audit fields"},
+		{Problem{Kind: ProblemKind(42), Line: 1, Detail: "?"}, "line 1: ProblemKind(42): ?"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.problem.String(), "Unexpected string for %#v.", tt.problem)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const (
+	// _maxDecodeDepth bounds the nesting of decoded maps and arrays, so that
+	// malformed input can't exhaust the stack.
+	_maxDecodeDepth = 1000
+
+	// _maxDecodePrealloc bounds the memory allocated up front for a string
+	// or container, so that a corrupt length can't exhaust memory before
+	// the data runs out.
+	_maxDecodePrealloc = 1 << 16
+)
+
+var errDecodeTooDeep = errors.New("maximum nesting depth exceeded")
+
+// binaryReader reads the primitives shared by the binary formats.
+type binaryReader struct {
+	r *bufio.Reader
+}
+
+func newBinaryReader(r io.Reader) binaryReader {
+	return binaryReader{r: bufio.NewReader(r)}
+}
+
+// start reports io.EOF if no data remains before the next data item.
+func (b binaryReader) start() error {
+	_, err := b.r.Peek(1)
+	return err
+}
+
+func (b binaryReader) readByte() (byte, error) {
+	c, err := b.r.ReadByte()
+	return c, unexpectedEOF(err)
+}
+
+func (b binaryReader) peekByte() (byte, error) {
+	c, err := b.r.Peek(1)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return c[0], nil
+}
+
+func (b binaryReader) readUint(size int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(b.r, buf[:size]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	switch size {
+	case 1:
+		return uint64(buf[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(buf[:])), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(buf[:])), nil
+	default:
+		return binary.BigEndian.Uint64(buf[:]), nil
+	}
+}
+
+// readBytes reads the next n bytes, growing its result as data arrives
+// rather than trusting n.
+func (b binaryReader) readBytes(n uint64) ([]byte, error) {
+	if n <= _maxDecodePrealloc {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(b.r, buf)
+		return buf, unexpectedEOF(err)
+	}
+	if n > math.MaxInt64 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, b.r, int64(n)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodedInt returns v as an int64 if it fits, and as a uint64 otherwise.
+func decodedInt(v uint64) interface{} {
+	if v <= math.MaxInt64 {
+		return int64(v)
+	}
+	return v
+}
+
+// preallocLen bounds the capacity preallocated for n items.
+func preallocLen(n uint64) int {
+	if n > _maxDecodePrealloc {
+		return _maxDecodePrealloc
+	}
+	return int(n)
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,552 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+// binaryFormat writes the data items of a binary serialization format, like
+// CBOR or MessagePack, to a buffer.
+type binaryFormat interface {
+	appendNil(*buffer.Buffer)
+	appendBool(*buffer.Buffer, bool)
+	appendInt(*buffer.Buffer, int64)
+	appendUint(*buffer.Buffer, uint64)
+	appendFloat32(*buffer.Buffer, float32)
+	appendFloat64(*buffer.Buffer, float64)
+	appendString(*buffer.Buffer, string)
+	appendByteString(*buffer.Buffer, []byte) // for UTF-8 encoded bytes
+	appendBinary(*buffer.Buffer, []byte)
+	appendTime(*buffer.Buffer, time.Time)
+	appendDuration(*buffer.Buffer, time.Duration)
+
+	// Maps and arrays are opened before their length is known. Once they're
+	// complete, close is called with the offset at which they were opened
+	// and the number of pairs or elements they hold.
+	openMap(*buffer.Buffer)
+	closeMap(buf *buffer.Buffer, offset, pairs int)
+	openArray(*buffer.Buffer)
+	closeArray(buf *buffer.Buffer, offset, elems int)
+}
+
+var _binaryPool = pool.New(func() *binaryEncoder {
+	return &binaryEncoder{}
+})
+
+func putBinaryEncoder(enc *binaryEncoder) {
+	if enc.reflectBuf != nil {
+		enc.reflectBuf.Free()
+	}
+	enc.EncoderConfig = nil
+	enc.format = nil
+	enc.buf = nil
+	enc.containers = enc.containers[:0]
+	enc.keyed = false
+	enc.openNamespaces = 0
+	enc.reflectBuf = nil
+	enc.reflectEnc = nil
+	_binaryPool.Put(enc)
+}
+
+// binaryEncoder implements Encoder for binary formats. Every entry is
+// encoded as a single map holding the entry's metadata and fields, so no
+// line ending is written.
+type binaryEncoder struct {
+	*EncoderConfig
+	format binaryFormat
+	buf    *buffer.Buffer
+
+	// containers are the open maps and arrays, outermost first. The first
+	// container is the entry itself; in an encoder holding a logger's
+	// context, it has no header.
+	containers     []binaryContainer
+	keyed          bool // a key was written and its value is pending
+	openNamespaces int
+
+	// for encoding generic values by reflection
+	reflectBuf *buffer.Buffer
+	reflectEnc ReflectedEncoder
+}
+
+type binaryContainer struct {
+	offset int // offset of the container's header
+	count  int // pairs in a map, or elements in an array
+	array  bool
+}
+
+func newBinaryEncoder(cfg EncoderConfig, format binaryFormat) *binaryEncoder {
+	// If no EncoderConfig.NewReflectedEncoder is provided by the user, then use default
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+
+	return &binaryEncoder{
+		EncoderConfig: &cfg,
+		format:        format,
+		buf:           bufferpool.Get(),
+		containers:    []binaryContainer{{offset: -1}},
+	}
+}
+
+func (enc *binaryEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendArray(arr)
+}
+
+func (enc *binaryEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendObject(obj)
+}
+
+func (enc *binaryEncoder) AddBinary(key string, val []byte) {
+	enc.addKey(key)
+	enc.element()
+	enc.format.appendBinary(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AddByteString(key string, val []byte) {
+	enc.addKey(key)
+	enc.AppendByteString(val)
+}
+
+func (enc *binaryEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.AppendBool(val)
+}
+
+func (enc *binaryEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.AppendComplex128(val)
+}
+
+func (enc *binaryEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.AppendComplex64(val)
+}
+
+func (enc *binaryEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+}
+
+func (enc *binaryEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.AppendFloat64(val)
+}
+
+func (enc *binaryEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.AppendFloat32(val)
+}
+
+func (enc *binaryEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.AppendInt64(val)
+}
+
+func (enc *binaryEncoder) AddReflected(key string, obj interface{}) error {
+	valueBytes, err := enc.encodeReflected(obj)
+	if err != nil {
+		return err
+	}
+	enc.addKey(key)
+	enc.appendReflected(valueBytes)
+	return nil
+}
+
+func (enc *binaryEncoder) OpenNamespace(key string) {
+	enc.addKey(key)
+	enc.openContainer(false)
+	enc.openNamespaces++
+}
+
+func (enc *binaryEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.AppendString(val)
+}
+
+func (enc *binaryEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+}
+
+func (enc *binaryEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.AppendUint64(val)
+}
+
+func (enc *binaryEncoder) AppendArray(arr ArrayMarshaler) error {
+	enc.openContainer(true)
+	err := arr.MarshalLogArray(enc)
+	enc.closeContainer()
+	return err
+}
+
+func (enc *binaryEncoder) AppendObject(obj ObjectMarshaler) error {
+	// Close ONLY new openNamespaces that are created during
+	// AppendObject().
+	old := enc.openNamespaces
+	enc.openNamespaces = 0
+	enc.openContainer(false)
+	err := obj.MarshalLogObject(enc)
+	enc.closeOpenNamespaces()
+	enc.closeContainer()
+	enc.openNamespaces = old
+	return err
+}
+
+func (enc *binaryEncoder) AppendBool(val bool) {
+	enc.element()
+	enc.format.appendBool(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendByteString(val []byte) {
+	enc.element()
+	enc.format.appendByteString(enc.buf, val)
+}
+
+// Complex numbers are encoded as an array holding the real and imaginary
+// parts.
+func (enc *binaryEncoder) AppendComplex128(val complex128) {
+	enc.openContainer(true)
+	enc.AppendFloat64(real(val))
+	enc.AppendFloat64(imag(val))
+	enc.closeContainer()
+}
+
+func (enc *binaryEncoder) AppendComplex64(val complex64) {
+	enc.openContainer(true)
+	enc.AppendFloat32(real(val))
+	enc.AppendFloat32(imag(val))
+	enc.closeContainer()
+}
+
+func (enc *binaryEncoder) AppendDuration(val time.Duration) {
+	enc.element()
+	enc.format.appendDuration(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendFloat64(val float64) {
+	enc.element()
+	enc.format.appendFloat64(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendFloat32(val float32) {
+	enc.element()
+	enc.format.appendFloat32(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendInt64(val int64) {
+	enc.element()
+	enc.format.appendInt(enc.buf, val)
+}
+
+// AppendReflected encodes the value with the configured ReflectedEncoder and,
+// if the result is valid JSON, translates it into the binary format.
+// Anything else is added as a string.
+func (enc *binaryEncoder) AppendReflected(val interface{}) error {
+	valueBytes, err := enc.encodeReflected(val)
+	if err != nil {
+		return err
+	}
+	enc.appendReflected(valueBytes)
+	return nil
+}
+
+// encodeReflected encodes obj into enc.reflectBuf, so that nothing is written
+// to the entry if encoding fails. It returns nil for a nil obj.
+func (enc *binaryEncoder) encodeReflected(obj interface{}) ([]byte, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	if enc.reflectBuf == nil {
+		enc.reflectBuf = bufferpool.Get()
+		enc.reflectEnc = enc.NewReflectedEncoder(enc.reflectBuf)
+	} else {
+		enc.reflectBuf.Reset()
+	}
+	if err := enc.reflectEnc.Encode(obj); err != nil {
+		return nil, err
+	}
+	enc.reflectBuf.TrimNewline()
+	return enc.reflectBuf.Bytes(), nil
+}
+
+func (enc *binaryEncoder) appendReflected(data []byte) {
+	switch {
+	case data == nil:
+		enc.element()
+		enc.format.appendNil(enc.buf)
+	case !json.Valid(data):
+		enc.AppendByteString(data)
+	default:
+		enc.appendJSON(data)
+	}
+}
+
+// appendJSON translates a valid JSON value into the binary format.
+func (enc *binaryEncoder) appendJSON(data []byte) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// For each open JSON object, whether the next token is a key.
+	var objects []bool
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			// data is valid, so this is io.EOF.
+			return
+		}
+
+		if n := len(objects); n > 0 && objects[n-1] {
+			if key, ok := tok.(string); ok {
+				objects[n-1] = false
+				enc.addKey(key)
+				continue
+			}
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{':
+				enc.openContainer(false)
+				objects = append(objects, true)
+			case '[':
+				enc.openContainer(true)
+				objects = append(objects, false)
+			default:
+				enc.closeContainer()
+				objects = objects[:len(objects)-1]
+			}
+		case string:
+			enc.AppendString(v)
+		case json.Number:
+			enc.appendNumber(v)
+		case bool:
+			enc.AppendBool(v)
+		case nil:
+			enc.element()
+			enc.format.appendNil(enc.buf)
+		}
+
+		if n := len(objects); n > 0 && tok != json.Delim('{') && tok != json.Delim('[') {
+			// A value completed in the enclosing object, so a key follows.
+			objects[n-1] = !enc.containers[len(enc.containers)-1].array
+		}
+	}
+}
+
+func (enc *binaryEncoder) appendNumber(n json.Number) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		enc.AppendInt64(i)
+	} else if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		enc.AppendUint64(u)
+	} else if f, err := n.Float64(); err == nil {
+		enc.AppendFloat64(f)
+	} else {
+		enc.AppendString(string(n))
+	}
+}
+
+func (enc *binaryEncoder) AppendString(val string) {
+	enc.element()
+	enc.format.appendString(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendTime(val time.Time) {
+	enc.element()
+	enc.format.appendTime(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AppendUint64(val uint64) {
+	enc.element()
+	enc.format.appendUint(enc.buf, val)
+}
+
+func (enc *binaryEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *binaryEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *binaryEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *binaryEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *binaryEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *binaryEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *binaryEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *binaryEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *binaryEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *binaryEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *binaryEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *binaryEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *binaryEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *binaryEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *binaryEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *binaryEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *binaryEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *binaryEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+func (enc *binaryEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	clone.containers = append(clone.containers, enc.containers...)
+	clone.openNamespaces = enc.openNamespaces
+	return clone
+}
+
+func (enc *binaryEncoder) clone() *binaryEncoder {
+	clone := _binaryPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.format = enc.format
+	clone.buf = bufferpool.Get()
+	return clone
+}
+
+func (enc *binaryEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.clone()
+	final.openContainer(false)
+
+	if final.LevelKey != "" && final.EncodeLevel != nil {
+		final.addKey(final.LevelKey)
+		cur := final.buf.Len()
+		final.EncodeLevel(ent.Level, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeLevel was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.Level.String())
+		}
+	}
+	if final.TimeKey != "" && !ent.Time.IsZero() {
+		final.AddTime(final.TimeKey, ent.Time)
+	}
+	if ent.LoggerName != "" && final.NameKey != "" {
+		final.addKey(final.NameKey)
+		cur := final.buf.Len()
+		nameEncoder := final.EncodeName
+
+		// if no name encoder provided, fall back to FullNameEncoder for backwards
+		// compatibility
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+
+		nameEncoder(ent.LoggerName, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeName was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.LoggerName)
+		}
+	}
+	if ent.Caller.Defined {
+		if final.CallerKey != "" && final.EncodeCaller != nil {
+			final.addKey(final.CallerKey)
+			cur := final.buf.Len()
+			final.EncodeCaller(ent.Caller, final)
+			if cur == final.buf.Len() {
+				// User-supplied EncodeCaller was a no-op. Fall back to strings to
+				// keep the map well-formed.
+				final.AppendString(ent.Caller.String())
+			}
+		}
+		if final.FunctionKey != "" {
+			final.addKey(final.FunctionKey)
+			final.AppendString(ent.Caller.Function)
+		}
+	}
+	if final.MessageKey != "" {
+		final.addKey(enc.MessageKey)
+		final.AppendString(ent.Message)
+	}
+	if enc.buf.Len() > 0 {
+		// Namespaces still open in the context moved along with it.
+		offset := final.buf.Len()
+		final.buf.Write(enc.buf.Bytes())
+		final.containers[0].count += enc.containers[0].count
+		for _, c := range enc.containers[1:] {
+			c.offset += offset
+			final.containers = append(final.containers, c)
+		}
+		final.openNamespaces = enc.openNamespaces
+	}
+	addFields(final, fields)
+	final.closeOpenNamespaces()
+	if ent.Stack != "" && final.StacktraceKey != "" {
+		final.AddString(final.StacktraceKey, ent.Stack)
+	}
+	final.closeContainer()
+
+	ret := final.buf
+	putBinaryEncoder(final)
+	return ret, nil
+}
+
+func (enc *binaryEncoder) closeOpenNamespaces() {
+	for i := 0; i < enc.openNamespaces; i++ {
+		enc.closeContainer()
+	}
+	enc.openNamespaces = 0
+}
+
+func (enc *binaryEncoder) addKey(key string) {
+	enc.containers[len(enc.containers)-1].count++
+	enc.format.appendString(enc.buf, key)
+	enc.keyed = true
+}
+
+// element counts a new element of the innermost array, unless the value
+// belongs to a key that was just added.
+func (enc *binaryEncoder) element() {
+	if enc.keyed {
+		enc.keyed = false
+		return
+	}
+	if n := len(enc.containers); n > 0 {
+		enc.containers[n-1].count++
+	}
+}
+
+func (enc *binaryEncoder) openContainer(array bool) {
+	enc.element()
+	enc.containers = append(enc.containers, binaryContainer{
+		offset: enc.buf.Len(),
+		array:  array,
+	})
+	if array {
+		enc.format.openArray(enc.buf)
+	} else {
+		enc.format.openMap(enc.buf)
+	}
+}
+
+func (enc *binaryEncoder) closeContainer() {
+	c := enc.containers[len(enc.containers)-1]
+	enc.containers = enc.containers[:len(enc.containers)-1]
+	if c.array {
+		enc.format.closeArray(enc.buf, c.offset, c.count)
+	} else {
+		enc.format.closeMap(enc.buf, c.offset, c.count)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+type binaryDecoder interface {
+	Decode() (map[string]interface{}, error)
+}
+
+var binaryFormats = []struct {
+	name       string
+	newEncoder func(EncoderConfig) Encoder
+	newDecoder func(io.Reader) binaryDecoder
+}{
+	{
+		name:       "cbor",
+		newEncoder: NewCBOREncoder,
+		newDecoder: func(r io.Reader) binaryDecoder { return NewCBORDecoder(r) },
+	},
+	{
+		name:       "msgpack",
+		newEncoder: NewMsgpackEncoder,
+		newDecoder: func(r io.Reader) binaryDecoder { return NewMsgpackDecoder(r) },
+	},
+}
+
+type binaryPoint struct{ X, Y int }
+
+func (p binaryPoint) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddInt("x", p.X)
+	enc.OpenNamespace("meta")
+	enc.AddInt("y", p.Y)
+	return nil
+}
+
+func TestBinaryEncodeEntry(t *testing.T) {
+	ts := time.Date(2018, 6, 19, 16, 33, 42, 99, time.UTC)
+	ent := Entry{
+		Level:      WarnLevel,
+		Time:       ts,
+		LoggerName: "bob",
+		Message:    "lob law",
+		Caller:     EntryCaller{Defined: true, File: "foo.go", Line: 42, Function: "foo.Foo"},
+		Stack:      "fake-stack",
+	}
+	fields := []Field{
+		zap.String("str", "hello"),
+		zap.String("invalid", "a\xffb"),
+		zap.ByteString("bytes", []byte("byte string")),
+		zap.Binary("binary", []byte{0, 1, 2, 0xff}),
+		zap.Bool("bool", true),
+		zap.Int("int", -42),
+		zap.Int64("min", math.MinInt64),
+		zap.Uint64("max", math.MaxUint64),
+		zap.Float64("float64", 3.14),
+		zap.Float32("float32", 2.5),
+		zap.Complex128("complex", 1+2i),
+		zap.Duration("duration", 1500*time.Millisecond),
+		zap.Time("whole", time.Unix(1591287718, 0)),
+		zap.Time("before_epoch", time.Unix(-100, 5)),
+		zap.Ints("ints", []int{1, 2, 3}),
+		zap.Object("point", binaryPoint{1, 2}),
+		zap.Reflect("reflected", map[string]interface{}{
+			"a": []interface{}{1, "two", 3.5, nil, true},
+			"b": map[string]int{"c": 4},
+		}),
+		zap.Reflect("nil", nil),
+		zap.Error(errors.New("boom")),
+		zap.Namespace("ns"),
+		zap.String("inner", "value"),
+	}
+	want := map[string]interface{}{
+		"L":            "WARN",
+		"T":            ts,
+		"N":            "bob",
+		"C":            "foo.go:42",
+		"F":            "foo.Foo",
+		"M":            "lob law",
+		"S":            "fake-stack",
+		"ctx":          "with",
+		"str":          "hello",
+		"invalid":      "a�b",
+		"bytes":        "byte string",
+		"binary":       []byte{0, 1, 2, 0xff},
+		"bool":         true,
+		"int":          int64(-42),
+		"min":          int64(math.MinInt64),
+		"max":          uint64(math.MaxUint64),
+		"float64":      3.14,
+		"float32":      float32(2.5),
+		"complex":      []interface{}{1.0, 2.0},
+		"duration":     1500 * time.Millisecond,
+		"whole":        time.Unix(1591287718, 0).UTC(),
+		"before_epoch": time.Unix(-100, 5).UTC(),
+		"ints":         []interface{}{int64(1), int64(2), int64(3)},
+		"point": map[string]interface{}{
+			"x":    int64(1),
+			"meta": map[string]interface{}{"y": int64(2)},
+		},
+		"reflected": map[string]interface{}{
+			"a": []interface{}{int64(1), "two", 3.5, nil, true},
+			"b": map[string]interface{}{"c": int64(4)},
+		},
+		"nil":   nil,
+		"error": "boom",
+		"ns":    map[string]interface{}{"inner": "value"},
+	}
+
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := tt.newEncoder(EncoderConfig{
+				MessageKey:    "M",
+				LevelKey:      "L",
+				TimeKey:       "T",
+				NameKey:       "N",
+				CallerKey:     "C",
+				FunctionKey:   "F",
+				StacktraceKey: "S",
+				EncodeLevel:   CapitalLevelEncoder,
+				EncodeCaller:  ShortCallerEncoder,
+			})
+			enc.AddString("ctx", "with")
+
+			buf, err := enc.EncodeEntry(ent, fields)
+			require.NoError(t, err, "Unexpected encoding error.")
+			defer buf.Free()
+
+			got, err := tt.newDecoder(bytes.NewReader(buf.Bytes())).Decode()
+			require.NoError(t, err, "Unexpected decoding error.")
+			assert.Equal(t, want, got, "Unexpected decoded entry.")
+		})
+	}
+}
+
+func TestBinaryEncoderContext(t *testing.T) {
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := tt.newEncoder(EncoderConfig{MessageKey: "msg"})
+			enc.AddString("outer", "a")
+			enc.OpenNamespace("ns")
+			enc.AddString("inner", "b")
+
+			// Entries written by clones share the parent's context, but not
+			// each other's fields.
+			var stream bytes.Buffer
+			for i, msg := range []string{"first", "second"} {
+				clone := enc.Clone()
+				clone.AddInt("clone", i)
+				buf, err := clone.EncodeEntry(Entry{Message: msg}, []Field{zap.Int("field", i)})
+				require.NoError(t, err, "Unexpected encoding error.")
+				stream.Write(buf.Bytes())
+				buf.Free()
+			}
+
+			dec := tt.newDecoder(&stream)
+			for i, msg := range []string{"first", "second"} {
+				got, err := dec.Decode()
+				require.NoError(t, err, "Unexpected decoding error.")
+				assert.Equal(t, map[string]interface{}{
+					"msg":   msg,
+					"outer": "a",
+					"ns": map[string]interface{}{
+						"inner": "b",
+						"clone": int64(i),
+						"field": int64(i),
+					},
+				}, got, "Unexpected decoded entry.")
+			}
+			_, err := dec.Decode()
+			assert.Equal(t, io.EOF, err, "Expected io.EOF after the last entry.")
+		})
+	}
+}
+
+func TestBinaryEncoderCustomReflectedEncoder(t *testing.T) {
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := tt.newEncoder(EncoderConfig{
+				NewReflectedEncoder: func(w io.Writer) ReflectedEncoder {
+					return &notJSONReflectedEncoder{w}
+				},
+			})
+			buf, err := enc.EncodeEntry(Entry{}, []Field{zap.Reflect("data", 42)})
+			require.NoError(t, err, "Unexpected encoding error.")
+			defer buf.Free()
+
+			got, err := tt.newDecoder(bytes.NewReader(buf.Bytes())).Decode()
+			require.NoError(t, err, "Unexpected decoding error.")
+			assert.Equal(t, map[string]interface{}{"data": "<42>"}, got,
+				"Expected output of the reflected encoder that isn't JSON to be added as a string.")
+		})
+	}
+}
+
+func TestBinaryEncoderReflectedError(t *testing.T) {
+	failing := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		if err := enc.AppendReflected(42); err != nil {
+			enc.AppendString(err.Error())
+		}
+		return nil
+	})
+
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := tt.newEncoder(EncoderConfig{
+				NewReflectedEncoder: func(io.Writer) ReflectedEncoder {
+					return failingReflectedEncoder{}
+				},
+			})
+
+			// A failed reflection mustn't leave a key without a value, which
+			// would corrupt this entry and the next.
+			var out bytes.Buffer
+			for i := 0; i < 2; i++ {
+				buf, err := enc.EncodeEntry(Entry{}, []Field{
+					zap.Reflect("data", 42),
+					zap.Array("arr", failing),
+					zap.String("after", "ok"),
+				})
+				require.NoError(t, err, "Unexpected encoding error.")
+				out.Write(buf.Bytes())
+				buf.Free()
+			}
+
+			dec := tt.newDecoder(&out)
+			for i := 0; i < 2; i++ {
+				got, err := dec.Decode()
+				require.NoError(t, err, "Unexpected decoding error.")
+				assert.Equal(t, map[string]interface{}{
+					"dataError": "can't reflect",
+					"arr":       []interface{}{"can't reflect"},
+					"after":     "ok",
+				}, got, "Unexpected decoded entry.")
+			}
+			_, err := dec.Decode()
+			assert.Equal(t, io.EOF, err, "Expected io.EOF after the last entry.")
+		})
+	}
+}
+
+// Fails to encode any object.
+type failingReflectedEncoder struct{}
+
+func (failingReflectedEncoder) Encode(interface{}) error {
+	return errors.New("can't reflect")
+}
+
+// Encodes any object as a string that isn't valid JSON.
+type notJSONReflectedEncoder struct {
+	writer io.Writer
+}
+
+func (enc *notJSONReflectedEncoder) Encode(obj interface{}) error {
+	_, err := enc.writer.Write([]byte("<42>"))
+	return err
+}
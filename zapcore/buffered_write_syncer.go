@@ -22,6 +22,7 @@ package zapcore
 
 import (
 	"bufio"
+	"fmt"
 	"sync"
 	"time"
 
@@ -74,6 +75,20 @@ const (
 //	  FlushInterval: time.Minute,
 //	}
 //	defer ws.Stop()
+//
+// By default, writers wait while a full buffer is written to WS. Setting
+// MaxInFlight makes flushes asynchronous instead: full buffers are handed to
+// a background goroutine, and writers carry on filling a fresh buffer. Once
+// MaxInFlight buffers are waiting to be written, Backpressure decides
+// whether writers wait or drop their writes. In this mode, errors from WS
+// are reported by the next call to Sync or Stop rather than by Write.
+//
+//	ws := &BufferedWriteSyncer{
+//	  WS:          file,
+//	  MaxInFlight: 2,
+//	  SyncOnFlush: true,
+//	}
+//	defer ws.Stop()
 type BufferedWriteSyncer struct {
 	// WS is the WriteSyncer around which BufferedWriteSyncer will buffer
 	// writes.
@@ -99,6 +114,22 @@ type BufferedWriteSyncer struct {
 	// Defaults to the system clock.
 	Clock Clock
 
+	// MaxInFlight, if positive, makes flushes asynchronous, and limits the
+	// number of full buffers waiting to be written to WS.
+	//
+	// Defaults to zero, which flushes synchronously.
+	MaxInFlight int
+
+	// Backpressure specifies what Write does when MaxInFlight buffers are
+	// already waiting to be written.
+	//
+	// Defaults to BlockWhenFull.
+	Backpressure BackpressurePolicy
+
+	// SyncOnFlush syncs WS after each buffer is written to it in the
+	// background. It only applies when MaxInFlight is set.
+	SyncOnFlush bool
+
 	// unexported fields for state
 	mu          sync.Mutex
 	initialized bool // whether initialize() has run
@@ -107,6 +138,70 @@ type BufferedWriteSyncer struct {
 	ticker      *time.Ticker
 	stop        chan struct{} // closed when flushLoop should stop
 	done        chan struct{} // closed when flushLoop has stopped
+
+	// state for asynchronous flushes
+	size     int
+	active   []byte            // buffer being filled by writers
+	free     chan []byte       // written buffers for reuse
+	requests chan flushRequest // buffers for writeLoop to write, nil once stopped
+	written  chan struct{}     // closed when writeLoop has stopped
+	stats    BufferedStats
+}
+
+// BufferedStats describes the writes dropped by a BufferedWriteSyncer.
+type BufferedStats struct {
+	DroppedWrites int64
+	DroppedBytes  int64
+}
+
+// A BackpressurePolicy determines what an asynchronous BufferedWriteSyncer
+// does with writes while it can't keep up with them.
+type BackpressurePolicy uint8
+
+const (
+	// BlockWhenFull makes writers wait for a buffer to be written. This is
+	// the default.
+	BlockWhenFull BackpressurePolicy = iota
+	// DropWhenFull drops writes that don't fit in the current buffer.
+	DropWhenFull
+)
+
+// String returns a lower-case ASCII representation of the policy.
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BlockWhenFull:
+		return "block"
+	case DropWhenFull:
+		return "drop"
+	default:
+		return fmt.Sprintf("BackpressurePolicy(%d)", p)
+	}
+}
+
+// MarshalText marshals the BackpressurePolicy to text.
+func (p BackpressurePolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText unmarshals text to a BackpressurePolicy. "block" and the
+// empty string are unmarshaled to BlockWhenFull, and "drop" to DropWhenFull.
+func (p *BackpressurePolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "block":
+		*p = BlockWhenFull
+	case "drop":
+		*p = DropWhenFull
+	default:
+		return fmt.Errorf("unrecognized backpressure policy: %q", text)
+	}
+	return nil
+}
+
+// A flushRequest asks writeLoop to write a buffer, and optionally sync.
+type flushRequest struct {
+	buf  []byte
+	sync bool
+	done chan error // receives errors since the last request with a done
 }
 
 func (s *BufferedWriteSyncer) initialize() {
@@ -125,7 +220,16 @@ func (s *BufferedWriteSyncer) initialize() {
 	}
 
 	s.ticker = s.Clock.NewTicker(flushInterval)
-	s.writer = bufio.NewWriterSize(s.WS, size)
+	if s.MaxInFlight > 0 {
+		s.size = size
+		s.active = make([]byte, 0, size)
+		s.free = make(chan []byte, s.MaxInFlight+1)
+		s.requests = make(chan flushRequest, s.MaxInFlight)
+		s.written = make(chan struct{})
+		go s.writeLoop(s.requests)
+	} else {
+		s.writer = bufio.NewWriterSize(s.WS, size)
+	}
 	s.stop = make(chan struct{})
 	s.done = make(chan struct{})
 	s.initialized = true
@@ -141,6 +245,9 @@ func (s *BufferedWriteSyncer) Write(bs []byte) (int, error) {
 	if !s.initialized {
 		s.initialize()
 	}
+	if s.MaxInFlight > 0 {
+		return s.writeAsync(bs)
+	}
 
 	// To avoid partial writes from being flushed, we manually flush the existing buffer if:
 	// * The current write doesn't fit into the buffer fully, and
@@ -154,19 +261,103 @@ func (s *BufferedWriteSyncer) Write(bs []byte) (int, error) {
 	return s.writer.Write(bs)
 }
 
+// writeAsync adds a write to the active buffer, handing the buffer off
+// first if the write doesn't fit.
+func (s *BufferedWriteSyncer) writeAsync(bs []byte) (int, error) {
+	if s.requests == nil {
+		// writeLoop has stopped, so there's nothing to hand off to.
+		return s.WS.Write(bs)
+	}
+	if len(s.active) > 0 && len(s.active)+len(bs) > s.size {
+		if !s.handOff(flushRequest{}, s.Backpressure == BlockWhenFull) {
+			s.stats.DroppedWrites++
+			s.stats.DroppedBytes += int64(len(bs))
+			return len(bs), nil
+		}
+	}
+	s.active = append(s.active, bs...)
+	return len(bs), nil
+}
+
+// handOff sends the active buffer to writeLoop with the given request,
+// reporting whether there was room to.
+func (s *BufferedWriteSyncer) handOff(req flushRequest, block bool) bool {
+	req.buf = s.active
+	if block {
+		s.requests <- req
+	} else {
+		select {
+		case s.requests <- req:
+		default:
+			return false
+		}
+	}
+
+	select {
+	case s.active = <-s.free:
+	default:
+		s.active = make([]byte, 0, s.size)
+	}
+	return true
+}
+
+// writeLoop writes the buffers handed off by writers until Stop is called.
+func (s *BufferedWriteSyncer) writeLoop(requests <-chan flushRequest) {
+	defer close(s.written)
+
+	var err error
+	for req := range requests {
+		if len(req.buf) > 0 {
+			_, werr := s.WS.Write(req.buf)
+			err = multierr.Append(err, werr)
+			if s.SyncOnFlush && werr == nil {
+				req.sync = true
+			}
+		}
+		if req.sync {
+			err = multierr.Append(err, s.WS.Sync())
+		}
+		if req.done != nil {
+			req.done <- err
+			err = nil
+		}
+
+		select {
+		case s.free <- req.buf[:0]:
+		default:
+		}
+	}
+}
+
 // Sync flushes buffered log data into disk directly.
 func (s *BufferedWriteSyncer) Sync() error {
 	s.mu.Lock()
+
+	if s.requests != nil {
+		// Wait for writeLoop to write and sync everything so far, without
+		// making writers wait too.
+		done := make(chan error, 1)
+		s.handOff(flushRequest{sync: true, done: done}, true)
+		s.mu.Unlock()
+		return <-done
+	}
 	defer s.mu.Unlock()
 
 	var err error
-	if s.initialized {
+	if s.initialized && s.writer != nil {
 		err = s.writer.Flush()
 	}
 
 	return multierr.Append(err, s.WS.Sync())
 }
 
+// Stats reports the number of writes dropped because of backpressure.
+func (s *BufferedWriteSyncer) Stats() BufferedStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
 // flushLoop flushes the buffer at the configured interval until Stop is
 // called.
 func (s *BufferedWriteSyncer) flushLoop() {
@@ -175,6 +366,10 @@ func (s *BufferedWriteSyncer) flushLoop() {
 	for {
 		select {
 		case <-s.ticker.C:
+			if s.MaxInFlight > 0 {
+				s.flushAsync()
+				continue
+			}
 			// we just simply ignore error here
 			// because the underlying bufio writer stores any errors
 			// and we return any error from Sync() as part of the close
@@ -185,6 +380,16 @@ func (s *BufferedWriteSyncer) flushLoop() {
 	}
 }
 
+// flushAsync hands the active buffer off to be written and synced, unless
+// writeLoop is already behind. Errors are reported by the next Sync.
+func (s *BufferedWriteSyncer) flushAsync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.active) > 0 {
+		s.handOff(flushRequest{sync: true}, false)
+	}
+}
+
 // Stop closes the buffer, cleans up background goroutines, and flushes
 // remaining unwritten data.
 func (s *BufferedWriteSyncer) Stop() (err error) {
@@ -216,5 +421,17 @@ func (s *BufferedWriteSyncer) Stop() (err error) {
 	// See https://github.com/uber-go/zap/issues/1428 for details.
 	<-s.done
 
-	return s.Sync()
+	if s.MaxInFlight == 0 {
+		return s.Sync()
+	}
+
+	// Write what's left, then stop writeLoop. Later writes go straight to WS.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	done := make(chan error, 1)
+	s.handOff(flushRequest{sync: true, done: done}, true)
+	close(s.requests)
+	s.requests = nil
+	<-s.written
+	return <-done
 }
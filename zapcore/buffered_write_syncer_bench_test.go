@@ -23,6 +23,7 @@ package zapcore
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,3 +54,49 @@ func BenchmarkBufferedWriteSyncer(b *testing.B) {
 		})
 	})
 }
+
+// slowWriteSyncer is a WriteSyncer that takes a while to write, like a
+// busy disk.
+type slowWriteSyncer struct{ delay time.Duration }
+
+func (w slowWriteSyncer) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func (slowWriteSyncer) Sync() error { return nil }
+
+func BenchmarkBufferedWriteSyncerContention(b *testing.B) {
+	tests := []struct {
+		name        string
+		maxInFlight int
+		policy      BackpressurePolicy
+	}{
+		{name: "sync flush"},
+		{name: "async flush", maxInFlight: 2},
+		{name: "async flush/drop", maxInFlight: 2, policy: DropWhenFull},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			w := &BufferedWriteSyncer{
+				WS:           slowWriteSyncer{delay: 50 * time.Microsecond},
+				Size:         16 * 1024,
+				MaxInFlight:  tt.maxInFlight,
+				Backpressure: tt.policy,
+			}
+			defer func() {
+				assert.NoError(b, w.Stop(), "failed to stop buffered write syncer")
+			}()
+			msg := []byte("foobarbazbabble\n")
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := w.Write(msg); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
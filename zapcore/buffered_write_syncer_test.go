@@ -146,3 +146,160 @@ func TestBufferWriterWithoutStart(t *testing.T) {
 		assert.NoError(t, ws.Sync(), "Sync must not fail")
 	})
 }
+
+// blockingWriter is a WriteSyncer whose writes wait to be released.
+type blockingWriter struct {
+	bytes.Buffer
+
+	started chan struct{} // receives when a write starts
+	release chan struct{} // closed to let writes finish
+	synced  chan struct{} // receives on each sync
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+		synced:  make(chan struct{}, 10),
+	}
+}
+
+func (w *blockingWriter) Sync() error {
+	w.synced <- struct{}{}
+	return nil
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.started <- struct{}{}
+	<-w.release
+	return w.Buffer.Write(p)
+}
+
+func TestBufferWriterAsync(t *testing.T) {
+	t.Run("drop when full", func(t *testing.T) {
+		w := newBlockingWriter()
+		ws := &BufferedWriteSyncer{WS: w, Size: 3, MaxInFlight: 1, Backpressure: DropWhenFull}
+
+		writeAll(t, ws, "aaa", "bbb")
+		<-w.started
+
+		// "aaa" is being written, so "bbb" waits in flight and "ddd" has
+		// nowhere to go.
+		writeAll(t, ws, "ccc", "ddd")
+		assert.Equal(t, BufferedStats{DroppedWrites: 1, DroppedBytes: 3}, ws.Stats(), "Unexpected stats.")
+
+		close(w.release)
+		assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+		assert.Equal(t, "aaabbbccc", w.String(), "Unexpected output.")
+		assert.Len(t, w.synced, 1, "Expected Stop to sync.")
+	})
+
+	t.Run("block when full", func(t *testing.T) {
+		w := newBlockingWriter()
+		ws := &BufferedWriteSyncer{WS: w, Size: 3, MaxInFlight: 1}
+
+		writeAll(t, ws, "aaa", "bbb")
+		<-w.started
+		writeAll(t, ws, "ccc")
+
+		blocked := make(chan struct{})
+		go func() {
+			defer close(blocked)
+			writeAll(t, ws, "ddd")
+		}()
+		select {
+		case <-blocked:
+			t.Fatal("Expected the write to wait for a buffer.")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		close(w.release)
+		<-blocked
+		assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+		assert.Equal(t, "aaabbbcccddd", w.String(), "Unexpected output.")
+		assert.Equal(t, BufferedStats{}, ws.Stats(), "Expected nothing to be dropped.")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		ws := &BufferedWriteSyncer{WS: buf, MaxInFlight: 1}
+		defer ws.Stop()
+
+		writeAll(t, ws, "foo")
+		assert.Empty(t, buf.String(), "Expected the write to be buffered.")
+		assert.NoError(t, ws.Sync(), "Unexpected error syncing.")
+		assert.Equal(t, "foo", buf.String(), "Expected Sync to write the buffer.")
+		assert.True(t, buf.Called(), "Expected Sync to sync WS.")
+	})
+
+	t.Run("sync on flush", func(t *testing.T) {
+		w := newBlockingWriter()
+		close(w.release)
+		ws := &BufferedWriteSyncer{WS: w, Size: 3, MaxInFlight: 1, SyncOnFlush: true}
+
+		writeAll(t, ws, "aaa", "bbb")
+		select {
+		case <-w.synced:
+		case <-time.After(time.Second):
+			t.Fatal("Expected WS to be synced after a flush.")
+		}
+		assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ws := &BufferedWriteSyncer{WS: &ztest.FailWriter{}, Size: 3, MaxInFlight: 1}
+		writeAll(t, ws, "aaa", "bbb")
+		assert.Error(t, ws.Sync(), "Expected Sync to report the failed writes.")
+		assert.NoError(t, ws.Sync(), "Expected errors to be reported once.")
+		writeAll(t, ws, "ccc")
+		assert.Error(t, ws.Stop(), "Expected Stop to report the failed write.")
+	})
+
+	t.Run("flush timer", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		clock := ztest.NewMockClock()
+		ws := &BufferedWriteSyncer{WS: Lock(buf), FlushInterval: time.Second, Clock: clock, MaxInFlight: 1}
+		defer ws.Stop()
+
+		writeAll(t, ws, "foo")
+		clock.Add(time.Second)
+		assert.Eventually(t, func() bool {
+			return bufString(ws) == "foo"
+		}, time.Second, time.Millisecond, "Expected the buffer to be flushed in the background.")
+	})
+
+	t.Run("write after stop", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		ws := &BufferedWriteSyncer{WS: buf, MaxInFlight: 1}
+		writeAll(t, ws, "foo")
+		assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+		writeAll(t, ws, "bar")
+		assert.Equal(t, "foobar", buf.String(), "Expected writes after Stop to go straight to WS.")
+		assert.NoError(t, ws.Sync(), "Unexpected error syncing after Stop.")
+	})
+}
+
+// bufString reads a locked ztest.Buffer wrapped by a BufferedWriteSyncer.
+func bufString(ws *BufferedWriteSyncer) string {
+	locked := ws.WS.(*lockedWriteSyncer)
+	locked.Lock()
+	defer locked.Unlock()
+	return locked.ws.(*ztest.Buffer).String()
+}
+
+func TestBackpressurePolicyText(t *testing.T) {
+	for _, p := range []BackpressurePolicy{BlockWhenFull, DropWhenFull} {
+		text, err := p.MarshalText()
+		require.NoError(t, err, "Unexpected error marshaling %v.", p)
+
+		var got BackpressurePolicy
+		require.NoError(t, got.UnmarshalText(text), "Unexpected error unmarshaling %q.", text)
+		assert.Equal(t, p, got, "Expected %q to round-trip.", text)
+	}
+
+	var p BackpressurePolicy
+	assert.NoError(t, p.UnmarshalText(nil), "Expected the empty string to unmarshal.")
+	assert.Equal(t, BlockWhenFull, p, "Expected the empty string to be BlockWhenFull.")
+	assert.Error(t, p.UnmarshalText([]byte("shrug")), "Expected an error for an unknown policy.")
+	assert.Equal(t, "BackpressurePolicy(42)", BackpressurePolicy(42).String(), "Unexpected string for an unknown policy.")
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// CBORDecoder reads entries written by the CBOR encoder. It accepts any
+// stream of CBOR data items whose top level is a map with text string keys.
+//
+// Values are decoded as
+//
+//   - maps as map[string]interface{}, and arrays as []interface{}
+//   - text strings as string, and byte strings as []byte
+//   - integers as int64, or as uint64 if they don't fit in an int64
+//   - floating-point numbers as float64, or as float32 if they were
+//     written with single or half precision
+//   - booleans as bool, and null and undefined as nil
+//   - tags 1 and 1001 as time.Time in UTC, and tag 1002 as time.Duration
+//
+// Other tags are dropped in favor of their content.
+type CBORDecoder struct {
+	r binaryReader
+}
+
+// NewCBORDecoder creates a CBORDecoder reading from r.
+func NewCBORDecoder(r io.Reader) *CBORDecoder {
+	return &CBORDecoder{r: newBinaryReader(r)}
+}
+
+// Decode reads the next entry. It returns io.EOF once the input is
+// exhausted.
+func (d *CBORDecoder) Decode() (map[string]interface{}, error) {
+	if err := d.r.start(); err != nil {
+		return nil, err
+	}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("cbor: %w", err)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cbor: expected a map, got %T", v)
+	}
+	return m, nil
+}
+
+// head reads the initial byte of a data item and its argument. For
+// indefinite-length items, indefinite is true.
+func (d *CBORDecoder) head() (major, info byte, arg uint64, indefinite bool, err error) {
+	c, err := d.r.readByte()
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	major, info = c&0xe0, c&0x1f
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		arg, err = d.r.readUint(1 << (info - 24))
+	case info == _cborIndefinite:
+		indefinite = true
+	default:
+		err = fmt.Errorf("invalid additional information %d", info)
+	}
+	return major, info, arg, indefinite, err
+}
+
+// isBreak consumes the next byte if it's the break stop code.
+func (d *CBORDecoder) isBreak() (bool, error) {
+	c, err := d.r.peekByte()
+	if err != nil || c != _cborBreak {
+		return false, err
+	}
+	_, err = d.r.readByte()
+	return true, err
+}
+
+func (d *CBORDecoder) value(depth int) (interface{}, error) {
+	if depth > _maxDecodeDepth {
+		return nil, errDecodeTooDeep
+	}
+
+	major, info, arg, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	if indefinite && (major == _cborUint || major == _cborNegint || major == _cborTag) {
+		return nil, fmt.Errorf("invalid indefinite length for major type %d", major>>5)
+	}
+
+	switch major {
+	case _cborUint:
+		return decodedInt(arg), nil
+	case _cborNegint:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer -1-%d overflows int64", arg)
+		}
+		return -1 - int64(arg), nil
+	case _cborBytes, _cborText:
+		b, err := d.str(major, arg, indefinite)
+		if err != nil || major == _cborBytes {
+			return b, err
+		}
+		return string(b), nil
+	case _cborArray:
+		return d.array(arg, indefinite, depth)
+	case _cborMap:
+		return d.dict(arg, indefinite, depth)
+	case _cborTag:
+		return d.tag(arg, depth)
+	}
+
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return halfToFloat32(uint16(arg)), nil
+	case 26:
+		return math.Float32frombits(uint32(arg)), nil
+	case 27:
+		return math.Float64frombits(arg), nil
+	case _cborIndefinite:
+		return nil, fmt.Errorf("unexpected break")
+	}
+	return nil, fmt.Errorf("unsupported simple value %d", arg)
+}
+
+// str reads the content of a byte or text string, joining the chunks of
+// indefinite-length strings.
+func (d *CBORDecoder) str(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		return d.r.readBytes(n)
+	}
+	var b []byte
+	for {
+		if brk, err := d.isBreak(); err != nil || brk {
+			return b, err
+		}
+		chunkMajor, _, n, indefinite, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || indefinite {
+			return nil, fmt.Errorf("invalid chunk in indefinite-length string")
+		}
+		chunk, err := d.r.readBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, chunk...)
+	}
+}
+
+func (d *CBORDecoder) array(n uint64, indefinite bool, depth int) ([]interface{}, error) {
+	arr := make([]interface{}, 0, preallocLen(n))
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if brk, err := d.isBreak(); err != nil {
+				return nil, err
+			} else if brk {
+				break
+			}
+		}
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (d *CBORDecoder) dict(n uint64, indefinite bool, depth int) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, preallocLen(n))
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if brk, err := d.isBreak(); err != nil {
+				return nil, err
+			} else if brk {
+				break
+			}
+		}
+		k, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a text string key, got %T", k)
+		}
+		if m[key], err = d.value(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (d *CBORDecoder) tag(tag uint64, depth int) (interface{}, error) {
+	switch tag {
+	case _cborTagEpochTime:
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		case float32:
+			return floatTime(float64(v)), nil
+		case float64:
+			return floatTime(v), nil
+		}
+		return nil, fmt.Errorf("invalid content %T for tag 1", v)
+	case _cborTagExtTime, _cborTagDuration:
+		sec, nsec, err := d.seconds(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tag == _cborTagDuration {
+			return time.Duration(sec)*time.Second + time.Duration(nsec), nil
+		}
+		return time.Unix(sec, nsec).UTC(), nil
+	}
+	return d.value(depth + 1)
+}
+
+// seconds reads the map used by RFC 9581 times and durations, which has
+// integer keys.
+func (d *CBORDecoder) seconds(depth int) (sec, nsec int64, err error) {
+	major, _, n, indefinite, err := d.head()
+	if err != nil {
+		return 0, 0, err
+	}
+	if major != _cborMap || indefinite {
+		return 0, 0, fmt.Errorf("invalid content for time or duration")
+	}
+	for i := uint64(0); i < n; i++ {
+		k, err := d.value(depth + 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		num, _ := v.(int64)
+		switch k {
+		case int64(_cborKeySeconds):
+			sec = num
+		case int64(_cborKeyNanosecond):
+			nsec = num
+		}
+	}
+	return sec, nsec, nil
+}
+
+func floatTime(f float64) time.Time {
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
+
+// halfToFloat32 converts an IEEE 754 half-precision number.
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+	switch exp {
+	case 0:
+		// Zero or subnormal.
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		// Infinity or NaN.
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+// CBOR major types, already shifted into the high bits of the initial byte.
+const (
+	_cborUint   = 0 << 5
+	_cborNegint = 1 << 5
+	_cborBytes  = 2 << 5
+	_cborText   = 3 << 5
+	_cborArray  = 4 << 5
+	_cborMap    = 5 << 5
+	_cborTag    = 6 << 5
+	_cborSimple = 7 << 5
+)
+
+// CBOR initial bytes and tags with special meaning.
+const (
+	_cborFalse         = _cborSimple | 20
+	_cborTrue          = _cborSimple | 21
+	_cborNull          = _cborSimple | 22
+	_cborUndefined     = _cborSimple | 23
+	_cborFloat16       = _cborSimple | 25
+	_cborFloat32       = _cborSimple | 26
+	_cborFloat64       = _cborSimple | 27
+	_cborBreak         = _cborSimple | 31
+	_cborIndefinite    = 31
+	_cborTagEpochTime  = 1
+	_cborTagExtTime    = 1001 // RFC 9581
+	_cborTagDuration   = 1002 // RFC 9581
+	_cborKeySeconds    = 1
+	_cborKeyNanosecond = -9
+)
+
+// NewCBOREncoder creates an encoder whose output is a stream of CBOR (RFC
+// 8949) data items, one per entry. Each entry is an indefinite-length map
+// holding the same keys as the JSON encoder's output.
+//
+// Times, durations, and binary data are encoded natively, so the
+// EncodeTime and EncodeDuration settings of the EncoderConfig are ignored.
+// Times are written as epoch-based date/times (tag 1), or as extended times
+// (tag 1001) if they have sub-second precision. Durations are written with
+// tag 1002. Complex numbers are written as an array of their real and
+// imaginary parts. Output written with this encoder can be read back with a
+// CBORDecoder.
+func NewCBOREncoder(cfg EncoderConfig) Encoder {
+	return newBinaryEncoder(cfg, cborFormat{})
+}
+
+type cborFormat struct{}
+
+func (cborFormat) appendHead(buf *buffer.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		buf.AppendByte(major | byte(n))
+	case n <= math.MaxUint8:
+		buf.AppendByte(major | 24)
+		buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(major | 25)
+		appendUint16(buf, uint16(n))
+	case n <= math.MaxUint32:
+		buf.AppendByte(major | 26)
+		appendUint32(buf, uint32(n))
+	default:
+		buf.AppendByte(major | 27)
+		appendUint64(buf, n)
+	}
+}
+
+func (cborFormat) appendNil(buf *buffer.Buffer) {
+	buf.AppendByte(_cborNull)
+}
+
+func (cborFormat) appendBool(buf *buffer.Buffer, v bool) {
+	if v {
+		buf.AppendByte(_cborTrue)
+	} else {
+		buf.AppendByte(_cborFalse)
+	}
+}
+
+func (f cborFormat) appendInt(buf *buffer.Buffer, v int64) {
+	if v < 0 {
+		f.appendHead(buf, _cborNegint, uint64(-1-v))
+		return
+	}
+	f.appendHead(buf, _cborUint, uint64(v))
+}
+
+func (f cborFormat) appendUint(buf *buffer.Buffer, v uint64) {
+	f.appendHead(buf, _cborUint, v)
+}
+
+func (cborFormat) appendFloat32(buf *buffer.Buffer, v float32) {
+	buf.AppendByte(_cborFloat32)
+	appendUint32(buf, math.Float32bits(v))
+}
+
+func (cborFormat) appendFloat64(buf *buffer.Buffer, v float64) {
+	buf.AppendByte(_cborFloat64)
+	appendUint64(buf, math.Float64bits(v))
+}
+
+// Text strings must be valid UTF-8, so invalid sequences are replaced with
+// utf8.RuneError, as in the JSON encoder.
+func (f cborFormat) appendString(buf *buffer.Buffer, s string) {
+	if !utf8.ValidString(s) {
+		s = toValidUTF8(s)
+	}
+	f.appendHead(buf, _cborText, uint64(len(s)))
+	buf.AppendString(s)
+}
+
+func (f cborFormat) appendByteString(buf *buffer.Buffer, s []byte) {
+	if !utf8.Valid(s) {
+		f.appendString(buf, string(s))
+		return
+	}
+	f.appendHead(buf, _cborText, uint64(len(s)))
+	buf.Write(s)
+}
+
+func (f cborFormat) appendBinary(buf *buffer.Buffer, v []byte) {
+	f.appendHead(buf, _cborBytes, uint64(len(v)))
+	buf.Write(v)
+}
+
+func (f cborFormat) appendTime(buf *buffer.Buffer, t time.Time) {
+	if t.Nanosecond() == 0 {
+		f.appendHead(buf, _cborTag, _cborTagEpochTime)
+		f.appendInt(buf, t.Unix())
+		return
+	}
+	f.appendHead(buf, _cborTag, _cborTagExtTime)
+	f.appendSeconds(buf, t.Unix(), int64(t.Nanosecond()))
+}
+
+func (f cborFormat) appendDuration(buf *buffer.Buffer, d time.Duration) {
+	f.appendHead(buf, _cborTag, _cborTagDuration)
+	f.appendSeconds(buf, int64(d/time.Second), int64(d%time.Second))
+}
+
+// appendSeconds writes the map used by RFC 9581 times and durations.
+func (f cborFormat) appendSeconds(buf *buffer.Buffer, sec, nsec int64) {
+	if nsec == 0 {
+		f.appendHead(buf, _cborMap, 1)
+		f.appendInt(buf, _cborKeySeconds)
+		f.appendInt(buf, sec)
+		return
+	}
+	f.appendHead(buf, _cborMap, 2)
+	f.appendInt(buf, _cborKeySeconds)
+	f.appendInt(buf, sec)
+	f.appendInt(buf, _cborKeyNanosecond)
+	f.appendInt(buf, nsec)
+}
+
+func (cborFormat) openMap(buf *buffer.Buffer) {
+	buf.AppendByte(_cborMap | _cborIndefinite)
+}
+
+func (cborFormat) closeMap(buf *buffer.Buffer, _, _ int) {
+	buf.AppendByte(_cborBreak)
+}
+
+func (cborFormat) openArray(buf *buffer.Buffer) {
+	buf.AppendByte(_cborArray | _cborIndefinite)
+}
+
+func (cborFormat) closeArray(buf *buffer.Buffer, _, _ int) {
+	buf.AppendByte(_cborBreak)
+}
+
+func appendUint16(buf *buffer.Buffer, v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	buf.Write(b[:])
+}
+
+func appendUint32(buf *buffer.Buffer, v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func appendUint64(buf *buffer.Buffer, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+// toValidUTF8 replaces each invalid byte in s with utf8.RuneError.
+func toValidUTF8(s string) string {
+	b := make([]byte, 0, len(s)+8)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = utf8.AppendRune(b, utf8.RuneError)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return string(b)
+}
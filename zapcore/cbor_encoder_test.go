@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+)
+
+func appendHex(f func(*buffer.Buffer)) string {
+	buf := bufferpool.Get()
+	defer buf.Free()
+	f(buf)
+	return hex.EncodeToString(buf.Bytes())
+}
+
+// Expected values are from Appendix A of RFC 8949.
+func TestCBORFormat(t *testing.T) {
+	var f cborFormat
+	tests := []struct {
+		desc   string
+		append func(*buffer.Buffer)
+		want   string
+	}{
+		{"0", func(b *buffer.Buffer) { f.appendUint(b, 0) }, "00"},
+		{"23", func(b *buffer.Buffer) { f.appendUint(b, 23) }, "17"},
+		{"24", func(b *buffer.Buffer) { f.appendUint(b, 24) }, "1818"},
+		{"1000", func(b *buffer.Buffer) { f.appendInt(b, 1000) }, "1903e8"},
+		{"1000000", func(b *buffer.Buffer) { f.appendInt(b, 1000000) }, "1a000f4240"},
+		{"1000000000000", func(b *buffer.Buffer) { f.appendInt(b, 1000000000000) }, "1b000000e8d4a51000"},
+		{"max uint64", func(b *buffer.Buffer) { f.appendUint(b, math.MaxUint64) }, "1bffffffffffffffff"},
+		{"-1", func(b *buffer.Buffer) { f.appendInt(b, -1) }, "20"},
+		{"-100", func(b *buffer.Buffer) { f.appendInt(b, -100) }, "3863"},
+		{"-1000", func(b *buffer.Buffer) { f.appendInt(b, -1000) }, "3903e7"},
+		{"min int64", func(b *buffer.Buffer) { f.appendInt(b, math.MinInt64) }, "3b7fffffffffffffff"},
+		{"float64", func(b *buffer.Buffer) { f.appendFloat64(b, 1.1) }, "fb3ff199999999999a"},
+		{"float32", func(b *buffer.Buffer) { f.appendFloat32(b, 100000) }, "fa47c35000"},
+		{"false", func(b *buffer.Buffer) { f.appendBool(b, false) }, "f4"},
+		{"true", func(b *buffer.Buffer) { f.appendBool(b, true) }, "f5"},
+		{"null", func(b *buffer.Buffer) { f.appendNil(b) }, "f6"},
+		{"empty string", func(b *buffer.Buffer) { f.appendString(b, "") }, "60"},
+		{"string", func(b *buffer.Buffer) { f.appendString(b, "IETF") }, "6449455446"},
+		{"invalid string", func(b *buffer.Buffer) { f.appendString(b, "\xff") }, "63efbfbd"},
+		{"byte string", func(b *buffer.Buffer) { f.appendByteString(b, []byte("a")) }, "6161"},
+		{"binary", func(b *buffer.Buffer) { f.appendBinary(b, []byte{1, 2, 3, 4}) }, "4401020304"},
+		{"time", func(b *buffer.Buffer) { f.appendTime(b, time.Unix(1363896240, 0)) }, "c11a514b67b0"},
+		{
+			"time with nanoseconds",
+			func(b *buffer.Buffer) { f.appendTime(b, time.Unix(1363896240, 5)) },
+			"d903e9a2011a514b67b02805",
+		},
+		{"duration", func(b *buffer.Buffer) { f.appendDuration(b, time.Minute) }, "d903eaa101183c"},
+		{
+			"map",
+			func(b *buffer.Buffer) {
+				f.openMap(b)
+				f.appendString(b, "a")
+				f.openArray(b)
+				f.appendInt(b, 1)
+				f.closeArray(b, 1, 1)
+				f.closeMap(b, 0, 1)
+			},
+			"bf61619f01ffff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, appendHex(tt.append), "Unexpected CBOR encoding.")
+		})
+	}
+}
+
+func TestCBORDecoder(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want interface{}
+	}{
+		{"definite map", "a16161a26161016162820203", map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+		{"indefinite byte string", "a161615f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"indefinite text string", "a161617f657374726561646d696e67ff", "streaming"},
+		{"half float", "a16161f93c00", float32(1)},
+		{"largest half float", "a16161f97bff", float32(65504)},
+		{"subnormal half float", "a16161f90001", float32(5.960464477539063e-8)},
+		{"negative infinity", "a16161f9fc00", float32(math.Inf(-1))},
+		{"undefined", "a16161f7", nil},
+		{"large uint", "a161611bffffffffffffffff", uint64(math.MaxUint64)},
+		{"float time", "a16161c1fb41d452d9ec200000", time.Unix(1363896240, 5e8).UTC()},
+		{"unknown tag", "a16161d82076687474703a2f2f7777772e6578616d706c652e636f6d", "http://www.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			require.NoError(t, err, "Invalid test input.")
+			got, err := NewCBORDecoder(bytes.NewReader(in)).Decode()
+			require.NoError(t, err, "Unexpected decoding error.")
+			assert.Equal(t, tt.want, got["a"], "Unexpected decoded value.")
+		})
+	}
+}
+
+func TestCBORDecoderErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{"truncated", "bf6161", "cbor: unexpected EOF"},
+		{"truncated string", "a161617a00010000", "cbor: unexpected EOF"},
+		{"not a map", "01", "cbor: expected a map, got int64"},
+		{"integer key", "a10101", "cbor: expected a text string key, got int64"},
+		{"unexpected break", "a16161ff", "cbor: unexpected break"},
+		{"invalid additional information", "a161611c", "cbor: invalid additional information 28"},
+		{"indefinite integer", "a161611f", "cbor: invalid indefinite length for major type 0"},
+		{"negative integer overflow", "a161613bffffffffffffffff", "cbor: negative integer -1-18446744073709551615 overflows int64"},
+		{"invalid chunk", "a161615f6161ff", "cbor: invalid chunk in indefinite-length string"},
+		{"invalid time", "a16161c16161", "cbor: invalid content string for tag 1"},
+		{"too deep", "a16161" + strings.Repeat("81", _maxDecodeDepth+1) + "00", "cbor: maximum nesting depth exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			require.NoError(t, err, "Invalid test input.")
+			_, err = NewCBORDecoder(bytes.NewReader(in)).Decode()
+			assert.EqualError(t, err, tt.want, "Unexpected decoding error.")
+		})
+	}
+
+	_, err := NewCBORDecoder(bytes.NewReader(nil)).Decode()
+	assert.Equal(t, io.EOF, err, "Expected io.EOF from empty input.")
+}
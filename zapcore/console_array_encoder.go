@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/pool"
+)
+
+var _consoleArrayPool = pool.New(func() *consoleArrayEncoder {
+	return &consoleArrayEncoder{}
+})
+
+func getConsoleArrayEncoder(buf *buffer.Buffer, sep string) *consoleArrayEncoder {
+	enc := _consoleArrayPool.Get()
+	enc.buf = buf
+	enc.sep = sep
+	return enc
+}
+
+func putConsoleArrayEncoder(enc *consoleArrayEncoder) {
+	enc.buf = nil
+	enc.sep = ""
+	enc.escape = NoEscaping
+	enc.elems = 0
+	_consoleArrayPool.Put(enc)
+}
+
+// consoleArrayEncoder is an ArrayEncoder that writes the console encoder's
+// metadata straight into a buffer, separating elements with the console
+// separator. Each element is formatted the way fmt.Print would format it,
+// but primitives are written without boxing them into interfaces.
+type consoleArrayEncoder struct {
+	buf    *buffer.Buffer
+	sep    string
+	escape EscapeMode
+	elems  int
+
+	// scratch holds formatted numbers before they're copied into buf.
+	scratch [64]byte
+}
+
+func (enc *consoleArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	arr := &sliceArrayEncoder{}
+	err := v.MarshalLogArray(arr)
+	enc.appendSprint(arr.elems)
+	return err
+}
+
+func (enc *consoleArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	m := NewMapObjectEncoder()
+	err := v.MarshalLogObject(m)
+	enc.appendSprint(m.Fields)
+	return err
+}
+
+func (enc *consoleArrayEncoder) AppendReflected(v interface{}) error {
+	enc.appendSprint(v)
+	return nil
+}
+
+func (enc *consoleArrayEncoder) AppendBool(v bool) {
+	enc.element()
+	enc.buf.AppendBool(v)
+}
+
+func (enc *consoleArrayEncoder) AppendByteString(v []byte) {
+	enc.element()
+	if enc.escape == NoEscaping {
+		_, _ = enc.buf.Write(v)
+		return
+	}
+	enc.escape.appendEscaped(enc.buf, string(v))
+}
+
+func (enc *consoleArrayEncoder) AppendComplex128(v complex128) { enc.appendComplex(v, 64) }
+func (enc *consoleArrayEncoder) AppendComplex64(v complex64)   { enc.appendComplex(complex128(v), 32) }
+
+func (enc *consoleArrayEncoder) AppendDuration(v time.Duration) {
+	enc.AppendString(v.String())
+}
+
+func (enc *consoleArrayEncoder) AppendFloat64(v float64) {
+	enc.element()
+	enc.appendFloat(v, 64)
+}
+
+func (enc *consoleArrayEncoder) AppendFloat32(v float32) {
+	enc.element()
+	enc.appendFloat(float64(v), 32)
+}
+
+func (enc *consoleArrayEncoder) AppendInt(v int)     { enc.AppendInt64(int64(v)) }
+func (enc *consoleArrayEncoder) AppendInt32(v int32) { enc.AppendInt64(int64(v)) }
+func (enc *consoleArrayEncoder) AppendInt16(v int16) { enc.AppendInt64(int64(v)) }
+func (enc *consoleArrayEncoder) AppendInt8(v int8)   { enc.AppendInt64(int64(v)) }
+
+func (enc *consoleArrayEncoder) AppendInt64(v int64) {
+	enc.element()
+	enc.buf.AppendInt(v)
+}
+
+func (enc *consoleArrayEncoder) AppendString(v string) {
+	enc.element()
+	enc.escape.appendEscaped(enc.buf, v)
+}
+
+func (enc *consoleArrayEncoder) AppendTime(v time.Time) {
+	enc.AppendString(v.String())
+}
+
+func (enc *consoleArrayEncoder) AppendUint(v uint)       { enc.AppendUint64(uint64(v)) }
+func (enc *consoleArrayEncoder) AppendUint32(v uint32)   { enc.AppendUint64(uint64(v)) }
+func (enc *consoleArrayEncoder) AppendUint16(v uint16)   { enc.AppendUint64(uint64(v)) }
+func (enc *consoleArrayEncoder) AppendUint8(v uint8)     { enc.AppendUint64(uint64(v)) }
+func (enc *consoleArrayEncoder) AppendUintptr(v uintptr) { enc.AppendUint64(uint64(v)) }
+
+func (enc *consoleArrayEncoder) AppendUint64(v uint64) {
+	enc.element()
+	enc.buf.AppendUint(v)
+}
+
+func (enc *consoleArrayEncoder) element() {
+	if enc.elems > 0 {
+		enc.buf.AppendString(enc.sep)
+	}
+	enc.elems++
+}
+
+// appendSprint appends values that don't have a plain-text form of their
+// own, like objects, in fmt's default format.
+func (enc *consoleArrayEncoder) appendSprint(v interface{}) {
+	enc.AppendString(fmt.Sprint(v))
+}
+
+func (enc *consoleArrayEncoder) appendFloat(v float64, bitSize int) {
+	_, _ = enc.buf.Write(strconv.AppendFloat(enc.scratch[:0], v, 'g', -1, bitSize))
+}
+
+// appendComplex matches fmt's format for complex numbers, like "(1+2i)".
+func (enc *consoleArrayEncoder) appendComplex(v complex128, bitSize int) {
+	enc.element()
+	enc.buf.AppendByte('(')
+	enc.appendFloat(real(v), bitSize)
+	im := strconv.AppendFloat(enc.scratch[:0], imag(v), 'g', -1, bitSize)
+	if im[0] != '+' && im[0] != '-' {
+		enc.buf.AppendByte('+')
+	}
+	_, _ = enc.buf.Write(im)
+	enc.buf.AppendString("i)")
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap/buffer"
+)
+
+func TestConsoleArrayEncoderMatchesFmt(t *testing.T) {
+	// The console encoder used to format its metadata with fmt.Print, so
+	// output must stay the same.
+	tests := []struct {
+		desc   string
+		f      func(ArrayEncoder)
+		expect interface{}
+	}{
+		{"bool", func(e ArrayEncoder) { e.AppendBool(true) }, true},
+		{"byte string", func(e ArrayEncoder) { e.AppendByteString([]byte("foo")) }, "foo"},
+		{"complex128", func(e ArrayEncoder) { e.AppendComplex128(1 + 2i) }, 1 + 2i},
+		{"complex128 negative", func(e ArrayEncoder) { e.AppendComplex128(-1.5 - 2e30i) }, -1.5 - 2e30i},
+		{"complex128 NaN", func(e ArrayEncoder) { e.AppendComplex128(complex(0, math.NaN())) }, complex(0, math.NaN())},
+		{"complex64", func(e ArrayEncoder) { e.AppendComplex64(0.1 + 0.2i) }, complex64(0.1 + 0.2i)},
+		{"duration", func(e ArrayEncoder) { e.AppendDuration(time.Second) }, time.Second},
+		{"float64", func(e ArrayEncoder) { e.AppendFloat64(3.14) }, 3.14},
+		{"float64 large", func(e ArrayEncoder) { e.AppendFloat64(1e21) }, 1e21},
+		{"float64 +Inf", func(e ArrayEncoder) { e.AppendFloat64(math.Inf(1)) }, math.Inf(1)},
+		{"float32", func(e ArrayEncoder) { e.AppendFloat32(3.14) }, float32(3.14)},
+		{"int", func(e ArrayEncoder) { e.AppendInt(-42) }, -42},
+		{"int64", func(e ArrayEncoder) { e.AppendInt64(math.MinInt64) }, int64(math.MinInt64)},
+		{"int32", func(e ArrayEncoder) { e.AppendInt32(42) }, int32(42)},
+		{"int16", func(e ArrayEncoder) { e.AppendInt16(42) }, int16(42)},
+		{"int8", func(e ArrayEncoder) { e.AppendInt8(42) }, int8(42)},
+		{"string", func(e ArrayEncoder) { e.AppendString("foo") }, "foo"},
+		{"time", func(e ArrayEncoder) { e.AppendTime(time.Unix(0, 0).UTC()) }, time.Unix(0, 0).UTC()},
+		{"uint", func(e ArrayEncoder) { e.AppendUint(42) }, uint(42)},
+		{"uint64", func(e ArrayEncoder) { e.AppendUint64(math.MaxUint64) }, uint64(math.MaxUint64)},
+		{"uint32", func(e ArrayEncoder) { e.AppendUint32(42) }, uint32(42)},
+		{"uint16", func(e ArrayEncoder) { e.AppendUint16(42) }, uint16(42)},
+		{"uint8", func(e ArrayEncoder) { e.AppendUint8(42) }, uint8(42)},
+		{"uintptr", func(e ArrayEncoder) { e.AppendUintptr(42) }, uintptr(42)},
+		{
+			desc: "object",
+			f: func(e ArrayEncoder) {
+				assert.NoError(t, e.AppendObject(loggable{true}), "Unexpected error appending object.")
+			},
+			expect: map[string]interface{}{"loggable": "yes"},
+		},
+		{
+			desc: "array",
+			f: func(e ArrayEncoder) {
+				assert.NoError(t, e.AppendArray(ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+					arr.AppendInt(1)
+					arr.AppendString("two")
+					return nil
+				})), "Unexpected error appending array.")
+			},
+			expect: []interface{}{1, "two"},
+		},
+		{
+			desc: "reflected",
+			f: func(e ArrayEncoder) {
+				assert.NoError(t, e.AppendReflected(struct{ A int }{1}), "Unexpected error appending reflected.")
+			},
+			expect: struct{ A int }{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			enc := getConsoleArrayEncoder(buf, " | ")
+			defer putConsoleArrayEncoder(enc)
+
+			tt.f(enc)
+			tt.f(enc)
+			want := fmt.Sprint(tt.expect)
+			assert.Equal(t, want+" | "+want, buf.String(), "Unexpected output.")
+		})
+	}
+}
+
+func TestConsoleArrayEncoderErrors(t *testing.T) {
+	buf := &buffer.Buffer{}
+	enc := getConsoleArrayEncoder(buf, " ")
+	defer putConsoleArrayEncoder(enc)
+
+	fail := errors.New("fail")
+	assert.Equal(t, fail, enc.AppendObject(ObjectMarshalerFunc(func(ObjectEncoder) error {
+		return fail
+	})), "Expected object errors to be returned.")
+	assert.Equal(t, fail, enc.AppendArray(ArrayMarshalerFunc(func(ArrayEncoder) error {
+		return fail
+	})), "Expected array errors to be returned.")
+}
@@ -21,8 +21,6 @@
 package zapcore
 
 import (
-	"fmt"
-
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/internal/bufferpool"
 	"go.uber.org/zap/internal/pool"
@@ -68,21 +66,28 @@ func (c consoleEncoder) Clone() Encoder {
 }
 
 func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	line := c.encodeEntry(ent, fields, true)
+	if max := c.Limits.MaxEntrySize; max > 0 && line.Len() > max {
+		line = c.Limits.fitEntry(line, ent, fields, c.encodeEntry)
+	}
+	return line, nil
+}
+
+func (c consoleEncoder) encodeEntry(ent Entry, fields []Field, withContext bool) *buffer.Buffer {
 	line := bufferpool.Get()
 
 	// We don't want the entry's metadata to be quoted and escaped (if it's
-	// encoded as strings), which means that we can't use the JSON encoder. The
-	// simplest option is to use the memory encoder and fmt.Fprint.
-	//
-	// If this ever becomes a performance bottleneck, we can implement
-	// ArrayEncoder for our plain-text format.
-	arr := getSliceEncoder()
+	// encoded as strings), which means that we can't use the JSON encoder.
+	// Instead, write it in a plain-text format directly into the line.
+	arr := getConsoleArrayEncoder(line, c.ConsoleSeparator)
 	if c.TimeKey != "" && c.EncodeTime != nil && !ent.Time.IsZero() {
 		c.EncodeTime(ent.Time, arr)
 	}
 	if c.LevelKey != "" && c.EncodeLevel != nil {
 		c.EncodeLevel(ent.Level, arr)
 	}
+	// The remaining metadata may come from users, so it's escaped.
+	arr.escape = c.ConsoleEscaping
 	if ent.LoggerName != "" && c.NameKey != "" {
 		nameEncoder := c.EncodeName
 
@@ -101,36 +106,40 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 			arr.AppendString(ent.Caller.Function)
 		}
 	}
-	for i := range arr.elems {
-		if i > 0 {
-			line.AppendString(c.ConsoleSeparator)
-		}
-		_, _ = fmt.Fprint(line, arr.elems[i])
-	}
-	putSliceEncoder(arr)
+	putConsoleArrayEncoder(arr)
 
 	// Add the message itself.
 	if c.MessageKey != "" {
 		c.addSeparatorIfNecessary(line)
-		line.AppendString(ent.Message)
+		msg, marker := c.Limits.truncateString(ent.Message)
+		c.ConsoleEscaping.appendEscaped(line, msg)
+		line.AppendString(marker)
 	}
 
 	// Add any structured context.
-	c.writeContext(line, fields)
+	c.writeContext(line, fields, withContext)
 
 	// If there's no stacktrace key, honor that; this allows users to force
 	// single-line output.
 	if ent.Stack != "" && c.StacktraceKey != "" {
 		line.AppendByte('\n')
-		line.AppendString(ent.Stack)
+		stack, marker := c.Limits.truncateString(ent.Stack)
+		line.AppendString(stack)
+		line.AppendString(marker)
 	}
 
 	line.AppendString(c.LineEnding)
-	return line, nil
+	return line
 }
 
-func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) {
-	context := c.jsonEncoder.Clone().(*jsonEncoder)
+func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field, withContext bool) {
+	var context *jsonEncoder
+	if withContext {
+		context = c.jsonEncoder.Clone().(*jsonEncoder)
+	} else {
+		context = c.jsonEncoder.clone()
+		context.openNamespaces = 0
+	}
 	defer func() {
 		// putJSONEncoder assumes the buffer is still used, but we write out the buffer so
 		// we can free it.
@@ -140,6 +149,7 @@ func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) {
 
 	addFields(context, extra)
 	context.closeOpenNamespaces()
+	context.closeKeyScope()
 	if context.buf.Len() == 0 {
 		return
 	}
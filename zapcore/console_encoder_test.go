@@ -20,10 +20,13 @@
 package zapcore_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	//revive:disable:dot-imports
 	. "go.uber.org/zap/zapcore"
 )
@@ -133,3 +136,46 @@ func encoderTestEncoderConfig(separator string) EncoderConfig {
 	testEncoder.ConsoleSeparator = separator
 	return testEncoder
 }
+
+func FuzzConsoleEncoder(f *testing.F) {
+	f.Add("hello", "main", []byte{0, 0x11, 0x37, 0x48, 0x02}, "value", 1.5, int64(-7))
+	f.Add("a\tb\nc\x1b[31m", "", []byte{0x30, 0x06}, "\t\x7f\u0085", -0.0, int64(1)<<62)
+	f.Add("\xff\u2028", "x\\y\r", []byte{0x09}, "", float64(1)/3, int64(0))
+
+	f.Fuzz(func(t *testing.T, msg, name string, ops []byte, s string, fl float64, i int64) {
+		enc := NewConsoleEncoder(EncoderConfig{
+			MessageKey:      "M",
+			LevelKey:        "L",
+			NameKey:         "N",
+			EncodeLevel:     LowercaseLevelEncoder,
+			ConsoleEscaping: EscapeStrict,
+		})
+		fields, _ := fuzzFields(ops, s, fl, i)
+
+		buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, LoggerName: name, Message: msg}, fields)
+		require.NoError(t, err, "Unexpected error encoding entry.")
+		out := buf.String()
+
+		require.True(t, strings.HasSuffix(out, "\n"), "Expected a line ending in %q.", out)
+		line := strings.TrimSuffix(out, "\n")
+		require.False(t, strings.ContainsAny(line, "\n\r"), "Expected a single line in %q.", out)
+
+		// Escaping leaves the separators as the only tabs, so the line splits
+		// into its level, name, message and context.
+		columns := strings.Split(line, "\t")
+		want := 2
+		if name != "" {
+			want++
+		}
+		if len(columns) == want+1 {
+			assert.True(t, json.Valid([]byte(columns[want])), "Expected a JSON context in %q.", out)
+			columns = columns[:want]
+		}
+		require.Len(t, columns, want, "Unexpected columns in %q.", out)
+		for _, col := range columns {
+			for _, r := range col {
+				assert.False(t, r < 0x20 || r == 0x7f || (0x80 <= r && r < 0xa0), "Unexpected control character %U in %q.", r, out)
+			}
+		}
+	})
+}
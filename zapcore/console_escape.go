@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+// EscapeMode controls how the console and pretty encoders escape the text of
+// log entries - their message, logger name, and caller - to keep user input
+// from forging log lines or sending escape sequences to terminals. Fields are
+// always escaped by the JSON or pretty-printing of their values.
+type EscapeMode uint8
+
+const (
+	// NoEscaping writes text as-is. This is the default, for backwards
+	// compatibility.
+	NoEscaping EscapeMode = iota
+	// EscapeControl escapes control characters, including newlines, tabs,
+	// and the escape character that starts ANSI sequences, with Go-style
+	// escapes like \n and \x1b.
+	EscapeControl
+	// EscapeStrict additionally escapes backslashes, so that escaped text
+	// is unambiguous; invalid UTF-8; and the Unicode line separators and
+	// bidirectional formatting characters that can disguise text.
+	EscapeStrict
+)
+
+// String returns a lower-case ASCII representation of the mode.
+func (m EscapeMode) String() string {
+	switch m {
+	case NoEscaping:
+		return "none"
+	case EscapeControl:
+		return "control"
+	case EscapeStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("EscapeMode(%d)", m)
+	}
+}
+
+// MarshalText marshals the EscapeMode to text.
+func (m EscapeMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText unmarshals text to an EscapeMode. "none" and the empty
+// string are unmarshaled to NoEscaping, "control" to EscapeControl, and
+// "strict" to EscapeStrict.
+func (m *EscapeMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "none", "":
+		*m = NoEscaping
+	case "control":
+		*m = EscapeControl
+	case "strict":
+		*m = EscapeStrict
+	default:
+		return fmt.Errorf("unrecognized escape mode: %q", text)
+	}
+	return nil
+}
+
+// appendEscaped appends s to buf, escaped according to the mode.
+func (m EscapeMode) appendEscaped(buf *buffer.Buffer, s string) {
+	if m == NoEscaping {
+		buf.AppendString(s)
+		return
+	}
+
+	// Like safeAppendStringLike, copy runs of characters that don't need
+	// escaping as-is.
+	last := 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !m.escapes(r, size) {
+			i += size
+			continue
+		}
+
+		buf.AppendString(s[last:i])
+		switch {
+		case r == '\\':
+			buf.AppendString(`\\`)
+		case r == '\n':
+			buf.AppendString(`\n`)
+		case r == '\r':
+			buf.AppendString(`\r`)
+		case r == '\t':
+			buf.AppendString(`\t`)
+		case r == utf8.RuneError && size == 1, r < utf8.RuneSelf:
+			buf.AppendString(`\x`)
+			buf.AppendByte(_hex[s[i]>>4])
+			buf.AppendByte(_hex[s[i]&0xF])
+		default:
+			buf.AppendString(`\u`)
+			for shift := 12; shift >= 0; shift -= 4 {
+				buf.AppendByte(_hex[(r>>shift)&0xF])
+			}
+		}
+		i += size
+		last = i
+	}
+	buf.AppendString(s[last:])
+}
+
+func (m EscapeMode) escapes(r rune, size int) bool {
+	switch {
+	case r < 0x20, r == 0x7f, 0x80 <= r && r < 0xa0:
+		// C0 and C1 control characters, and DEL.
+		return true
+	case m != EscapeStrict:
+		return false
+	case r == '\\', r == utf8.RuneError && size == 1:
+		return true
+	case r == 0x2028, r == 0x2029:
+		// Line and paragraph separators.
+		return true
+	case r == 0x200e, r == 0x200f, 0x202a <= r && r <= 0x202e, 0x2066 <= r && r <= 0x2069:
+		// Bidirectional formatting characters.
+		return true
+	}
+	return false
+}
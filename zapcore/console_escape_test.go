@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestConsoleEscaping(t *testing.T) {
+	tests := []struct {
+		desc    string
+		mode    EscapeMode
+		message string
+		want    string
+	}{
+		{
+			desc:    "none",
+			mode:    NoEscaping,
+			message: "a\nb",
+			want:    "a\nb",
+		},
+		{
+			desc:    "forged line",
+			mode:    EscapeControl,
+			message: "ok\n2018-06-19T16:33:42Z\terror\tforged",
+			want:    `ok\n2018-06-19T16:33:42Z\terror\tforged`,
+		},
+		{
+			desc:    "ANSI sequence",
+			mode:    EscapeControl,
+			message: "\x1b[31mred\x1b[0m",
+			want:    `\x1b[31mred\x1b[0m`,
+		},
+		{
+			desc:    "carriage return, DEL, and C1",
+			mode:    EscapeControl,
+			message: "a\rb\x7fc\u009b",
+			want:    `a\rb\x7fc\u009b`,
+		},
+		{
+			desc:    "control leaves backslashes and Unicode",
+			mode:    EscapeControl,
+			message: "a\\n\u2028\u202e\xff",
+			want:    "a\\n\u2028\u202e\xff",
+		},
+		{
+			desc:    "strict backslash",
+			mode:    EscapeStrict,
+			message: `a\nb`,
+			want:    `a\\nb`,
+		},
+		{
+			desc:    "strict Unicode",
+			mode:    EscapeStrict,
+			message: "\u2028\u2029\u202etxt.exe\u2066",
+			want:    `\u2028\u2029\u202etxt.exe\u2066`,
+		},
+		{
+			desc:    "strict invalid UTF-8",
+			mode:    EscapeStrict,
+			message: "a\xffb\xc3",
+			want:    `a\xffb\xc3`,
+		},
+		{
+			desc:    "printable",
+			mode:    EscapeStrict,
+			message: "héllo, 世界",
+			want:    "héllo, 世界",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.ConsoleEscaping = tt.mode
+			enc := NewConsoleEncoder(cfg)
+
+			buf, err := enc.EncodeEntry(Entry{Message: tt.message}, nil)
+			require.NoError(t, err, "Unexpected console encoding error.")
+			defer buf.Free()
+
+			assert.Equal(t, "info\t"+tt.want+"\n", buf.String(), "Unexpected escaped message.")
+		})
+	}
+}
+
+func TestConsoleEscapingMetadata(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.ConsoleEscaping = EscapeStrict
+	cfg.EncodeLevel = func(l Level, enc PrimitiveArrayEncoder) {
+		// Level encoders may color their output on purpose.
+		enc.AppendString("\x1b[34m" + l.String() + "\x1b[0m")
+	}
+	ent := Entry{
+		LoggerName: "main\nfake",
+		Message:    "msg",
+		Caller:     EntryCaller{Defined: true, File: "a\x1b.go", Line: 1, Function: "f\r"},
+		Stack:      "line one\nline two",
+	}
+
+	t.Run("console", func(t *testing.T) {
+		buf, err := NewConsoleEncoder(cfg).EncodeEntry(ent, nil)
+		require.NoError(t, err, "Unexpected console encoding error.")
+		defer buf.Free()
+
+		assert.Equal(t,
+			"\x1b[34minfo\x1b[0m\t"+`main\nfake`+"\t"+`a\x1b.go:1`+"\t"+`f\r`+"\tmsg\nline one\nline two\n",
+			buf.String(),
+			"Unexpected escaped metadata.",
+		)
+	})
+
+	t.Run("pretty", func(t *testing.T) {
+		cfg := cfg
+		cfg.ConsoleColor = ColorNever
+		ent := ent
+		ent.Stack = ""
+		ent.Message = "m\x1b[2J"
+		buf, err := NewPrettyEncoder(cfg).EncodeEntry(ent, nil)
+		require.NoError(t, err, "Unexpected pretty encoding error.")
+		defer buf.Free()
+
+		assert.Equal(t,
+			`INFO  main\nfake a\x1b.go:1 f\r m\x1b[2J`+"\n",
+			buf.String(),
+			"Unexpected escaped metadata.",
+		)
+	})
+}
+
+func TestEscapeModeText(t *testing.T) {
+	for _, mode := range []EscapeMode{NoEscaping, EscapeControl, EscapeStrict} {
+		text, err := mode.MarshalText()
+		require.NoError(t, err, "Unexpected error marshaling %v.", mode)
+
+		var got EscapeMode
+		require.NoError(t, got.UnmarshalText(text), "Unexpected error unmarshaling %q.", text)
+		assert.Equal(t, mode, got, "Expected %q to round-trip.", text)
+	}
+
+	mode := EscapeStrict
+	assert.NoError(t, mode.UnmarshalText(nil), "Expected the empty string to unmarshal.")
+	assert.Equal(t, NoEscaping, mode, "Expected the empty string to be NoEscaping.")
+	assert.Error(t, mode.UnmarshalText([]byte("paranoid")), "Expected an error for an unknown mode.")
+	assert.Equal(t, "EscapeMode(42)", EscapeMode(42).String(), "Unexpected string for an unknown mode.")
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// A DuplicateKeyPolicy determines how encoders handle a key that's added to
+// the same object more than once; for example, by calling
+// logger.With(zap.String("k", ...)) and then logging zap.String("k", ...).
+//
+// Duplicates are resolved separately within each object: the top level of
+// the entry, each namespace, and each nested object. The top level includes
+// the keys configured for the entry's metadata (MessageKey, LevelKey,
+// TimeKey, etc.). "First" and "last" refer to the order in which keys appear
+// in the output, where all metadata except the stacktrace precedes the
+// logger's context and the entry's fields.
+//
+// The console encoder doesn't write keys for the entry's metadata, so the
+// policy only applies to its structured context.
+type DuplicateKeyPolicy uint8
+
+const (
+	// KeepDuplicateKeys writes every key and value as-is, even if that
+	// produces duplicate keys. This is the default.
+	KeepDuplicateKeys DuplicateKeyPolicy = iota
+	// LastKeyWins keeps only the last value added for each key.
+	LastKeyWins
+	// FirstKeyWins keeps only the first value added for each key. If the
+	// dropped value is a namespace, all fields added to it are dropped too.
+	FirstKeyWins
+	// RenameDuplicateKeys keeps every value, adding a numeric suffix to
+	// repeated keys: the second "k" is written as "k_1", the third as "k_2",
+	// and so on.
+	RenameDuplicateKeys
+)
+
+// String returns a lower-case ASCII representation of the policy.
+func (p DuplicateKeyPolicy) String() string {
+	switch p {
+	case KeepDuplicateKeys:
+		return "keep"
+	case LastKeyWins:
+		return "last"
+	case FirstKeyWins:
+		return "first"
+	case RenameDuplicateKeys:
+		return "rename"
+	default:
+		return fmt.Sprintf("DuplicateKeyPolicy(%d)", p)
+	}
+}
+
+// MarshalText marshals the DuplicateKeyPolicy to text.
+func (p DuplicateKeyPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText unmarshals text to a DuplicateKeyPolicy. "keep" and the empty
+// string are unmarshaled to KeepDuplicateKeys, "last" to LastKeyWins,
+// "first" to FirstKeyWins, and "rename" to RenameDuplicateKeys.
+func (p *DuplicateKeyPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "keep":
+		*p = KeepDuplicateKeys
+	case "last":
+		*p = LastKeyWins
+	case "first":
+		*p = FirstKeyWins
+	case "rename":
+		*p = RenameDuplicateKeys
+	default:
+		return fmt.Errorf("unrecognized duplicate key policy: %q", text)
+	}
+	return nil
+}
+
+// keyTracker records where each key of the jsonEncoder's open objects was
+// written so that duplicates can be resolved after the fact.
+//
+// Keys of all open objects share a single slice, outermost object first, and
+// scopes holds the index of the first key of each open nested object or
+// namespace. Only the innermost object is ever modified.
+type keyTracker struct {
+	policy DuplicateKeyPolicy
+	keys   []trackedKey
+	scopes []int
+}
+
+type trackedKey struct {
+	name  string
+	start int  // offset of the key's opening quote
+	value int  // offset of the key's value
+	end   int  // offset just past the value, or -1 if it may still grow
+	drop  bool // remove once the value is complete
+}
+
+func (kt *keyTracker) enabled() bool {
+	return kt.policy != KeepDuplicateKeys
+}
+
+func (kt *keyTracker) reset() {
+	kt.keys = kt.keys[:0]
+	kt.scopes = kt.scopes[:0]
+}
+
+func (kt *keyTracker) copyFrom(other *keyTracker) {
+	kt.policy = other.policy
+	kt.keys = append(kt.keys[:0], other.keys...)
+	kt.scopes = append(kt.scopes[:0], other.scopes...)
+}
+
+// innermost returns the index of the first key of the innermost open object.
+func (kt *keyTracker) innermost() int {
+	if n := len(kt.scopes); n > 0 {
+		return kt.scopes[n-1]
+	}
+	return 0
+}
+
+// find returns the index of the given key in the innermost open object, or
+// -1 if it hasn't been added.
+func (kt *keyTracker) find(name string) int {
+	for i := kt.innermost(); i < len(kt.keys); i++ {
+		if kt.keys[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (kt *keyTracker) rename(name string) string {
+	for n := 1; ; n++ {
+		renamed := name + "_" + strconv.Itoa(n)
+		if kt.find(renamed) < 0 {
+			return renamed
+		}
+	}
+}
+
+// resolveKey applies the duplicate key policy to a key that's about to be
+// added to the innermost open object. It returns the key to write and
+// whether its value should be dropped once it's complete.
+func (enc *jsonEncoder) resolveKey(key string) (string, bool) {
+	enc.finishLastKey()
+	i := enc.keys.find(key)
+	if i < 0 {
+		return key, false
+	}
+	switch enc.keys.policy {
+	case LastKeyWins:
+		enc.cutKey(i)
+	case FirstKeyWins:
+		return key, true
+	case RenameDuplicateKeys:
+		return enc.keys.rename(key), false
+	}
+	return key, false
+}
+
+// trackKey records a key written at start whose value begins at the current
+// end of the buffer.
+func (enc *jsonEncoder) trackKey(key string, start int, drop bool) {
+	enc.keys.keys = append(enc.keys.keys, trackedKey{
+		name:  key,
+		start: start,
+		value: enc.buf.Len(),
+		end:   -1,
+		drop:  drop,
+	})
+}
+
+// finishLastKey marks the value of the most recently added key in the
+// innermost open object as complete, removing it if it should be dropped.
+func (enc *jsonEncoder) finishLastKey() {
+	last := len(enc.keys.keys) - 1
+	if last < enc.keys.innermost() {
+		return
+	}
+	if enc.keys.keys[last].end < 0 {
+		enc.keys.keys[last].end = enc.buf.Len()
+	}
+	if enc.keys.keys[last].drop {
+		enc.cutKey(last)
+	}
+}
+
+// cutKey removes the i-th tracked key, its value, and one adjacent element
+// separator from the buffer.
+func (enc *jsonEncoder) cutKey(i int) {
+	k := enc.keys.keys[i]
+	bs := enc.buf.Bytes()
+	sep := 1
+	if enc.spaced {
+		sep = 2
+	}
+
+	lo, hi := k.start, k.end
+	if hi < len(bs) && bs[hi] == ',' {
+		hi += sep
+	} else if lo >= sep && bs[lo-sep] == ',' {
+		lo -= sep
+	}
+	n := copy(bs[lo:], bs[hi:])
+	enc.buf.Truncate(lo + n)
+
+	enc.keys.keys = append(enc.keys.keys[:i], enc.keys.keys[i+1:]...)
+	for j := i; j < len(enc.keys.keys); j++ {
+		enc.keys.keys[j].shift(lo - hi)
+	}
+}
+
+func (k *trackedKey) shift(delta int) {
+	k.start += delta
+	k.value += delta
+	if k.end >= 0 {
+		k.end += delta
+	}
+}
+
+// openKeyScope starts tracking keys for a newly opened object or namespace.
+func (enc *jsonEncoder) openKeyScope() {
+	if enc.keys.enabled() {
+		enc.keys.scopes = append(enc.keys.scopes, len(enc.keys.keys))
+	}
+}
+
+// closeKeyScope resolves any pending duplicate in the innermost open object
+// and stops tracking its keys. At the top level, it only resolves pending
+// duplicates.
+func (enc *jsonEncoder) closeKeyScope() {
+	if !enc.keys.enabled() {
+		return
+	}
+	enc.finishLastKey()
+	if n := len(enc.keys.scopes); n > 0 {
+		enc.keys.keys = enc.keys.keys[:enc.keys.scopes[n-1]]
+		enc.keys.scopes = enc.keys.scopes[:n-1]
+	}
+}
+
+// addContext adds the fields accumulated in ctx, a jsonEncoder with the same
+// configuration, to the top level of enc. Unlike copying ctx's buffer
+// wholesale, this resolves keys in ctx that collide with keys already
+// present in enc.
+func (enc *jsonEncoder) addContext(ctx *jsonEncoder) {
+	top := len(ctx.keys.keys)
+	if len(ctx.keys.scopes) > 0 {
+		top = ctx.keys.scopes[0]
+	}
+	if top == 0 {
+		enc.addElementSeparator()
+		enc.buf.Write(ctx.buf.Bytes())
+		return
+	}
+
+	var delta int
+	for _, k := range ctx.keys.keys[:top] {
+		end := k.end
+		if end < 0 {
+			end = ctx.buf.Len()
+		}
+		enc.addKey(k.name)
+		delta = enc.buf.Len() - k.value
+		enc.buf.Write(ctx.buf.Bytes()[k.value:end])
+	}
+
+	// Namespaces that are still open can only belong to the last key, so
+	// everything nested in them moved by the same amount.
+	offset := len(enc.keys.keys) - top
+	for _, s := range ctx.keys.scopes {
+		enc.keys.scopes = append(enc.keys.scopes, s+offset)
+	}
+	for _, k := range ctx.keys.keys[top:] {
+		k.shift(delta)
+		enc.keys.keys = append(enc.keys.keys, k)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestDuplicateKeyPolicies(t *testing.T) {
+	entry := Entry{Level: InfoLevel, Message: "hello", Stack: "fake-stack"}
+	inner := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddInt("a", 1)
+		enc.AddInt("b", 2)
+		enc.AddInt("a", 3)
+		return nil
+	})
+
+	tests := []struct {
+		desc    string
+		context []Field
+		fields  []Field
+		want    map[DuplicateKeyPolicy]string
+	}{
+		{
+			desc:   "no duplicates",
+			fields: []Field{zap.Int("a", 1), zap.Int("b", 2)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:   "fields",
+			fields: []Field{zap.Int("a", 1), zap.Int("b", 2), zap.Int("a", 3), zap.Int("a", 4)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","a":1,"b":2,"a":3,"a":4,"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","b":2,"a":4,"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":1,"b":2,"a_1":3,"a_2":4,"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:    "context and fields",
+			context: []Field{zap.Int("a", 1), zap.Int("b", 2)},
+			fields:  []Field{zap.Int("a", 3)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","a":1,"b":2,"a":3,"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","b":2,"a":3,"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":1,"b":2,"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":1,"b":2,"a_1":3,"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:    "metadata keys",
+			context: []Field{zap.String("msg", "ctx"), zap.Int("a", 1)},
+			fields:  []Field{zap.String("level", "field"), zap.String("stacktrace", "field")},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","msg":"ctx","a":1,"level":"field","stacktrace":"field","stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"msg":"ctx","a":1,"level":"field","stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":1,"stacktrace":"field"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","msg_1":"ctx","a":1,"level_1":"field","stacktrace":"field","stacktrace_1":"fake-stack"}`,
+			},
+		},
+		{
+			desc:    "namespaces",
+			context: []Field{zap.Int("a", 1), zap.Namespace("ns"), zap.Int("a", 2)},
+			fields:  []Field{zap.Int("a", 3), zap.Int("b", 4)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","a":1,"ns":{"a":2,"a":3,"b":4},"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","a":1,"ns":{"a":3,"b":4},"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":1,"ns":{"a":2,"b":4},"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":1,"ns":{"a":2,"a_1":3,"b":4},"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:    "duplicate namespace",
+			context: []Field{zap.Int("ns", 1)},
+			fields:  []Field{zap.Namespace("ns"), zap.Int("a", 2)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","ns":1,"ns":{"a":2},"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","ns":{"a":2},"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","ns":1,"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","ns":1,"ns_1":{"a":2},"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:   "nested objects",
+			fields: []Field{zap.Object("a", inner), zap.Object("a", inner)},
+			want: map[DuplicateKeyPolicy]string{
+				KeepDuplicateKeys:   `{"level":"info","msg":"hello","a":{"a":1,"b":2,"a":3},"a":{"a":1,"b":2,"a":3},"stacktrace":"fake-stack"}`,
+				LastKeyWins:         `{"level":"info","msg":"hello","a":{"b":2,"a":3},"stacktrace":"fake-stack"}`,
+				FirstKeyWins:        `{"level":"info","msg":"hello","a":{"a":1,"b":2},"stacktrace":"fake-stack"}`,
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":{"a":1,"b":2,"a_1":3},"a_1":{"a":1,"b":2,"a_1":3},"stacktrace":"fake-stack"}`,
+			},
+		},
+		{
+			desc:   "renamed key collides",
+			fields: []Field{zap.Int("a", 1), zap.Int("a_1", 2), zap.Int("a", 3)},
+			want: map[DuplicateKeyPolicy]string{
+				RenameDuplicateKeys: `{"level":"info","msg":"hello","a":1,"a_1":2,"a_2":3,"stacktrace":"fake-stack"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for policy, want := range tt.want {
+			t.Run(tt.desc+"/"+policy.String(), func(t *testing.T) {
+				cfg := EncoderConfig{
+					MessageKey:    "msg",
+					LevelKey:      "level",
+					StacktraceKey: "stacktrace",
+					EncodeLevel:   LowercaseLevelEncoder,
+					DuplicateKeys: policy,
+				}
+				enc := NewJSONEncoder(cfg).Clone()
+				for _, f := range tt.context {
+					f.AddTo(enc)
+				}
+
+				buf, err := enc.EncodeEntry(entry, tt.fields)
+				require.NoError(t, err, "Unexpected JSON encoding error.")
+				defer buf.Free()
+				assert.Equal(t, want+"\n", buf.String(), "Incorrect encoded entry.")
+				if policy != KeepDuplicateKeys {
+					assert.True(t, json.Valid(buf.Bytes()), "Expected valid JSON.")
+				}
+			})
+		}
+	}
+}
+
+func TestDuplicateKeyPoliciesSpaced(t *testing.T) {
+	tests := []struct {
+		policy DuplicateKeyPolicy
+		want   string
+	}{
+		{KeepDuplicateKeys, `{"a": 1, "b": 2, "a": 3}`},
+		{LastKeyWins, `{"b": 2, "a": 3}`},
+		{FirstKeyWins, `{"a": 1, "b": 2}`},
+		{RenameDuplicateKeys, `{"a": 1, "b": 2, "a_1": 3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			enc := NewConsoleEncoder(EncoderConfig{
+				MessageKey:    "msg",
+				DuplicateKeys: tt.policy,
+			})
+			enc.AddInt("a", 1)
+			enc.AddInt("b", 2)
+
+			buf, err := enc.EncodeEntry(Entry{Message: "hello"}, []Field{zap.Int("a", 3)})
+			require.NoError(t, err, "Unexpected console encoding error.")
+			defer buf.Free()
+			assert.Equal(t, "hello\t"+tt.want+"\n", buf.String(), "Incorrect encoded entry.")
+		})
+	}
+}
+
+func TestDuplicateKeysDoesNotAffectParent(t *testing.T) {
+	parent := NewJSONEncoder(EncoderConfig{MessageKey: "msg", DuplicateKeys: LastKeyWins})
+	parent.AddInt("a", 1)
+	parent.AddInt("b", 2)
+	child := parent.Clone()
+	child.AddInt("a", 3)
+
+	buf, err := parent.EncodeEntry(Entry{Message: "hello"}, []Field{zap.Int("b", 4)})
+	require.NoError(t, err, "Unexpected JSON encoding error.")
+	assert.Equal(t, `{"msg":"hello","a":1,"b":4}`+"\n", buf.String(), "Unexpected parent output.")
+	buf.Free()
+
+	buf, err = child.EncodeEntry(Entry{Message: "hello"}, nil)
+	require.NoError(t, err, "Unexpected JSON encoding error.")
+	assert.Equal(t, `{"msg":"hello","b":2,"a":3}`+"\n", buf.String(), "Unexpected child output.")
+	buf.Free()
+}
+
+func TestDuplicateKeyPolicyText(t *testing.T) {
+	for _, p := range []DuplicateKeyPolicy{KeepDuplicateKeys, LastKeyWins, FirstKeyWins, RenameDuplicateKeys} {
+		text, err := p.MarshalText()
+		require.NoError(t, err, "Unexpected error marshaling %v.", p)
+
+		var unmarshaled DuplicateKeyPolicy
+		require.NoError(t, unmarshaled.UnmarshalText(text), "Unexpected error unmarshaling %q.", text)
+		assert.Equal(t, p, unmarshaled, "Policy didn't round-trip.")
+	}
+
+	var p DuplicateKeyPolicy = LastKeyWins
+	require.NoError(t, p.UnmarshalText(nil), "Unexpected error unmarshaling empty text.")
+	assert.Equal(t, KeepDuplicateKeys, p, "Expected empty text to keep duplicate keys.")
+
+	assert.Error(t, p.UnmarshalText([]byte("foo")), "Expected error unmarshaling unknown policy.")
+	assert.Equal(t, "DuplicateKeyPolicy(42)", DuplicateKeyPolicy(42).String(), "Unexpected string for unknown policy.")
+}
@@ -356,6 +356,18 @@ type EncoderConfig struct {
 	// Configures the field separator used by the console encoder. Defaults
 	// to tab.
 	ConsoleSeparator string `json:"consoleSeparator" yaml:"consoleSeparator"`
+	// Configures whether the pretty encoder colors its output. Defaults to
+	// ColorAuto.
+	ConsoleColor ColorMode `json:"consoleColor" yaml:"consoleColor"`
+	// Configures how the console and pretty encoders escape the message,
+	// logger name, and caller of entries. Defaults to NoEscaping.
+	ConsoleEscaping EscapeMode `json:"consoleEscaping" yaml:"consoleEscaping"`
+	// Configures how keys added more than once to the same object are
+	// handled. The zero value keeps all of them; see DuplicateKeyPolicy.
+	DuplicateKeys DuplicateKeyPolicy `json:"duplicateKeys" yaml:"duplicateKeys"`
+	// Caps the size of encoded values and entries. The zero value imposes no
+	// limits; see EncoderLimits.
+	Limits EncoderLimits `json:"limits" yaml:"limits"`
 }
 
 // ObjectEncoder is a strongly-typed, encoding-agnostic interface for adding a
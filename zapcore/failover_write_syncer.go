@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// _defaultFailoverThreshold is the default number of consecutive
+	// failures before a FailoverWriteSyncer fails over.
+	_defaultFailoverThreshold = 3
+
+	// _defaultFailoverProbeInterval is how long a FailoverWriteSyncer waits
+	// before probing a failed primary by default.
+	_defaultFailoverProbeInterval = 5 * time.Second
+)
+
+// A FailoverWriteSyncer is a WriteSyncer that writes to a primary
+// WriteSyncer, and falls back to a secondary one when the primary fails.
+//
+// A write that fails on the primary is written to the secondary instead,
+// whole, so that the secondary gets complete entries. If the primary
+// accepted part of the write before failing, that torn prefix may be left
+// on the primary.
+// Once the primary fails FailureThreshold times in a row, it's considered
+// unhealthy and writes go straight to the secondary. Every ProbeInterval,
+// one write is sent to the primary to probe it; if that succeeds, the
+// primary is healthy again.
+//
+// FailoverWriteSyncer is safe for concurrent use.
+//
+//	ws := &zapcore.FailoverWriteSyncer{
+//	  Primary:   agent,     // e.g., a network sink
+//	  Secondary: localFile, // e.g., an *os.File
+//	}
+type FailoverWriteSyncer struct {
+	// Primary is the WriteSyncer written to while it's healthy.
+	//
+	// This field is required.
+	Primary WriteSyncer
+
+	// Secondary is the WriteSyncer written to when the primary fails.
+	//
+	// This field is required.
+	Secondary WriteSyncer
+
+	// FailureThreshold is the number of consecutive failed writes after
+	// which the primary is considered unhealthy.
+	//
+	// Defaults to 3 if unspecified.
+	FailureThreshold int
+
+	// ProbeInterval specifies how often an unhealthy primary is probed.
+	//
+	// Defaults to 5 seconds if unspecified.
+	ProbeInterval time.Duration
+
+	// Clock, if specified, provides control of the source of time for the
+	// writer.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+
+	// unexported fields for state
+	mu        sync.Mutex
+	failures  int       // consecutive failed writes to the primary
+	unhealthy bool      // whether failures reached the threshold
+	nextProbe time.Time // when to probe an unhealthy primary
+}
+
+// Healthy reports whether writes are going to the primary.
+func (s *FailoverWriteSyncer) Healthy() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.unhealthy
+}
+
+// Write writes to the primary, or the secondary if the primary is unhealthy
+// or the write fails. It only returns an error if the write fails on the
+// secondary too.
+func (s *FailoverWriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var primaryErr error
+	if !s.unhealthy || !s.now().Before(s.nextProbe) {
+		n, err := s.Primary.Write(bs)
+		if err == nil {
+			s.failures = 0
+			s.unhealthy = false
+			return n, nil
+		}
+		primaryErr = err
+		s.fail()
+	}
+
+	n, err := s.Secondary.Write(bs)
+	if err != nil {
+		return n, multierr.Append(primaryErr, err)
+	}
+	return n, nil
+}
+
+// fail records a failed write to the primary.
+func (s *FailoverWriteSyncer) fail() {
+	s.failures++
+	threshold := s.FailureThreshold
+	if threshold <= 0 {
+		threshold = _defaultFailoverThreshold
+	}
+	if s.failures < threshold {
+		return
+	}
+
+	interval := s.ProbeInterval
+	if interval <= 0 {
+		interval = _defaultFailoverProbeInterval
+	}
+	s.unhealthy = true
+	s.nextProbe = s.now().Add(interval)
+}
+
+func (s *FailoverWriteSyncer) now() time.Time {
+	if s.Clock == nil {
+		return DefaultClock.Now()
+	}
+	return s.Clock.Now()
+}
+
+// Sync syncs the secondary, and the primary if it's healthy.
+func (s *FailoverWriteSyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.Secondary.Sync()
+	if !s.unhealthy {
+		err = multierr.Append(s.Primary.Sync(), err)
+	}
+	return err
+}
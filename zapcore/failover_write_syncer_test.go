@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+	"go.uber.org/zap/internal/ztest"
+)
+
+func TestFailoverWriteSyncer(t *testing.T) {
+	clock := ztest.NewMockClock()
+	primary, secondary := &flakyWriteSyncer{}, &flakyWriteSyncer{}
+	ws := &FailoverWriteSyncer{
+		Primary:          primary,
+		Secondary:        secondary,
+		FailureThreshold: 2,
+		ProbeInterval:    time.Minute,
+		Clock:            clock,
+	}
+
+	writeAll(t, ws, "a")
+	assert.True(t, ws.Healthy(), "Expected the primary to start healthy.")
+
+	// Failed writes go to the secondary, but the primary stays healthy until
+	// it reaches the threshold.
+	primary.setDown(true)
+	writeAll(t, ws, "b")
+	assert.True(t, ws.Healthy(), "Expected one failure to be tolerated.")
+	writeAll(t, ws, "c")
+	assert.False(t, ws.Healthy(), "Expected the primary to be unhealthy.")
+
+	// While unhealthy, the primary isn't tried until it's time to probe.
+	primary.setDown(false)
+	writeAll(t, ws, "d")
+	assert.Equal(t, "a", primary.String(), "Unexpected writes to the primary.")
+	assert.Equal(t, "bcd", secondary.String(), "Unexpected writes to the secondary.")
+
+	// A failed probe waits for the next interval.
+	primary.setDown(true)
+	clock.Add(time.Minute)
+	writeAll(t, ws, "e")
+	assert.False(t, ws.Healthy(), "Expected a failed probe to leave the primary unhealthy.")
+	primary.setDown(false)
+	writeAll(t, ws, "f")
+	assert.Equal(t, "bcdef", secondary.String(), "Unexpected writes to the secondary.")
+
+	// A successful probe restores the primary.
+	clock.Add(time.Minute)
+	writeAll(t, ws, "g", "h")
+	assert.True(t, ws.Healthy(), "Expected a successful probe to restore the primary.")
+	assert.Equal(t, "agh", primary.String(), "Unexpected writes to the primary.")
+}
+
+func TestFailoverWriteSyncerDefaults(t *testing.T) {
+	clock := ztest.NewMockClock()
+	primary := &flakyWriteSyncer{down: true}
+	ws := &FailoverWriteSyncer{Primary: primary, Secondary: &flakyWriteSyncer{}, Clock: clock}
+
+	writeAll(t, ws, "a", "b")
+	assert.True(t, ws.Healthy(), "Expected failures below the default threshold to be tolerated.")
+	writeAll(t, ws, "c")
+	assert.False(t, ws.Healthy(), "Expected the primary to be unhealthy after three failures.")
+
+	primary.setDown(false)
+	clock.Add(_defaultFailoverProbeInterval - time.Nanosecond)
+	writeAll(t, ws, "d")
+	assert.False(t, ws.Healthy(), "Expected no probe before the default interval.")
+	clock.Add(time.Nanosecond)
+	writeAll(t, ws, "e")
+	assert.True(t, ws.Healthy(), "Expected a probe after the default interval.")
+}
+
+func TestFailoverWriteSyncerErrors(t *testing.T) {
+	ws := &FailoverWriteSyncer{
+		Primary:   &flakyWriteSyncer{down: true},
+		Secondary: &flakyWriteSyncer{down: true},
+	}
+	_, err := ws.Write([]byte("a"))
+	require.Error(t, err, "Expected an error when both WriteSyncers fail.")
+	assert.Len(t, multierr.Errors(err), 2, "Expected both errors.")
+}
+
+func TestFailoverWriteSyncerPartialWrite(t *testing.T) {
+	primary := &flakyWriteSyncer{down: true, partial: 3}
+	secondary := &flakyWriteSyncer{}
+	ws := &FailoverWriteSyncer{Primary: primary, Secondary: secondary}
+
+	writeAll(t, ws, "abcdef")
+	assert.Equal(t, "abc", primary.String(), "Expected a torn prefix on the primary.")
+	assert.Equal(t, "abcdef", secondary.String(), "Expected the whole write on the secondary.")
+
+	secondary.partial = 1
+	secondary.setDown(true)
+	n, err := ws.Write([]byte("ghijkl"))
+	assert.Error(t, err, "Expected an error when both WriteSyncers fail.")
+	assert.Equal(t, 1, n, "Expected bytes written to the secondary to be counted.")
+}
+
+func TestFailoverWriteSyncerSync(t *testing.T) {
+	primary, secondary := &ztest.Discarder{}, &ztest.Discarder{}
+	ws := &FailoverWriteSyncer{Primary: primary, Secondary: secondary}
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, primary.Called(), "Expected a healthy primary to be synced.")
+	assert.True(t, secondary.Called(), "Expected the secondary to be synced.")
+
+	primary, secondary = &ztest.Discarder{}, &ztest.Discarder{}
+	ws = &FailoverWriteSyncer{Primary: primary, Secondary: secondary}
+	ws.unhealthy = true
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.False(t, primary.Called(), "Expected an unhealthy primary not to be synced.")
+	assert.True(t, secondary.Called(), "Expected the secondary to be synced.")
+}
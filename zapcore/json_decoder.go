@@ -0,0 +1,676 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// _probeTime and _probeDuration are encoded with the configured encoders to
+// learn how to decode their output. They have distinct digits at every
+// precision, so that units can't be confused.
+var (
+	_probeTime     = time.Date(2006, time.January, 2, 15, 4, 5, 123456789, time.UTC)
+	_probeDuration = 90*time.Minute + 1500*time.Millisecond
+)
+
+// _timeLayouts are tried when a TimeEncoder writes strings without
+// revealing its layout.
+var _timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05.000Z0700", // ISO8601TimeEncoder
+	"2006-01-02 15:04:05",
+	time.Layout,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+	time.RFC822,
+	time.RFC822Z,
+	time.RFC850,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.StampNano,
+}
+
+// _epochUnits are the units numeric times and durations may be written in.
+var _epochUnits = []time.Duration{time.Second, time.Millisecond, time.Microsecond, time.Nanosecond}
+
+// A DecodedObject is an object read back by a JSONDecoder. Its fields are
+// in the order they were written, and it can be logged again as an
+// ObjectMarshaler.
+type DecodedObject []Field
+
+// MarshalLogObject implements ObjectMarshaler.
+func (o DecodedObject) MarshalLogObject(enc ObjectEncoder) error {
+	for _, f := range o {
+		f.AddTo(enc)
+	}
+	return nil
+}
+
+// A DecodedArray is an array read back by a JSONDecoder. Its elements are
+// fields with empty keys, and it can be logged again as an ArrayMarshaler.
+type DecodedArray []Field
+
+// MarshalLogArray implements ArrayMarshaler.
+func (a DecodedArray) MarshalLogArray(enc ArrayEncoder) error {
+	var errs error
+	for _, f := range a {
+		var err error
+		switch f.Type {
+		case BoolType:
+			enc.AppendBool(f.Integer == 1)
+		case Int64Type:
+			enc.AppendInt64(f.Integer)
+		case Uint64Type:
+			enc.AppendUint64(uint64(f.Integer))
+		case Float64Type:
+			enc.AppendFloat64(math.Float64frombits(uint64(f.Integer)))
+		case Float32Type:
+			enc.AppendFloat32(math.Float32frombits(uint32(f.Integer)))
+		case StringType:
+			enc.AppendString(f.String)
+		case ByteStringType:
+			enc.AppendByteString(f.Interface.([]byte))
+		case DurationType:
+			enc.AppendDuration(time.Duration(f.Integer))
+		case TimeType, TimeFullType:
+			t, _ := fieldTime(f)
+			enc.AppendTime(t)
+		case ObjectMarshalerType:
+			err = enc.AppendObject(f.Interface.(ObjectMarshaler))
+		case ArrayMarshalerType:
+			err = enc.AppendArray(f.Interface.(ArrayMarshaler))
+		default:
+			err = enc.AppendReflected(f.Interface)
+		}
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
+
+// A JSONDecoder reads entries written by the JSON encoder back into an Entry
+// and its fields. It's configured with the EncoderConfig the entries were
+// written with, and recognizes the output of any TimeEncoder,
+// LevelEncoder, and DurationEncoder that writes a single value, including
+// custom ones.
+//
+// Fields are decoded by their JSON type: strings as StringType, integers
+// as Int64Type (or Uint64Type if they don't fit), other numbers as
+// Float64Type, booleans as BoolType, and nulls as ReflectType with a nil
+// Interface. Objects and arrays are decoded into DecodedObject and
+// DecodedArray values, so fields form a tree that can be walked or logged
+// again. Since JSON doesn't record whether a string was a time or a
+// duration, DecodeTime and DecodeDuration interpret fields on request.
+//
+// Keys that aren't in the EncoderConfig, and entry metadata that can't be
+// parsed, are kept as fields.
+type JSONDecoder struct {
+	cfg    EncoderConfig
+	r      *bufio.Reader
+	levels map[string]Level
+
+	// Learned from the configured encoders; see newTimeFormat.
+	time     encodedFormat
+	duration encodedFormat
+}
+
+// encodedFormat describes how a TimeEncoder or DurationEncoder writes
+// values: as numbers in a unit, or as text in a layout. The layout may be
+// unknown for text, and the zero value means the format is unknown.
+type encodedFormat struct {
+	unit   time.Duration
+	layout string
+	text   bool
+}
+
+// NewJSONDecoder creates a JSONDecoder that reads entries from r, one per
+// line, as written by a JSON encoder with the given configuration.
+func NewJSONDecoder(r io.Reader, cfg EncoderConfig) *JSONDecoder {
+	d := &JSONDecoder{
+		cfg:    cfg,
+		r:      bufio.NewReader(r),
+		levels: make(map[string]Level),
+	}
+	if cfg.EncodeLevel != nil {
+		for l := _minLevel; l <= _maxLevel; l++ {
+			if v, ok := probe(func(enc PrimitiveArrayEncoder) { cfg.EncodeLevel(l, enc) }); ok {
+				d.levels[fmt.Sprint(v)] = l
+			}
+		}
+	}
+	if cfg.EncodeTime != nil {
+		d.time = newTimeFormat(cfg.EncodeTime)
+	}
+	if cfg.EncodeDuration != nil {
+		d.duration = newDurationFormat(cfg.EncodeDuration)
+	}
+	return d
+}
+
+// Decode reads the next entry. Blank lines are skipped, and io.EOF is
+// returned at the end of the input. If a line can't be decoded, Decode
+// returns an error, and the next call continues with the following line.
+func (d *JSONDecoder) Decode() (Entry, []Field, error) {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			return d.DecodeEntry(line)
+		}
+		if err != nil {
+			return Entry{}, nil, err
+		}
+	}
+}
+
+// DecodeEntry decodes a single encoded entry.
+func (d *JSONDecoder) DecodeEntry(line []byte) (Entry, []Field, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	obj, err := decodeJSONObject(dec, 0)
+	if err == nil {
+		if _, err = dec.Token(); err == io.EOF {
+			err = nil
+		} else if err == nil {
+			err = errors.New("unexpected data after entry")
+		}
+	}
+	if err != nil {
+		return Entry{}, nil, fmt.Errorf("json: %v", err)
+	}
+
+	ent, fields := d.DecodeFields(obj)
+	return ent, fields, nil
+}
+
+// DecodeFields splits decoded fields into the entry metadata they hold,
+// according to the decoder's EncoderConfig, and the remaining fields. It
+// lets other formats whose values can be read into fields, like the
+// console encoder's output or CBOR maps, share the JSON decoder's handling
+// of metadata.
+func (d *JSONDecoder) DecodeFields(obj []Field) (Entry, []Field) {
+	var (
+		ent    Entry
+		fields = make([]Field, 0, len(obj))
+		seen   = make(map[string]bool, 8)
+	)
+	for _, f := range obj {
+		if f.Key == "" || seen[f.Key] || !d.decodeMetadata(&ent, f) {
+			fields = append(fields, f)
+			continue
+		}
+		seen[f.Key] = true
+	}
+	return ent, fields
+}
+
+// decodeMetadata sets the part of ent that f's key is configured for,
+// reporting whether f was entry metadata that could be parsed.
+func (d *JSONDecoder) decodeMetadata(ent *Entry, f Field) bool {
+	switch f.Key {
+	case d.cfg.MessageKey:
+		if f.Type == StringType {
+			ent.Message = f.String
+			return true
+		}
+	case d.cfg.LevelKey:
+		if l, ok := d.decodeLevel(f); ok {
+			ent.Level = l
+			return true
+		}
+	case d.cfg.TimeKey:
+		if t, ok := d.DecodeTime(f); ok {
+			ent.Time = t
+			return true
+		}
+	case d.cfg.NameKey:
+		if f.Type == StringType {
+			ent.LoggerName = f.String
+			return true
+		}
+	case d.cfg.CallerKey:
+		if c, ok := decodeCaller(f); ok {
+			// The function may already have been read from FunctionKey.
+			c.Function = ent.Caller.Function
+			if fn, ok := decodedCallerFunction(f); ok {
+				c.Function = fn
+			}
+			ent.Caller = c
+			return true
+		}
+	case d.cfg.FunctionKey:
+		if f.Type == StringType {
+			ent.Caller.Defined = true
+			ent.Caller.Function = f.String
+			return true
+		}
+	case d.cfg.StacktraceKey:
+		if f.Type == StringType {
+			ent.Stack = f.String
+			return true
+		}
+	}
+	return false
+}
+
+func (d *JSONDecoder) decodeLevel(f Field) (Level, bool) {
+	if s, ok := primitiveText(f); ok {
+		if l, ok := d.levels[s]; ok {
+			return l, true
+		}
+	}
+	if f.Type != StringType || f.String == "" {
+		// Level.UnmarshalText accepts the empty string, but encoders never
+		// write it.
+		return InvalidLevel, false
+	}
+	// Fall back to the level names, ignoring any color.
+	var l Level
+	if l.unmarshalText([]byte(strings.ToLower(stripANSI(f.String)))) {
+		return l, true
+	}
+	return InvalidLevel, false
+}
+
+// DecodeTime interprets a decoded field as a time written by the
+// configured TimeEncoder. Fields that already hold times are returned
+// as-is.
+func (d *JSONDecoder) DecodeTime(f Field) (time.Time, bool) {
+	switch f.Type {
+	case TimeType, TimeFullType:
+		return fieldTime(f)
+	case StringType:
+		if d.time.layout != "" {
+			t, err := time.Parse(d.time.layout, f.String)
+			return t, err == nil
+		}
+		if !d.time.text {
+			return time.Time{}, false
+		}
+		for _, layout := range _timeLayouts {
+			if t, err := time.Parse(layout, f.String); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	if d.time.unit == 0 {
+		return time.Time{}, false
+	}
+	if f.Type == Int64Type {
+		return time.Unix(0, f.Integer*int64(d.time.unit)), true
+	}
+	v, ok := fieldNumber(f)
+	if !ok {
+		return time.Time{}, false
+	}
+	whole, frac := math.Modf(v)
+	// Floats hold current times to about a microsecond, so round off
+	// anything finer.
+	nanos := math.Round(frac*float64(d.time.unit)/1e3) * 1e3
+	return time.Unix(0, int64(whole)*int64(d.time.unit)+int64(nanos)), true
+}
+
+// DecodeDuration interprets a decoded field as a duration written by the
+// configured DurationEncoder. Fields that already hold durations are
+// returned as-is.
+func (d *JSONDecoder) DecodeDuration(f Field) (time.Duration, bool) {
+	switch f.Type {
+	case DurationType:
+		return time.Duration(f.Integer), true
+	case StringType:
+		if !d.duration.text {
+			return 0, false
+		}
+		dur, err := time.ParseDuration(f.String)
+		return dur, err == nil
+	}
+
+	if d.duration.unit == 0 {
+		return 0, false
+	}
+	if f.Type == Int64Type {
+		return time.Duration(f.Integer) * d.duration.unit, true
+	}
+	v, ok := fieldNumber(f)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(math.Round(v * float64(d.duration.unit))), true
+}
+
+// newTimeFormat learns how enc writes times by encoding _probeTime.
+func newTimeFormat(enc TimeEncoder) encodedFormat {
+	var layout string
+	v, ok := probe(func(pe PrimitiveArrayEncoder) {
+		enc(_probeTime, pe)
+		layout = pe.(*probeEncoder).layout
+	})
+	if !ok {
+		return encodedFormat{}
+	}
+	if layout != "" {
+		return encodedFormat{layout: layout, text: true}
+	}
+	switch v := v.(type) {
+	case string:
+		for _, layout := range _timeLayouts {
+			if _probeTime.Format(layout) == v {
+				return encodedFormat{layout: layout, text: true}
+			}
+		}
+		return encodedFormat{text: true}
+	default:
+		return encodedFormat{unit: probedUnit(v, _probeTime.UnixNano())}
+	}
+}
+
+// newDurationFormat learns how enc writes durations by encoding
+// _probeDuration.
+func newDurationFormat(enc DurationEncoder) encodedFormat {
+	v, ok := probe(func(pe PrimitiveArrayEncoder) { enc(_probeDuration, pe) })
+	if !ok {
+		return encodedFormat{}
+	}
+	if _, ok := v.(string); ok {
+		return encodedFormat{text: true}
+	}
+	return encodedFormat{unit: probedUnit(v, int64(_probeDuration))}
+}
+
+// probedUnit returns the unit in which v represents nanos, or zero if
+// there's none.
+func probedUnit(v interface{}, nanos int64) time.Duration {
+	var f float64
+	switch v := v.(type) {
+	case int64:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	default:
+		return 0
+	}
+	for _, unit := range _epochUnits {
+		if math.Abs(f*float64(unit)-float64(nanos)) < float64(unit) {
+			return unit
+		}
+	}
+	return 0
+}
+
+// probe runs an encoder function, returning the single value it appends.
+func probe(f func(PrimitiveArrayEncoder)) (interface{}, bool) {
+	enc := &probeEncoder{}
+	f(enc)
+	if len(enc.elems) != 1 {
+		return nil, false
+	}
+	return enc.elems[0], true
+}
+
+// probeEncoder records the values that encoders append, and the layout
+// used by time encoders that support AppendTimeLayout.
+type probeEncoder struct {
+	sliceArrayEncoder
+
+	layout string
+}
+
+func (p *probeEncoder) AppendTimeLayout(t time.Time, layout string) {
+	p.layout = layout
+	p.AppendString(t.Format(layout))
+}
+
+func decodeCaller(f Field) (EntryCaller, bool) {
+	switch f.Type {
+	case StringType:
+		// FullCallerEncoder and ShortCallerEncoder write "file:line".
+		i := strings.LastIndexByte(f.String, ':')
+		if i < 0 {
+			return EntryCaller{}, false
+		}
+		line, err := strconv.Atoi(f.String[i+1:])
+		if err != nil {
+			return EntryCaller{}, false
+		}
+		return EntryCaller{Defined: true, File: f.String[:i], Line: line}, true
+	case ObjectMarshalerType:
+		// Structured callers, like GCP's sourceLocation or ECS's
+		// log.origin, have a file and a line that may be nested in it.
+		obj, _ := f.Interface.(DecodedObject)
+		c := EntryCaller{Defined: true}
+		for _, f := range obj {
+			switch f.Key {
+			case "file":
+				if f.Type == StringType {
+					c.File = f.String
+					continue
+				}
+				file, _ := f.Interface.(DecodedObject)
+				for _, f := range file {
+					if f.Key == "name" && f.Type == StringType {
+						c.File = f.String
+					} else if n, ok := fieldInt(f); ok && f.Key == "line" {
+						c.Line = n
+					}
+				}
+			case "line":
+				if n, ok := fieldInt(f); ok {
+					c.Line = n
+				}
+			}
+		}
+		return c, c.File != ""
+	}
+	return EntryCaller{}, false
+}
+
+func decodedCallerFunction(f Field) (string, bool) {
+	obj, _ := f.Interface.(DecodedObject)
+	for _, f := range obj {
+		if f.Key == "function" && f.Type == StringType {
+			return f.String, true
+		}
+	}
+	return "", false
+}
+
+// fieldInt returns the value of an integer field, or of a string field
+// holding one.
+func fieldInt(f Field) (int, bool) {
+	switch f.Type {
+	case Int64Type:
+		return int(f.Integer), true
+	case StringType:
+		n, err := strconv.Atoi(f.String)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// fieldTime returns the value of a TimeType or TimeFullType field.
+func fieldTime(f Field) (time.Time, bool) {
+	switch f.Type {
+	case TimeType:
+		if f.Interface != nil {
+			return time.Unix(0, f.Integer).In(f.Interface.(*time.Location)), true
+		}
+		return time.Unix(0, f.Integer), true
+	case TimeFullType:
+		t, ok := f.Interface.(time.Time)
+		return t, ok
+	}
+	return time.Time{}, false
+}
+
+func fieldNumber(f Field) (float64, bool) {
+	switch f.Type {
+	case Int64Type:
+		return float64(f.Integer), true
+	case Uint64Type:
+		return float64(uint64(f.Integer)), true
+	case Float64Type:
+		return math.Float64frombits(uint64(f.Integer)), true
+	case Float32Type:
+		return float64(math.Float32frombits(uint32(f.Integer))), true
+	}
+	return 0, false
+}
+
+// primitiveText formats a decoded primitive the way fmt.Sprint formats the
+// value that was encoded.
+func primitiveText(f Field) (string, bool) {
+	switch f.Type {
+	case StringType:
+		return f.String, true
+	case BoolType:
+		return strconv.FormatBool(f.Integer == 1), true
+	case Int64Type:
+		return strconv.FormatInt(f.Integer, 10), true
+	case Uint64Type:
+		return strconv.FormatUint(uint64(f.Integer), 10), true
+	case Float64Type:
+		return fmt.Sprint(math.Float64frombits(uint64(f.Integer))), true
+	}
+	return "", false
+}
+
+// stripANSI removes the escape sequences added by the color level
+// encoders.
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			j := i + 2
+			for j < len(s) && (s[j] == ';' || '0' <= s[j] && s[j] <= '9') {
+				j++
+			}
+			if j < len(s) {
+				i = j
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// decodeJSONObject reads an object's fields in order, after its opening
+// delimiter has been consumed if depth > 0.
+func decodeJSONObject(dec *json.Decoder, depth int) (DecodedObject, error) {
+	if depth == 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok != json.Delim('{') {
+			return nil, fmt.Errorf("expected an object, found %v", tok)
+		}
+	}
+	var obj DecodedObject
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		f, err := decodeJSONField(dec, key, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, f)
+	}
+	_, err := dec.Token() // '}'
+	return obj, err
+}
+
+func decodeJSONField(dec *json.Decoder, key string, depth int) (Field, error) {
+	if depth > _maxDecodeDepth {
+		return Field{}, errDecodeTooDeep
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return Field{}, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			obj, err := decodeJSONObject(dec, depth)
+			return Field{Key: key, Type: ObjectMarshalerType, Interface: obj}, err
+		}
+		arr := DecodedArray{}
+		for dec.More() {
+			f, err := decodeJSONField(dec, "", depth+1)
+			if err != nil {
+				return Field{}, err
+			}
+			arr = append(arr, f)
+		}
+		_, err := dec.Token() // ']'
+		return Field{Key: key, Type: ArrayMarshalerType, Interface: arr}, err
+	case string:
+		return Field{Key: key, Type: StringType, String: v}, nil
+	case bool:
+		var i int64
+		if v {
+			i = 1
+		}
+		return Field{Key: key, Type: BoolType, Integer: i}, nil
+	case json.Number:
+		return decodeJSONNumber(key, v)
+	default:
+		return Field{Key: key, Type: ReflectType}, nil
+	}
+}
+
+func decodeJSONNumber(key string, n json.Number) (Field, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return Field{Key: key, Type: Int64Type, Integer: i}, nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return Field{Key: key, Type: Uint64Type, Integer: int64(u)}, nil
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Field{}, err
+	}
+	return Field{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(f))}, nil
+}
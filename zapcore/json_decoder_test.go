@@ -0,0 +1,355 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+var _decoderTime = time.Date(2018, 6, 19, 16, 33, 42, 123456000, time.UTC)
+
+func encodeForDecoding(t *testing.T, cfg EncoderConfig, ent Entry, fields ...Field) []byte {
+	buf, err := NewJSONEncoder(cfg).EncodeEntry(ent, fields)
+	require.NoError(t, err, "Unexpected JSON encoding error.")
+	defer buf.Free()
+	return append([]byte(nil), buf.Bytes()...)
+}
+
+func TestJSONDecoderTimeEncoders(t *testing.T) {
+	tests := []struct {
+		desc      string
+		enc       TimeEncoder
+		precision time.Duration
+	}{
+		{"epoch", EpochTimeEncoder, time.Microsecond},
+		{"epoch millis", EpochMillisTimeEncoder, time.Microsecond},
+		{"epoch nanos", EpochNanosTimeEncoder, time.Nanosecond},
+		{"ISO8601", ISO8601TimeEncoder, time.Millisecond},
+		{"RFC3339", RFC3339TimeEncoder, time.Second},
+		{"RFC3339 nano", RFC3339NanoTimeEncoder, time.Nanosecond},
+		{"layout", TimeEncoderOfLayout("02/01/2006 15:04:05.000000"), time.Microsecond},
+		{
+			desc: "custom",
+			enc: func(t time.Time, enc PrimitiveArrayEncoder) {
+				enc.AppendString(t.Format(time.RFC1123Z))
+			},
+			precision: time.Second,
+		},
+		{
+			desc: "custom unix seconds",
+			enc: func(t time.Time, enc PrimitiveArrayEncoder) {
+				enc.AppendInt64(t.Unix())
+			},
+			precision: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.EncodeTime = tt.enc
+			line := encodeForDecoding(t, cfg, Entry{Time: _decoderTime, Message: "hi"}, zap.Time("at", _decoderTime))
+
+			dec := NewJSONDecoder(bytes.NewReader(line), cfg)
+			ent, fields, err := dec.Decode()
+			require.NoError(t, err, "Unexpected error decoding %s.", line)
+			want := _decoderTime.Truncate(tt.precision)
+			assert.True(t, want.Equal(ent.Time), "Expected time %v, got %v.", want, ent.Time)
+
+			require.Len(t, fields, 1, "Expected a single field.")
+			at, ok := dec.DecodeTime(fields[0])
+			require.True(t, ok, "Expected to decode the time field %v.", fields[0])
+			assert.True(t, want.Equal(at), "Expected time field %v, got %v.", want, at)
+		})
+	}
+}
+
+func TestJSONDecoderLevelEncoders(t *testing.T) {
+	tests := []struct {
+		desc string
+		enc  LevelEncoder
+	}{
+		{"lowercase", LowercaseLevelEncoder},
+		{"lowercase color", LowercaseColorLevelEncoder},
+		{"capital", CapitalLevelEncoder},
+		{"capital color", CapitalColorLevelEncoder},
+		{"GCP", GCPSeverityEncoder},
+		{"Datadog", DatadogStatusEncoder},
+		{"number", func(l Level, enc PrimitiveArrayEncoder) { enc.AppendInt8(int8(l)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.EncodeLevel = tt.enc
+			for _, l := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel} {
+				line := encodeForDecoding(t, cfg, Entry{Level: l})
+				ent, fields, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+				require.NoError(t, err, "Unexpected error decoding %s.", line)
+				assert.Equal(t, l, ent.Level, "Unexpected level decoded from %s.", line)
+				assert.Empty(t, fields, "Unexpected fields decoded from %s.", line)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		_, fields, err := NewJSONDecoder(strings.NewReader(`{"level":""}`), testEncoderConfig()).Decode()
+		require.NoError(t, err, "Unexpected error decoding an empty level.")
+		assert.Equal(t, []Field{zap.String("level", "")}, fields, "Expected an empty level to be kept as a field.")
+	})
+
+	t.Run("different encoder", func(t *testing.T) {
+		cfg := testEncoderConfig()
+		cfg.EncodeLevel = CapitalColorLevelEncoder
+		line := encodeForDecoding(t, cfg, Entry{Level: WarnLevel})
+
+		cfg.EncodeLevel = LowercaseLevelEncoder
+		ent, _, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+		require.NoError(t, err, "Unexpected error decoding %s.", line)
+		assert.Equal(t, WarnLevel, ent.Level, "Expected level names to be recognized regardless of color.")
+	})
+}
+
+func TestJSONDecoderDurationEncoders(t *testing.T) {
+	tests := []struct {
+		desc string
+		enc  DurationEncoder
+		d    time.Duration
+	}{
+		{"seconds", SecondsDurationEncoder, 1500 * time.Millisecond},
+		{"nanos", NanosDurationEncoder, 1500 * time.Nanosecond},
+		{"millis", MillisDurationEncoder, 1500 * time.Millisecond},
+		{"string", StringDurationEncoder, 90*time.Minute + time.Nanosecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.EncodeDuration = tt.enc
+			line := encodeForDecoding(t, cfg, Entry{}, zap.Duration("took", tt.d))
+
+			dec := NewJSONDecoder(bytes.NewReader(line), cfg)
+			_, fields, err := dec.Decode()
+			require.NoError(t, err, "Unexpected error decoding %s.", line)
+			require.Len(t, fields, 1, "Expected a single field.")
+			d, ok := dec.DecodeDuration(fields[0])
+			require.True(t, ok, "Expected to decode the duration field %v.", fields[0])
+			assert.Equal(t, tt.d, d, "Unexpected duration.")
+		})
+	}
+
+	t.Run("mismatched types", func(t *testing.T) {
+		cfg := testEncoderConfig()
+		cfg.EncodeDuration = StringDurationEncoder
+		dec := NewJSONDecoder(strings.NewReader(""), cfg)
+		_, ok := dec.DecodeDuration(zap.Int64("n", 1))
+		assert.False(t, ok, "Expected numbers not to decode as string durations.")
+
+		cfg.EncodeDuration = NanosDurationEncoder
+		dec = NewJSONDecoder(strings.NewReader(""), cfg)
+		_, ok = dec.DecodeDuration(zap.String("s", "1s"))
+		assert.False(t, ok, "Expected strings not to decode as numeric durations.")
+		_, ok = dec.DecodeDuration(zap.Bool("b", true))
+		assert.False(t, ok, "Expected booleans not to decode as durations.")
+	})
+}
+
+func TestJSONDecoderEntry(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.FunctionKey = "func"
+	cfg.EncodeCaller = FullCallerEncoder
+	ent := Entry{
+		Level:      ErrorLevel,
+		Time:       _decoderTime,
+		LoggerName: "main.sub",
+		Message:    "hello\nworld",
+		Caller:     EntryCaller{Defined: true, File: "/src/foo:bar.go", Line: 42, Function: "foo.Bar"},
+		Stack:      "fake-stack",
+	}
+	line := encodeForDecoding(t, cfg, ent, zap.String("msg", "duplicate key"))
+
+	got, fields, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+	require.NoError(t, err, "Unexpected error decoding %s.", line)
+	assert.True(t, ent.Time.Equal(got.Time), "Unexpected time.")
+	got.Time = ent.Time
+	assert.Equal(t, ent, got, "Unexpected entry.")
+	assert.Equal(t, []Field{zap.String("msg", "duplicate key")}, fields, "Expected repeated keys to be kept as fields.")
+}
+
+func TestJSONDecoderStructuredCallers(t *testing.T) {
+	caller := EntryCaller{Defined: true, File: "app/foo.go", Line: 42, Function: "app.Foo"}
+	for _, enc := range []CallerEncoder{GCPSourceLocationEncoder, ECSCallerEncoder} {
+		cfg := testEncoderConfig()
+		cfg.EncodeCaller = enc
+		line := encodeForDecoding(t, cfg, Entry{Caller: caller})
+
+		ent, fields, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+		require.NoError(t, err, "Unexpected error decoding %s.", line)
+		assert.Equal(t, caller, ent.Caller, "Unexpected caller decoded from %s.", line)
+		assert.Empty(t, fields, "Unexpected fields decoded from %s.", line)
+	}
+}
+
+func TestJSONDecoderFields(t *testing.T) {
+	cfg := testEncoderConfig()
+	line := []byte(`{"level":"info","msg":"hi","str":"s","int":-1,"big":18446744073709551615,` +
+		`"float":1.5,"exp":1e3,"bool":true,"null":null,"level":"not a level",` +
+		`"obj":{"a":[1,"two",{"b":false}],"c":{}},"arr":[]}`)
+
+	ent, fields, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+	require.NoError(t, err, "Unexpected error decoding %s.", line)
+	assert.Equal(t, Entry{Level: InfoLevel, Message: "hi"}, ent, "Unexpected entry.")
+	assert.Equal(t, []Field{
+		zap.String("str", "s"),
+		zap.Int64("int", -1),
+		zap.Uint64("big", math.MaxUint64),
+		zap.Float64("float", 1.5),
+		zap.Float64("exp", 1000),
+		zap.Bool("bool", true),
+		{Key: "null", Type: ReflectType},
+		zap.String("level", "not a level"),
+		zap.Object("obj", DecodedObject{
+			zap.Array("a", DecodedArray{
+				zap.Int64("", 1),
+				zap.String("", "two"),
+				zap.Object("", DecodedObject{zap.Bool("b", false)}),
+			}),
+			zap.Object("c", DecodedObject(nil)),
+		}),
+		zap.Array("arr", DecodedArray{}),
+	}, fields, "Unexpected fields.")
+}
+
+func TestJSONDecoderReencode(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.EncodeTime = RFC3339NanoTimeEncoder
+	line := encodeForDecoding(t, cfg, Entry{
+		Level:      WarnLevel,
+		Time:       _decoderTime,
+		LoggerName: "main",
+		Message:    "hello",
+		Caller:     EntryCaller{Defined: true, File: "foo.go", Line: 42},
+	},
+		zap.Int("int", 1),
+		zap.Float64("float", 0.5),
+		zap.Uint64("big", math.MaxUint64),
+		zap.Strings("strs", []string{"a", "b"}),
+		zap.Any("nested", map[string]interface{}{"a": []interface{}{1.5, true, nil}}),
+		zap.Namespace("ns"),
+		zap.String("inner", "x"),
+	)
+
+	ent, fields, err := NewJSONDecoder(bytes.NewReader(line), cfg).Decode()
+	require.NoError(t, err, "Unexpected error decoding %s.", line)
+	assert.Equal(t, string(line), string(encodeForDecoding(t, cfg, ent, fields...)), "Expected decoding and encoding to round-trip.")
+}
+
+func TestJSONDecoderStream(t *testing.T) {
+	input := "\n" +
+		`{"level":"info","msg":"one"}` + "\n" +
+		"not json\n" +
+		`["not an object"]` + "\n" +
+		`{"msg":"trailing"} {}` + "\n" +
+		`{"msg":"unterminated"` + "\n" +
+		"  \n" +
+		`{"level":"warn","msg":"two"}`
+
+	dec := NewJSONDecoder(strings.NewReader(input), testEncoderConfig())
+	var msgs []string
+	var errs int
+	for {
+		ent, _, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			assert.True(t, strings.HasPrefix(err.Error(), "json: "), "Unexpected error %v.", err)
+			errs++
+			continue
+		}
+		msgs = append(msgs, ent.Message)
+	}
+	assert.Equal(t, []string{"one", "two"}, msgs, "Unexpected messages.")
+	assert.Equal(t, 4, errs, "Expected an error for each malformed line.")
+}
+
+func TestJSONDecoderTooDeep(t *testing.T) {
+	line := `{"a":` + strings.Repeat("[", 2000) + strings.Repeat("]", 2000) + "}"
+	_, _, err := NewJSONDecoder(strings.NewReader(line), testEncoderConfig()).Decode()
+	assert.ErrorContains(t, err, "maximum nesting depth exceeded", "Expected deeply nested input to fail.")
+}
+
+func TestJSONDecoderDecodeFields(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.EncodeTime = EpochTimeEncoder
+	dec := NewJSONDecoder(nil, cfg)
+
+	ent, fields := dec.DecodeFields([]Field{
+		zap.Time(cfg.TimeKey, _decoderTime),
+		zap.String(cfg.LevelKey, "warn"),
+		zap.String(cfg.MessageKey, "hi"),
+		zap.Duration("took", time.Second),
+		zap.Float32("f", 1.5),
+	})
+	assert.Equal(t, Entry{Time: _decoderTime, Level: WarnLevel, Message: "hi"}, ent, "Unexpected entry.")
+	require.Len(t, fields, 2, "Unexpected fields.")
+
+	d, ok := dec.DecodeDuration(fields[0])
+	assert.True(t, ok, "Expected duration fields to decode as durations.")
+	assert.Equal(t, time.Second, d, "Unexpected duration.")
+
+	at, ok := dec.DecodeTime(zap.Time("at", _decoderTime.In(time.FixedZone("X", 3600))))
+	assert.True(t, ok && at.Equal(_decoderTime), "Expected time fields to decode as times.")
+	_, offset := at.Zone()
+	assert.Equal(t, 3600, offset, "Expected the time's location to be kept.")
+}
+
+func TestDecodedArrayNativeTypes(t *testing.T) {
+	enc := NewMapObjectEncoder()
+	require.NoError(t, enc.AddArray("a", DecodedArray{
+		zap.Float32("", 1.5),
+		zap.ByteString("", []byte("bytes")),
+		zap.Duration("", time.Second),
+		zap.Time("", _decoderTime),
+		zap.Uint64("", math.MaxUint64),
+		{Type: ReflectType},
+	}), "Unexpected error encoding array.")
+	assert.Equal(t, []interface{}{
+		float32(1.5),
+		"bytes",
+		time.Second,
+		_decoderTime,
+		uint64(math.MaxUint64),
+		nil,
+	}, enc.Fields["a"], "Unexpected array elements.")
+}
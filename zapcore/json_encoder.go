@@ -46,8 +46,11 @@ func putJSONEncoder(enc *jsonEncoder) {
 	enc.buf = nil
 	enc.spaced = false
 	enc.openNamespaces = 0
+	enc.depth = 0
 	enc.reflectBuf = nil
 	enc.reflectEnc = nil
+	enc.keys.policy = KeepDuplicateKeys
+	enc.keys.reset()
 	_jsonPool.Put(enc)
 }
 
@@ -56,24 +59,28 @@ type jsonEncoder struct {
 	buf            *buffer.Buffer
 	spaced         bool // include spaces after colons and commas
 	openNamespaces int
+	depth          int // nesting of the objects and arrays being encoded
 
 	// for encoding generic values by reflection
 	reflectBuf *buffer.Buffer
 	reflectEnc ReflectedEncoder
+
+	// for resolving duplicate keys; unused with KeepDuplicateKeys
+	keys keyTracker
 }
 
 // NewJSONEncoder creates a fast, low-allocation JSON encoder. The encoder
 // appropriately escapes all field keys and values.
 //
-// Note that the encoder doesn't deduplicate keys, so it's possible to produce
-// a message like
+// Note that by default the encoder doesn't deduplicate keys, so it's possible
+// to produce a message like
 //
 //	{"foo":"bar","foo":"baz"}
 //
 // This is permitted by the JSON specification, but not encouraged. Many
 // libraries will ignore duplicate key-value pairs (typically keeping the last
 // pair) when unmarshaling, but users should attempt to avoid adding duplicate
-// keys.
+// keys. Set EncoderConfig.DuplicateKeys to have the encoder resolve them.
 func NewJSONEncoder(cfg EncoderConfig) Encoder {
 	return newJSONEncoder(cfg, false)
 }
@@ -94,6 +101,7 @@ func newJSONEncoder(cfg EncoderConfig, spaced bool) *jsonEncoder {
 		EncoderConfig: &cfg,
 		buf:           bufferpool.Get(),
 		spaced:        spaced,
+		keys:          keyTracker{policy: cfg.DuplicateKeys},
 	}
 }
 
@@ -182,6 +190,10 @@ func (enc *jsonEncoder) AddReflected(key string, obj interface{}) error {
 		return err
 	}
 	enc.addKey(key)
+	if enc.limitsValues() {
+		enc.appendLimitedJSON(valueBytes)
+		return nil
+	}
 	_, err = enc.buf.Write(valueBytes)
 	return err
 }
@@ -190,6 +202,7 @@ func (enc *jsonEncoder) OpenNamespace(key string) {
 	enc.addKey(key)
 	enc.buf.AppendByte('{')
 	enc.openNamespaces++
+	enc.openKeyScope()
 }
 
 func (enc *jsonEncoder) AddString(key, val string) {
@@ -209,22 +222,39 @@ func (enc *jsonEncoder) AddUint64(key string, val uint64) {
 
 func (enc *jsonEncoder) AppendArray(arr ArrayMarshaler) error {
 	enc.addElementSeparator()
+	if enc.tooDeep() {
+		return nil
+	}
+	enc.depth++
 	enc.buf.AppendByte('[')
-	err := arr.MarshalLogArray(enc)
+	var err error
+	if enc.EncoderConfig != nil && enc.Limits.MaxArrayLength > 0 {
+		err = enc.marshalLimitedArray(arr)
+	} else {
+		err = arr.MarshalLogArray(enc)
+	}
 	enc.buf.AppendByte(']')
+	enc.depth--
 	return err
 }
 
 func (enc *jsonEncoder) AppendObject(obj ObjectMarshaler) error {
 	// Close ONLY new openNamespaces that are created during
 	// AppendObject().
+	enc.addElementSeparator()
+	if enc.tooDeep() {
+		return nil
+	}
 	old := enc.openNamespaces
 	enc.openNamespaces = 0
-	enc.addElementSeparator()
+	enc.depth++
 	enc.buf.AppendByte('{')
+	enc.openKeyScope()
 	err := obj.MarshalLogObject(enc)
-	enc.buf.AppendByte('}')
 	enc.closeOpenNamespaces()
+	enc.closeKeyScope()
+	enc.buf.AppendByte('}')
+	enc.depth--
 	enc.openNamespaces = old
 	return err
 }
@@ -237,7 +267,12 @@ func (enc *jsonEncoder) AppendBool(val bool) {
 func (enc *jsonEncoder) AppendByteString(val []byte) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
+	var marker string
+	if enc.EncoderConfig != nil {
+		val, marker = enc.Limits.truncateBytes(val)
+	}
 	enc.safeAddByteString(val)
+	enc.buf.AppendString(marker)
 	enc.buf.AppendByte('"')
 }
 
@@ -284,6 +319,10 @@ func (enc *jsonEncoder) AppendReflected(val interface{}) error {
 	if err != nil {
 		return err
 	}
+	if enc.limitsValues() {
+		enc.appendLimitedJSON(valueBytes)
+		return nil
+	}
 	enc.addElementSeparator()
 	_, err = enc.buf.Write(valueBytes)
 	return err
@@ -292,7 +331,12 @@ func (enc *jsonEncoder) AppendReflected(val interface{}) error {
 func (enc *jsonEncoder) AppendString(val string) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
+	var marker string
+	if enc.EncoderConfig != nil {
+		val, marker = enc.Limits.truncateString(val)
+	}
 	enc.safeAddString(val)
+	enc.buf.AppendString(marker)
 	enc.buf.AppendByte('"')
 }
 
@@ -346,6 +390,7 @@ func (enc *jsonEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint6
 func (enc *jsonEncoder) Clone() Encoder {
 	clone := enc.clone()
 	clone.buf.Write(enc.buf.Bytes())
+	clone.keys.copyFrom(&enc.keys)
 	return clone
 }
 
@@ -354,12 +399,24 @@ func (enc *jsonEncoder) clone() *jsonEncoder {
 	clone.EncoderConfig = enc.EncoderConfig
 	clone.spaced = enc.spaced
 	clone.openNamespaces = enc.openNamespaces
+	clone.keys.policy = enc.keys.policy
 	clone.buf = bufferpool.Get()
 	return clone
 }
 
 func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	buf := enc.encodeEntry(ent, fields, true)
+	if max := enc.Limits.MaxEntrySize; max > 0 && buf.Len() > max {
+		buf = enc.Limits.fitEntry(buf, ent, fields, enc.encodeEntry)
+	}
+	return buf, nil
+}
+
+func (enc *jsonEncoder) encodeEntry(ent Entry, fields []Field, withContext bool) *buffer.Buffer {
 	final := enc.clone()
+	if !withContext {
+		final.openNamespaces = 0
+	}
 	final.buf.AppendByte('{')
 
 	if final.LevelKey != "" && final.EncodeLevel != nil {
@@ -413,36 +470,59 @@ func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 		final.addKey(enc.MessageKey)
 		final.AppendString(ent.Message)
 	}
-	if enc.buf.Len() > 0 {
-		final.addElementSeparator()
-		final.buf.Write(enc.buf.Bytes())
+	if withContext && enc.buf.Len() > 0 {
+		if final.keys.enabled() {
+			final.addContext(enc)
+		} else {
+			final.addElementSeparator()
+			final.buf.Write(enc.buf.Bytes())
+		}
 	}
 	addFields(final, fields)
 	final.closeOpenNamespaces()
 	if ent.Stack != "" && final.StacktraceKey != "" {
 		final.AddString(final.StacktraceKey, ent.Stack)
 	}
+	final.closeKeyScope()
 	final.buf.AppendByte('}')
 	final.buf.AppendString(final.LineEnding)
 
 	ret := final.buf
 	putJSONEncoder(final)
-	return ret, nil
+	return ret
 }
 
 func (enc *jsonEncoder) truncate() {
 	enc.buf.Reset()
+	enc.keys.reset()
 }
 
 func (enc *jsonEncoder) closeOpenNamespaces() {
 	for i := 0; i < enc.openNamespaces; i++ {
+		enc.closeKeyScope()
 		enc.buf.AppendByte('}')
 	}
 	enc.openNamespaces = 0
 }
 
 func (enc *jsonEncoder) addKey(key string) {
+	if enc.keys.enabled() {
+		enc.addTrackedKey(key)
+		return
+	}
+	enc.addElementSeparator()
+	enc.writeKey(key)
+}
+
+func (enc *jsonEncoder) addTrackedKey(key string) {
+	key, drop := enc.resolveKey(key)
 	enc.addElementSeparator()
+	start := enc.buf.Len()
+	enc.writeKey(key)
+	enc.trackKey(key, start, drop)
+}
+
+func (enc *jsonEncoder) writeKey(key string) {
 	enc.buf.AppendByte('"')
 	enc.safeAddString(key)
 	enc.buf.AppendByte('"')
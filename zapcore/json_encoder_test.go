@@ -21,11 +21,17 @@
 package zapcore_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -280,3 +286,182 @@ func TestJSONCustomReflectedEncoder(t *testing.T) {
 		})
 	}
 }
+
+// fuzzFields builds fields from a fuzzer's input. Each byte of ops picks the
+// type of a field with its low bits and its key with its high bits; keys are
+// drawn from a small set, so some collide. It also returns the number of keys
+// the fields add to the top level of an entry, assuming duplicates are kept.
+func fuzzFields(ops []byte, s string, f float64, i int64) (fields []zapcore.Field, topLevel int) {
+	if len(ops) > 64 {
+		ops = ops[:64]
+	}
+	keys := [...]string{"a", "b", "", s}
+	inNamespace := false
+	for _, op := range ops {
+		key := keys[(op>>4)%4]
+		var field zapcore.Field
+		switch op % 10 {
+		case 0:
+			field = zap.String(key, s)
+		case 1:
+			field = zap.Float64(key, f)
+		case 2:
+			field = zap.Int64(key, i)
+		case 3:
+			field = zap.Bool(key, op&0x80 != 0)
+		case 4:
+			field = zap.Binary(key, []byte(s))
+		case 5:
+			field = zap.ByteString(key, []byte(s))
+		case 6:
+			field = zap.Strings(key, []string{s, s})
+		case 7:
+			field = zap.Object(key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("a", s)
+				enc.OpenNamespace("ns")
+				enc.AddFloat64("f", f)
+				return nil
+			}))
+		case 8:
+			field = zap.Namespace(key)
+		case 9:
+			fields = append(fields, zap.Skip())
+			continue
+		}
+		if !inNamespace {
+			topLevel++
+		}
+		inNamespace = inNamespace || field.Type == zapcore.NamespaceType
+		fields = append(fields, field)
+	}
+	return fields, topLevel
+}
+
+// jsonKeys walks the JSON value read from dec, returning the number of keys
+// in its top-level object and whether any object repeats a key.
+func jsonKeys(dec *json.Decoder) (topLevel int, duplicates bool, err error) {
+	var walk func(depth int) error
+	walk = func(depth int) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			seen := make(map[string]bool)
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if seen[key.(string)] {
+					duplicates = true
+				}
+				seen[key.(string)] = true
+				if depth == 0 {
+					topLevel++
+				}
+				if err := walk(depth + 1); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+			return err
+		case json.Delim('['):
+			for dec.More() {
+				if err := walk(depth + 1); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+			return err
+		}
+		return nil
+	}
+	err = walk(0)
+	return topLevel, duplicates, err
+}
+
+// shape describes the keys of every object in a value, ignoring the values
+// of strings, numbers and the like. Keys are described as the JSON encoder
+// writes them, with invalid UTF-8 replaced.
+func shape(v interface{}) string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprintf("%q:%s", replaceInvalidUTF8(k), shape(v[k]))
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = shape(e)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+	return "_"
+}
+
+func replaceInvalidUTF8(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		// Ranging over a string yields utf8.RuneError for each invalid byte.
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func FuzzJSONEncoder(f *testing.F) {
+	f.Add("hello", []byte{0, 0x11, 0x21, 0x37, 0x48, 0x02, 0x13}, "value", 1.5, int64(-7), false)
+	f.Add("a\nb\x00\xff", []byte{0x30, 0x30, 0x08, 0x36, 0x04}, "\"\\\u2028\xfe", -0.0, int64(1)<<62, true)
+	f.Add("", []byte{0x38, 0x38, 0x18, 0x01, 0x09}, "b", float64(1)/3, int64(0), true)
+
+	f.Fuzz(func(t *testing.T, msg string, ops []byte, s string, fl float64, i int64, lastKeyWins bool) {
+		// Keep field keys from colliding with the entry's keys.
+		if s == "L" || s == "M" {
+			s += "_"
+		}
+		cfg := zapcore.EncoderConfig{
+			MessageKey:  "M",
+			LevelKey:    "L",
+			EncodeLevel: zapcore.LowercaseLevelEncoder,
+		}
+		if lastKeyWins {
+			cfg.DuplicateKeys = zapcore.LastKeyWins
+		}
+		fields, topLevel := fuzzFields(ops, s, fl, i)
+
+		buf, err := zapcore.NewJSONEncoder(cfg).EncodeEntry(zapcore.Entry{Message: msg}, fields)
+		require.NoError(t, err, "Unexpected error encoding entry.")
+		out := buf.Bytes()
+
+		require.True(t, bytes.HasSuffix(out, []byte("\n")), "Expected a line ending in %q.", out)
+		require.Equal(t, 1, bytes.Count(out, []byte("\n")), "Expected a single line in %q.", out)
+		require.True(t, json.Valid(out), "Expected valid JSON in %q.", out)
+
+		gotTop, duplicates, err := jsonKeys(json.NewDecoder(bytes.NewReader(out)))
+		require.NoError(t, err, "Unexpected error reading %q.", out)
+
+		if !lastKeyWins {
+			assert.Equal(t, 2+topLevel, gotTop, "Unexpected number of top-level keys in %q.", out)
+			return
+		}
+		assert.False(t, duplicates, "Unexpected duplicate keys in %q.", out)
+
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(out, &got), "Unexpected error decoding %q.", out)
+		delete(got, "L")
+		delete(got, "M")
+		want := zapcore.NewMapObjectEncoder()
+		for _, f := range fields {
+			f.AddTo(want)
+		}
+		assert.Equal(t, shape(want.Fields), shape(got), "Unexpected keys in %q.", out)
+	})
+}
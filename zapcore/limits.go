@@ -0,0 +1,548 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+// TruncatedKey is the key of the field that the JSON and console encoders add
+// to entries that they shortened to fit within EncoderLimits.MaxEntrySize.
+const TruncatedKey = "truncated"
+
+// EncoderLimits caps the size of encoded values and entries. Values that
+// exceed a limit are truncated and marked as such in the output, so a single
+// oversized field can't produce an unbounded log entry. Zero values disable
+// the corresponding limit.
+//
+// Limits apply to fields encoded by reflection as well as to fields
+// marshaled by zap.
+type EncoderLimits struct {
+	// MaxStringLength caps the length in bytes of string values, including
+	// the entry's message and stacktrace. Longer strings are cut short and
+	// suffixed with a marker like "...(truncated 1.2MB)".
+	MaxStringLength int `json:"maxStringLength" yaml:"maxStringLength"`
+	// MaxArrayLength caps the number of elements in arrays. Remaining
+	// elements are replaced by a single marker element like
+	// "...(truncated 42 elements)".
+	MaxArrayLength int `json:"maxArrayLength" yaml:"maxArrayLength"`
+	// MaxDepth caps how deeply objects and arrays may be nested within the
+	// entry; the values of top-level fields are at depth one. Deeper values
+	// are replaced by a marker like "...(truncated at depth 8)". Namespaces
+	// don't count towards the depth.
+	MaxDepth int `json:"maxDepth" yaml:"maxDepth"`
+	// MaxEntrySize caps the size in bytes of each encoded entry. Entries
+	// that are too large are re-encoded without the trailing fields that
+	// don't fit, dropping the logger's context, the stacktrace, and finally
+	// the message if necessary. A field with TruncatedKey and a marker like
+	// "...(truncated 1.2MB)" is added to such entries.
+	MaxEntrySize int `json:"maxEntrySize" yaml:"maxEntrySize"`
+	// Truncations, if set, counts the values and entries truncated because
+	// of these limits. It may be shared by any number of encoders.
+	Truncations *TruncationCounter `json:"-" yaml:"-"`
+}
+
+// TruncationCounter counts the values and entries that encoders have
+// truncated because of their EncoderLimits. It's safe for concurrent use.
+type TruncationCounter struct {
+	strings atomic.Uint64
+	arrays  atomic.Uint64
+	depth   atomic.Uint64
+	entries atomic.Uint64
+}
+
+// TruncationCounts is a snapshot of a TruncationCounter.
+type TruncationCounts struct {
+	Strings uint64 // strings cut short because of MaxStringLength
+	Arrays  uint64 // arrays cut short because of MaxArrayLength
+	Depth   uint64 // values dropped because of MaxDepth
+	Entries uint64 // entries shortened because of MaxEntrySize
+}
+
+// Load returns the current counts.
+func (c *TruncationCounter) Load() TruncationCounts {
+	return TruncationCounts{
+		Strings: c.strings.Load(),
+		Arrays:  c.arrays.Load(),
+		Depth:   c.depth.Load(),
+		Entries: c.entries.Load(),
+	}
+}
+
+type truncation int
+
+const (
+	_truncatedString truncation = iota
+	_truncatedArray
+	_truncatedDepth
+	_truncatedEntry
+)
+
+func (c *TruncationCounter) add(t truncation) {
+	if c == nil {
+		return
+	}
+	switch t {
+	case _truncatedString:
+		c.strings.Add(1)
+	case _truncatedArray:
+		c.arrays.Add(1)
+	case _truncatedDepth:
+		c.depth.Add(1)
+	case _truncatedEntry:
+		c.entries.Add(1)
+	}
+}
+
+// sizeString formats a number of bytes for truncation markers; for example,
+// 1288490 bytes is formatted as "1.2MB".
+func sizeString(n int) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.Itoa(n) + "B"
+	}
+	size, suffix := float64(n)/unit, "KB"
+	for _, s := range []string{"MB", "GB", "TB"} {
+		if size < unit {
+			break
+		}
+		size, suffix = size/unit, s
+	}
+	return strconv.FormatFloat(size, 'f', 1, 64) + suffix
+}
+
+func stringMarker(removed int) string {
+	return "...(truncated " + sizeString(removed) + ")"
+}
+
+func arrayMarker(dropped int) string {
+	return "...(truncated " + strconv.Itoa(dropped) + " elements)"
+}
+
+func depthMarker(depth int) string {
+	return "...(truncated at depth " + strconv.Itoa(depth) + ")"
+}
+
+// truncateString returns the longest prefix of s that's at most max bytes
+// long without splitting a UTF-8 sequence, and the marker to append to it.
+// If s is short enough, it's returned as-is with an empty marker.
+func (l *EncoderLimits) truncateString(s string) (string, string) {
+	if l.MaxStringLength <= 0 || len(s) <= l.MaxStringLength {
+		return s, ""
+	}
+	l.Truncations.add(_truncatedString)
+	n := l.MaxStringLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], stringMarker(len(s) - n)
+}
+
+// truncateBytes is truncateString for byte slices.
+func (l *EncoderLimits) truncateBytes(b []byte) ([]byte, string) {
+	if l.MaxStringLength <= 0 || len(b) <= l.MaxStringLength {
+		return b, ""
+	}
+	l.Truncations.add(_truncatedString)
+	n := l.MaxStringLength
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	return b[:n], stringMarker(len(b) - n)
+}
+
+// _truncatedFieldSize is an upper bound on the size of the TruncatedKey field
+// added to entries that exceed MaxEntrySize.
+var _truncatedFieldSize = len(`, "` + TruncatedKey + `": "` + stringMarker(1<<50) + `"`)
+
+// fitEntry re-encodes an entry whose encoded form, orig, is larger than
+// MaxEntrySize. encode must encode the entry with the given fields, and with
+// or without the encoder's accumulated context.
+func (l *EncoderLimits) fitEntry(
+	orig *buffer.Buffer,
+	ent Entry,
+	fields []Field,
+	encode func(ent Entry, fields []Field, withContext bool) *buffer.Buffer,
+) *buffer.Buffer {
+	l.Truncations.add(_truncatedEntry)
+	size := orig.Len()
+	orig.Free()
+
+	budget := l.MaxEntrySize - _truncatedFieldSize
+	fits := func(ent Entry, fields []Field, withContext bool) bool {
+		buf := encode(ent, fields, withContext)
+		defer buf.Free()
+		return buf.Len() <= budget
+	}
+
+	// Keep as many leading fields as possible. Sizes only grow as fields
+	// are added, so a binary search finds the longest prefix that fits.
+	withContext := true
+	n := sort.Search(len(fields)+1, func(i int) bool {
+		return !fits(ent, fields[:i], true)
+	}) - 1
+	if n >= 0 {
+		fields = fields[:n]
+	} else {
+		fields, withContext = nil, false
+		if !fits(ent, nil, false) {
+			ent.Stack = ""
+			if !fits(ent, nil, false) {
+				ent.Message = ""
+			}
+		}
+	}
+
+	buf := encode(ent, fields, withContext)
+	removed := size - buf.Len()
+	buf.Free()
+
+	marked := make([]Field, len(fields), len(fields)+1)
+	copy(marked, fields)
+	marked = append(marked, Field{Type: InlineMarshalerType, Interface: truncatedEntryMarker(removed)})
+	return encode(ent, marked, withContext)
+}
+
+// truncatedEntryMarker adds the TruncatedKey field to entries that exceeded
+// MaxEntrySize, bypassing MaxStringLength.
+type truncatedEntryMarker int
+
+func (m truncatedEntryMarker) MarshalLogObject(enc ObjectEncoder) error {
+	if enc, ok := enc.(*jsonEncoder); ok {
+		enc.addKey(TruncatedKey)
+		enc.appendMarker(stringMarker(int(m)))
+		return nil
+	}
+	enc.AddString(TruncatedKey, stringMarker(int(m)))
+	return nil
+}
+
+// limitedArrayEncoder is an ArrayEncoder that passes at most max elements on
+// to a jsonEncoder, counting the rest.
+type limitedArrayEncoder struct {
+	enc     *jsonEncoder
+	max     int
+	n       int
+	dropped int
+}
+
+func (l *limitedArrayEncoder) next() bool {
+	if l.n < l.max {
+		l.n++
+		return true
+	}
+	l.dropped++
+	return false
+}
+
+func (l *limitedArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	if l.next() {
+		return l.enc.AppendArray(v)
+	}
+	return nil
+}
+
+func (l *limitedArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	if l.next() {
+		return l.enc.AppendObject(v)
+	}
+	return nil
+}
+
+func (l *limitedArrayEncoder) AppendReflected(v interface{}) error {
+	if l.next() {
+		return l.enc.AppendReflected(v)
+	}
+	return nil
+}
+
+func (l *limitedArrayEncoder) AppendBool(v bool) {
+	if l.next() {
+		l.enc.AppendBool(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendByteString(v []byte) {
+	if l.next() {
+		l.enc.AppendByteString(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendComplex128(v complex128) {
+	if l.next() {
+		l.enc.AppendComplex128(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendComplex64(v complex64) {
+	if l.next() {
+		l.enc.AppendComplex64(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendDuration(v time.Duration) {
+	if l.next() {
+		l.enc.AppendDuration(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendFloat64(v float64) {
+	if l.next() {
+		l.enc.AppendFloat64(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendFloat32(v float32) {
+	if l.next() {
+		l.enc.AppendFloat32(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendInt(v int) {
+	if l.next() {
+		l.enc.AppendInt(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendInt64(v int64) {
+	if l.next() {
+		l.enc.AppendInt64(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendInt32(v int32) {
+	if l.next() {
+		l.enc.AppendInt32(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendInt16(v int16) {
+	if l.next() {
+		l.enc.AppendInt16(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendInt8(v int8) {
+	if l.next() {
+		l.enc.AppendInt8(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendString(v string) {
+	if l.next() {
+		l.enc.AppendString(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendTime(v time.Time) {
+	if l.next() {
+		l.enc.AppendTime(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUint(v uint) {
+	if l.next() {
+		l.enc.AppendUint(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUint64(v uint64) {
+	if l.next() {
+		l.enc.AppendUint64(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUint32(v uint32) {
+	if l.next() {
+		l.enc.AppendUint32(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUint16(v uint16) {
+	if l.next() {
+		l.enc.AppendUint16(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUint8(v uint8) {
+	if l.next() {
+		l.enc.AppendUint8(v)
+	}
+}
+
+func (l *limitedArrayEncoder) AppendUintptr(v uintptr) {
+	if l.next() {
+		l.enc.AppendUintptr(v)
+	}
+}
+
+// appendLimitedJSON appends a JSON value produced by a ReflectedEncoder,
+// re-encoding it to apply the encoder's limits to the strings, arrays, and
+// nested values within it. If data isn't valid JSON, it's appended as-is.
+func (enc *jsonEncoder) appendLimitedJSON(data []byte) {
+	type frame struct {
+		array   bool
+		key     bool // the next token in this object is a key
+		elems   int
+		dropped int
+	}
+
+	limits := &enc.Limits
+	mark, depth := enc.buf.Len(), enc.depth
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var (
+		stack []frame
+		skip  int // nesting depth within a value that's being dropped
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			enc.buf.Truncate(mark)
+			enc.depth = depth
+			enc.addElementSeparator()
+			enc.buf.Write(data)
+			return
+		}
+
+		delim, isDelim := tok.(json.Delim)
+		if skip > 0 {
+			if delim == '{' || delim == '[' {
+				skip++
+			} else if delim == '}' || delim == ']' {
+				skip--
+			}
+			continue
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = &stack[len(stack)-1]
+		}
+		if isDelim && (delim == '}' || delim == ']') {
+			if top.dropped > 0 {
+				enc.appendMarker(arrayMarker(top.dropped))
+			}
+			enc.buf.AppendByte(byte(delim))
+			stack = stack[:len(stack)-1]
+			enc.depth--
+			continue
+		}
+
+		if top != nil && !top.array {
+			if top.key {
+				top.key = false
+				enc.addElementSeparator()
+				enc.writeKey(tok.(string))
+				continue
+			}
+			top.key = true
+		}
+		if top != nil && top.array && limits.MaxArrayLength > 0 {
+			if top.elems >= limits.MaxArrayLength {
+				if top.dropped == 0 {
+					limits.Truncations.add(_truncatedArray)
+				}
+				top.dropped++
+				if isDelim {
+					skip = 1
+				}
+				continue
+			}
+			top.elems++
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			if enc.tooDeep() {
+				skip = 1
+				continue
+			}
+			enc.addElementSeparator()
+			enc.buf.AppendByte(byte(v))
+			stack = append(stack, frame{array: v == '[', key: v == '{'})
+			enc.depth++
+		case string:
+			enc.AppendString(v)
+		case json.Number:
+			enc.addElementSeparator()
+			enc.buf.AppendString(string(v))
+		case bool:
+			enc.AppendBool(v)
+		case nil:
+			enc.addElementSeparator()
+			enc.buf.Write(nullLiteralBytes)
+		}
+	}
+}
+
+// tooDeep reports whether an object or array may not be added at the
+// current depth. If so, it appends a marker in place of the value.
+func (enc *jsonEncoder) tooDeep() bool {
+	if enc.EncoderConfig == nil || enc.Limits.MaxDepth <= 0 || enc.depth < enc.Limits.MaxDepth {
+		return false
+	}
+	enc.Limits.Truncations.add(_truncatedDepth)
+	enc.appendMarker(depthMarker(enc.Limits.MaxDepth + 1))
+	return true
+}
+
+// appendMarker appends a truncation marker as a string element. Markers
+// never need to be escaped.
+func (enc *jsonEncoder) appendMarker(marker string) {
+	enc.addElementSeparator()
+	enc.buf.AppendByte('"')
+	enc.buf.AppendString(marker)
+	enc.buf.AppendByte('"')
+}
+
+// limitsValues reports whether any of the encoder's limits apply to the
+// values within fields.
+func (enc *jsonEncoder) limitsValues() bool {
+	if enc.EncoderConfig == nil {
+		return false
+	}
+	l := &enc.Limits
+	return l.MaxStringLength > 0 || l.MaxArrayLength > 0 || l.MaxDepth > 0
+}
+
+func (enc *jsonEncoder) marshalLimitedArray(arr ArrayMarshaler) error {
+	limited := &limitedArrayEncoder{enc: enc, max: enc.Limits.MaxArrayLength}
+	err := arr.MarshalLogArray(limited)
+	if limited.dropped > 0 {
+		enc.Limits.Truncations.add(_truncatedArray)
+		enc.appendMarker(arrayMarker(limited.dropped))
+	}
+	return err
+}
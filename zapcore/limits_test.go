@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestEncoderLimitsValues(t *testing.T) {
+	ints := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		for i := 0; i < 5; i++ {
+			enc.AppendInt(i)
+		}
+		return nil
+	})
+	nested := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		return enc.AddObject("b", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			enc.AddInt("c", 1)
+			return enc.AddArray("d", ints)
+		}))
+	})
+	type user struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Inner *user    `json:"inner,omitempty"`
+	}
+
+	tests := []struct {
+		desc   string
+		limits EncoderLimits
+		field  Field
+		want   string
+		counts TruncationCounts
+	}{
+		{
+			desc:   "string",
+			limits: EncoderLimits{MaxStringLength: 5},
+			field:  zap.String("k", strings.Repeat("a", 2000)),
+			want:   `"k":"aaaaa...(truncated 1.9KB)"`,
+			counts: TruncationCounts{Strings: 1},
+		},
+		{
+			desc:   "short string",
+			limits: EncoderLimits{MaxStringLength: 5},
+			field:  zap.String("k", "abcde"),
+			want:   `"k":"abcde"`,
+		},
+		{
+			desc:   "string split within rune",
+			limits: EncoderLimits{MaxStringLength: 2},
+			field:  zap.String("k", "a☃b"),
+			want:   `"k":"a...(truncated 4B)"`,
+			counts: TruncationCounts{Strings: 1},
+		},
+		{
+			desc:   "byte string",
+			limits: EncoderLimits{MaxStringLength: 3},
+			field:  zap.ByteString("k", []byte("abcdef")),
+			want:   `"k":"abc...(truncated 3B)"`,
+			counts: TruncationCounts{Strings: 1},
+		},
+		{
+			desc:   "array",
+			limits: EncoderLimits{MaxArrayLength: 2},
+			field:  zap.Array("k", ints),
+			want:   `"k":[0,1,"...(truncated 3 elements)"]`,
+			counts: TruncationCounts{Arrays: 1},
+		},
+		{
+			desc:   "depth",
+			limits: EncoderLimits{MaxDepth: 2},
+			field:  zap.Object("a", nested),
+			want:   `"a":{"b":{"c":1,"d":"...(truncated at depth 3)"}}`,
+			counts: TruncationCounts{Depth: 1},
+		},
+		{
+			desc:   "depth of top-level field",
+			limits: EncoderLimits{MaxDepth: 1},
+			field:  zap.Array("k", ints),
+			want:   `"k":[0,1,2,3,4]`,
+		},
+		{
+			desc:   "reflected",
+			limits: EncoderLimits{MaxStringLength: 3, MaxArrayLength: 1, MaxDepth: 2},
+			field: zap.Reflect("k", user{
+				Name:  "alice",
+				Tags:  []string{"a", "b", "c"},
+				Inner: &user{Name: "bob", Tags: []string{"d"}},
+			}),
+			want:   `"k":{"name":"ali...(truncated 2B)","tags":["a","...(truncated 2 elements)"],"inner":{"name":"bob","tags":"...(truncated at depth 3)"}}`,
+			counts: TruncationCounts{Strings: 1, Arrays: 1, Depth: 1},
+		},
+		{
+			desc:   "reflected without limits",
+			limits: EncoderLimits{MaxEntrySize: 1 << 20},
+			field:  zap.Reflect("k", user{Name: "alice", Tags: []string{"a", "b"}}),
+			want:   `"k":{"name":"alice","tags":["a","b"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var counter TruncationCounter
+			tt.limits.Truncations = &counter
+			enc := NewJSONEncoder(EncoderConfig{Limits: tt.limits})
+
+			buf, err := enc.EncodeEntry(Entry{}, []Field{tt.field})
+			require.NoError(t, err, "Unexpected JSON encoding error.")
+			defer buf.Free()
+
+			assert.Equal(t, "{"+tt.want+"}\n", buf.String(), "Incorrect encoded entry.")
+			assert.True(t, json.Valid(buf.Bytes()), "Expected valid JSON.")
+			assert.Equal(t, tt.counts, counter.Load(), "Unexpected truncation counts.")
+		})
+	}
+}
+
+func TestEncoderLimitsEntrySize(t *testing.T) {
+	cfg := EncoderConfig{
+		MessageKey:    "msg",
+		StacktraceKey: "stacktrace",
+		Limits:        EncoderLimits{MaxEntrySize: 120},
+	}
+	big := strings.Repeat("x", 100)
+
+	tests := []struct {
+		desc    string
+		ent     Entry
+		context []Field
+		fields  []Field
+		want    string
+	}{
+		{
+			desc:   "fits",
+			ent:    Entry{Message: "hello"},
+			fields: []Field{zap.Int("a", 1)},
+			want:   `{"msg":"hello","a":1}`,
+		},
+		{
+			desc:   "drop trailing fields",
+			ent:    Entry{Message: "hello"},
+			fields: []Field{zap.Int("a", 1), zap.String("b", big), zap.Int("c", 3)},
+			want:   `{"msg":"hello","a":1,"truncated":"...(truncated 113B)"}`,
+		},
+		{
+			desc:    "drop context",
+			ent:     Entry{Message: "hello"},
+			context: []Field{zap.String("ctx", big)},
+			fields:  []Field{zap.Int("a", 1)},
+			want:    `{"msg":"hello","truncated":"...(truncated 115B)"}`,
+		},
+		{
+			desc: "drop stack and message",
+			ent:  Entry{Message: big, Stack: big},
+			want: `{"msg":"","truncated":"...(truncated 216B)"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var counter TruncationCounter
+			cfg := cfg
+			cfg.Limits.Truncations = &counter
+			enc := NewJSONEncoder(cfg)
+			for _, f := range tt.context {
+				f.AddTo(enc)
+			}
+
+			buf, err := enc.EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err, "Unexpected JSON encoding error.")
+			defer buf.Free()
+
+			assert.Equal(t, tt.want+"\n", buf.String(), "Incorrect encoded entry.")
+			assert.LessOrEqual(t, buf.Len(), cfg.Limits.MaxEntrySize, "Entry exceeds limit.")
+			if tt.want != `{"msg":"hello","a":1}` {
+				assert.Equal(t, TruncationCounts{Entries: 1}, counter.Load(), "Unexpected truncation counts.")
+			}
+		})
+	}
+}
+
+func TestEncoderLimitsConsole(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{
+		MessageKey:    "msg",
+		StacktraceKey: "stacktrace",
+		Limits:        EncoderLimits{MaxStringLength: 5, MaxEntrySize: 90},
+	})
+	enc.AddString("ctx", "abcdefgh")
+
+	buf, err := enc.EncodeEntry(
+		Entry{Message: "hello world", Stack: "fake-stack"},
+		[]Field{zap.Int("a", 1)},
+	)
+	require.NoError(t, err, "Unexpected console encoding error.")
+	assert.Equal(t,
+		"hello...(truncated 6B)\t"+`{"ctx": "abcde...(truncated 3B)", "a": 1}`+"\nfake-...(truncated 5B)\n",
+		buf.String(), "Incorrect encoded entry.")
+	buf.Free()
+
+	buf, err = enc.EncodeEntry(
+		Entry{Message: "hello world", Stack: "fake-stack"},
+		[]Field{zap.Int("a", 1), zap.Int("b", 2)},
+	)
+	require.NoError(t, err, "Unexpected console encoding error.")
+	assert.Equal(t,
+		"hello...(truncated 6B)\t"+`{"truncated": "...(truncated 50B)"}`+"\nfake-...(truncated 5B)\n",
+		buf.String(), "Incorrect encoded entry.")
+	buf.Free()
+}
+
+func TestEncoderLimitsMalformedReflected(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{
+		Limits: EncoderLimits{MaxDepth: 2},
+		NewReflectedEncoder: func(w io.Writer) ReflectedEncoder {
+			return rawReflectedEncoder{w}
+		},
+	})
+	nested := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddInt("b", 1)
+		return nil
+	})
+
+	// The malformed value opens two objects before the decoder gives up on
+	// it, which mustn't affect the depth of the fields after it.
+	buf, err := enc.EncodeEntry(Entry{}, []Field{
+		zap.Reflect("k", `{"a":{"b":}`),
+		zap.Object("a", nested),
+	})
+	require.NoError(t, err, "Unexpected JSON encoding error.")
+	defer buf.Free()
+
+	assert.Equal(t, `{"k":{"a":{"b":},"a":{"b":1}}`+"\n", buf.String(), "Incorrect encoded entry.")
+}
+
+// Writes strings as-is, whether or not they're valid JSON.
+type rawReflectedEncoder struct {
+	writer io.Writer
+}
+
+func (enc rawReflectedEncoder) Encode(obj interface{}) error {
+	_, err := io.WriteString(enc.writer, obj.(string))
+	return err
+}
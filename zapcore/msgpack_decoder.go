@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// MsgpackDecoder reads entries written by the MessagePack encoder. It
+// accepts any stream of MessagePack objects whose top level is a map with
+// string keys.
+//
+// Values are decoded as
+//
+//   - maps as map[string]interface{}, and arrays as []interface{}
+//   - strings as string, and binary data as []byte
+//   - integers as int64, or as uint64 if they don't fit in an int64
+//   - floating-point numbers as float32 or float64
+//   - booleans as bool, and nil as nil
+//   - timestamps as time.Time in UTC, and extension type 1 as
+//     time.Duration
+//
+// Other extension types are decoded as the []byte holding their data.
+type MsgpackDecoder struct {
+	r binaryReader
+}
+
+// NewMsgpackDecoder creates a MsgpackDecoder reading from r.
+func NewMsgpackDecoder(r io.Reader) *MsgpackDecoder {
+	return &MsgpackDecoder{r: newBinaryReader(r)}
+}
+
+// Decode reads the next entry. It returns io.EOF once the input is
+// exhausted.
+func (d *MsgpackDecoder) Decode() (map[string]interface{}, error) {
+	if err := d.r.start(); err != nil {
+		return nil, err
+	}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: %w", err)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("msgpack: expected a map, got %T", v)
+	}
+	return m, nil
+}
+
+func (d *MsgpackDecoder) value(depth int) (interface{}, error) {
+	if depth > _maxDecodeDepth {
+		return nil, errDecodeTooDeep
+	}
+
+	c, err := d.r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == _msgpackFixmap:
+		return d.dict(uint64(c&0x0f), depth)
+	case c&0xf0 == _msgpackFixarray:
+		return d.array(uint64(c&0x0f), depth)
+	case c&0xe0 == _msgpackFixstr:
+		return d.str(uint64(c & 0x1f))
+	}
+
+	switch c {
+	case _msgpackNil:
+		return nil, nil
+	case _msgpackFalse:
+		return false, nil
+	case _msgpackTrue:
+		return true, nil
+	case _msgpackBin8, _msgpackBin16, _msgpackBin32:
+		n, err := d.r.readUint(1 << (c - _msgpackBin8))
+		if err != nil {
+			return nil, err
+		}
+		return d.r.readBytes(n)
+	case _msgpackExt8, _msgpackExt16, _msgpackExt32:
+		n, err := d.r.readUint(1 << (c - _msgpackExt8))
+		if err != nil {
+			return nil, err
+		}
+		return d.ext(n)
+	case _msgpackFloat32:
+		v, err := d.r.readUint(4)
+		return math.Float32frombits(uint32(v)), err
+	case _msgpackFloat64:
+		v, err := d.r.readUint(8)
+		return math.Float64frombits(v), err
+	case _msgpackUint8, _msgpackUint16, _msgpackUint32, _msgpackUint64:
+		v, err := d.r.readUint(1 << (c - _msgpackUint8))
+		return decodedInt(v), err
+	case _msgpackInt8, _msgpackInt16, _msgpackInt32, _msgpackInt64:
+		size := 1 << (c - _msgpackInt8)
+		v, err := d.r.readUint(size)
+		// Sign-extend from the encoded width.
+		shift := 64 - 8*size
+		return int64(v<<shift) >> shift, err
+	case _msgpackFixext1, _msgpackFixext2, _msgpackFixext4, _msgpackFixext8, _msgpackFixext16:
+		return d.ext(1 << (c - _msgpackFixext1))
+	case _msgpackStr8, _msgpackStr16, _msgpackStr32:
+		n, err := d.r.readUint(1 << (c - _msgpackStr8))
+		if err != nil {
+			return nil, err
+		}
+		return d.str(n)
+	case _msgpackArray16, _msgpackArray32:
+		n, err := d.r.readUint(2 << (c - _msgpackArray16))
+		if err != nil {
+			return nil, err
+		}
+		return d.array(n, depth)
+	case _msgpackMap16, _msgpackMap32:
+		n, err := d.r.readUint(2 << (c - _msgpackMap16))
+		if err != nil {
+			return nil, err
+		}
+		return d.dict(n, depth)
+	}
+	return nil, fmt.Errorf("invalid format byte 0x%x", c)
+}
+
+func (d *MsgpackDecoder) str(n uint64) (string, error) {
+	b, err := d.r.readBytes(n)
+	return string(b), err
+}
+
+func (d *MsgpackDecoder) array(n uint64, depth int) ([]interface{}, error) {
+	arr := make([]interface{}, 0, preallocLen(n))
+	for i := uint64(0); i < n; i++ {
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (d *MsgpackDecoder) dict(n uint64, depth int) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, preallocLen(n))
+	for i := uint64(0); i < n; i++ {
+		k, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string key, got %T", k)
+		}
+		if m[key], err = d.value(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ext reads an extension type and its n bytes of data.
+func (d *MsgpackDecoder) ext(n uint64) (interface{}, error) {
+	typ, err := d.r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.r.readBytes(n)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case typ == _msgpackExtTimestamp && n == 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case typ == _msgpackExtTimestamp && n == 8:
+		v := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), nil
+	case typ == _msgpackExtTimestamp && n == 12:
+		nsec := binary.BigEndian.Uint32(data)
+		sec := binary.BigEndian.Uint64(data[4:])
+		return time.Unix(int64(sec), int64(nsec)).UTC(), nil
+	case typ == _msgpackExtDuration && n == 8:
+		return time.Duration(binary.BigEndian.Uint64(data)), nil
+	}
+	return data, nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+// MessagePack format bytes.
+const (
+	_msgpackNil      = 0xc0
+	_msgpackFalse    = 0xc2
+	_msgpackTrue     = 0xc3
+	_msgpackBin8     = 0xc4
+	_msgpackBin16    = 0xc5
+	_msgpackBin32    = 0xc6
+	_msgpackExt8     = 0xc7
+	_msgpackExt16    = 0xc8
+	_msgpackExt32    = 0xc9
+	_msgpackFloat32  = 0xca
+	_msgpackFloat64  = 0xcb
+	_msgpackUint8    = 0xcc
+	_msgpackUint16   = 0xcd
+	_msgpackUint32   = 0xce
+	_msgpackUint64   = 0xcf
+	_msgpackInt8     = 0xd0
+	_msgpackInt16    = 0xd1
+	_msgpackInt32    = 0xd2
+	_msgpackInt64    = 0xd3
+	_msgpackFixext1  = 0xd4
+	_msgpackFixext2  = 0xd5
+	_msgpackFixext4  = 0xd6
+	_msgpackFixext8  = 0xd7
+	_msgpackFixext16 = 0xd8
+	_msgpackStr8     = 0xd9
+	_msgpackStr16    = 0xda
+	_msgpackStr32    = 0xdb
+	_msgpackArray16  = 0xdc
+	_msgpackArray32  = 0xdd
+	_msgpackMap16    = 0xde
+	_msgpackMap32    = 0xdf
+
+	_msgpackFixmap   = 0x80
+	_msgpackFixarray = 0x90
+	_msgpackFixstr   = 0xa0
+)
+
+// MessagePack extension types, as the byte written to the stream.
+const (
+	_msgpackExtTimestamp byte = 0xff // -1
+	_msgpackExtDuration  byte = 1
+)
+
+// NewMsgpackEncoder creates an encoder whose output is a stream of
+// MessagePack maps, one per entry, holding the same keys as the JSON
+// encoder's output. Maps and arrays always use 32-bit length headers so that
+// they can be written before their length is known.
+//
+// Times, durations, and binary data are encoded natively, so the
+// EncodeTime and EncodeDuration settings of the EncoderConfig are ignored.
+// Times use the timestamp extension type (-1), and durations use extension
+// type 1 holding a big-endian int64 count of nanoseconds. Complex numbers
+// are written as an array of their real and imaginary parts. Output written
+// with this encoder can be read back with a MsgpackDecoder.
+func NewMsgpackEncoder(cfg EncoderConfig) Encoder {
+	return newBinaryEncoder(cfg, msgpackFormat{})
+}
+
+type msgpackFormat struct{}
+
+func (msgpackFormat) appendNil(buf *buffer.Buffer) {
+	buf.AppendByte(_msgpackNil)
+}
+
+func (msgpackFormat) appendBool(buf *buffer.Buffer, v bool) {
+	if v {
+		buf.AppendByte(_msgpackTrue)
+	} else {
+		buf.AppendByte(_msgpackFalse)
+	}
+}
+
+func (f msgpackFormat) appendInt(buf *buffer.Buffer, v int64) {
+	switch {
+	case v >= 0:
+		f.appendUint(buf, uint64(v))
+	case v >= -32:
+		buf.AppendByte(byte(int8(v)))
+	case v >= math.MinInt8:
+		buf.AppendByte(_msgpackInt8)
+		buf.AppendByte(byte(int8(v)))
+	case v >= math.MinInt16:
+		buf.AppendByte(_msgpackInt16)
+		appendUint16(buf, uint16(int16(v)))
+	case v >= math.MinInt32:
+		buf.AppendByte(_msgpackInt32)
+		appendUint32(buf, uint32(int32(v)))
+	default:
+		buf.AppendByte(_msgpackInt64)
+		appendUint64(buf, uint64(v))
+	}
+}
+
+func (msgpackFormat) appendUint(buf *buffer.Buffer, v uint64) {
+	switch {
+	case v <= math.MaxInt8:
+		buf.AppendByte(byte(v))
+	case v <= math.MaxUint8:
+		buf.AppendByte(_msgpackUint8)
+		buf.AppendByte(byte(v))
+	case v <= math.MaxUint16:
+		buf.AppendByte(_msgpackUint16)
+		appendUint16(buf, uint16(v))
+	case v <= math.MaxUint32:
+		buf.AppendByte(_msgpackUint32)
+		appendUint32(buf, uint32(v))
+	default:
+		buf.AppendByte(_msgpackUint64)
+		appendUint64(buf, v)
+	}
+}
+
+func (msgpackFormat) appendFloat32(buf *buffer.Buffer, v float32) {
+	buf.AppendByte(_msgpackFloat32)
+	appendUint32(buf, math.Float32bits(v))
+}
+
+func (msgpackFormat) appendFloat64(buf *buffer.Buffer, v float64) {
+	buf.AppendByte(_msgpackFloat64)
+	appendUint64(buf, math.Float64bits(v))
+}
+
+func (msgpackFormat) appendStringHead(buf *buffer.Buffer, n int) {
+	switch {
+	case n < 32:
+		buf.AppendByte(_msgpackFixstr | byte(n))
+	case n <= math.MaxUint8:
+		buf.AppendByte(_msgpackStr8)
+		buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(_msgpackStr16)
+		appendUint16(buf, uint16(n))
+	default:
+		buf.AppendByte(_msgpackStr32)
+		appendUint32(buf, uint32(n))
+	}
+}
+
+// Strings should be valid UTF-8, so invalid sequences are replaced with
+// utf8.RuneError, as in the JSON encoder.
+func (f msgpackFormat) appendString(buf *buffer.Buffer, s string) {
+	if !utf8.ValidString(s) {
+		s = toValidUTF8(s)
+	}
+	f.appendStringHead(buf, len(s))
+	buf.AppendString(s)
+}
+
+func (f msgpackFormat) appendByteString(buf *buffer.Buffer, s []byte) {
+	if !utf8.Valid(s) {
+		f.appendString(buf, string(s))
+		return
+	}
+	f.appendStringHead(buf, len(s))
+	buf.Write(s)
+}
+
+func (msgpackFormat) appendBinary(buf *buffer.Buffer, v []byte) {
+	switch n := len(v); {
+	case n <= math.MaxUint8:
+		buf.AppendByte(_msgpackBin8)
+		buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(_msgpackBin16)
+		appendUint16(buf, uint16(n))
+	default:
+		buf.AppendByte(_msgpackBin32)
+		appendUint32(buf, uint32(n))
+	}
+	buf.Write(v)
+}
+
+// appendTime writes the smallest of the three timestamp formats that can
+// hold t.
+func (msgpackFormat) appendTime(buf *buffer.Buffer, t time.Time) {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case nsec == 0 && sec >= 0 && sec <= math.MaxUint32:
+		buf.AppendByte(_msgpackFixext4)
+		buf.AppendByte(_msgpackExtTimestamp)
+		appendUint32(buf, uint32(sec))
+	case sec >= 0 && sec>>34 == 0:
+		buf.AppendByte(_msgpackFixext8)
+		buf.AppendByte(_msgpackExtTimestamp)
+		appendUint64(buf, nsec<<34|uint64(sec))
+	default:
+		buf.AppendByte(_msgpackExt8)
+		buf.AppendByte(12)
+		buf.AppendByte(_msgpackExtTimestamp)
+		appendUint32(buf, uint32(nsec))
+		appendUint64(buf, uint64(sec))
+	}
+}
+
+func (msgpackFormat) appendDuration(buf *buffer.Buffer, d time.Duration) {
+	buf.AppendByte(_msgpackFixext8)
+	buf.AppendByte(_msgpackExtDuration)
+	appendUint64(buf, uint64(d))
+}
+
+func (msgpackFormat) openMap(buf *buffer.Buffer) {
+	buf.AppendByte(_msgpackMap32)
+	appendUint32(buf, 0)
+}
+
+func (msgpackFormat) closeMap(buf *buffer.Buffer, offset, pairs int) {
+	binary.BigEndian.PutUint32(buf.Bytes()[offset+1:], uint32(pairs))
+}
+
+func (msgpackFormat) openArray(buf *buffer.Buffer) {
+	buf.AppendByte(_msgpackArray32)
+	appendUint32(buf, 0)
+}
+
+func (msgpackFormat) closeArray(buf *buffer.Buffer, offset, elems int) {
+	binary.BigEndian.PutUint32(buf.Bytes()[offset+1:], uint32(elems))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/buffer"
+)
+
+func TestMsgpackFormat(t *testing.T) {
+	var f msgpackFormat
+	tests := []struct {
+		desc   string
+		append func(*buffer.Buffer)
+		want   string
+	}{
+		{"0", func(b *buffer.Buffer) { f.appendInt(b, 0) }, "00"},
+		{"positive fixint", func(b *buffer.Buffer) { f.appendInt(b, 127) }, "7f"},
+		{"uint8", func(b *buffer.Buffer) { f.appendUint(b, 128) }, "cc80"},
+		{"uint16", func(b *buffer.Buffer) { f.appendUint(b, 256) }, "cd0100"},
+		{"uint32", func(b *buffer.Buffer) { f.appendInt(b, 65536) }, "ce00010000"},
+		{"uint64", func(b *buffer.Buffer) { f.appendUint(b, 1<<32) }, "cf0000000100000000"},
+		{"negative fixint", func(b *buffer.Buffer) { f.appendInt(b, -32) }, "e0"},
+		{"int8", func(b *buffer.Buffer) { f.appendInt(b, -33) }, "d0df"},
+		{"int16", func(b *buffer.Buffer) { f.appendInt(b, -129) }, "d1ff7f"},
+		{"int32", func(b *buffer.Buffer) { f.appendInt(b, -32769) }, "d2ffff7fff"},
+		{"int64", func(b *buffer.Buffer) { f.appendInt(b, math.MinInt64) }, "d38000000000000000"},
+		{"float32", func(b *buffer.Buffer) { f.appendFloat32(b, 1.5) }, "ca3fc00000"},
+		{"float64", func(b *buffer.Buffer) { f.appendFloat64(b, 1.1) }, "cb3ff199999999999a"},
+		{"nil", func(b *buffer.Buffer) { f.appendNil(b) }, "c0"},
+		{"false", func(b *buffer.Buffer) { f.appendBool(b, false) }, "c2"},
+		{"true", func(b *buffer.Buffer) { f.appendBool(b, true) }, "c3"},
+		{"fixstr", func(b *buffer.Buffer) { f.appendString(b, "a") }, "a161"},
+		{"str8", func(b *buffer.Buffer) { f.appendString(b, strings.Repeat("a", 32)) }, "d920" + strings.Repeat("61", 32)},
+		{"invalid string", func(b *buffer.Buffer) { f.appendString(b, "\xff") }, "a3efbfbd"},
+		{"byte string", func(b *buffer.Buffer) { f.appendByteString(b, []byte("a")) }, "a161"},
+		{"bin8", func(b *buffer.Buffer) { f.appendBinary(b, []byte{1, 2}) }, "c4020102"},
+		{"timestamp32", func(b *buffer.Buffer) { f.appendTime(b, time.Unix(1, 0)) }, "d6ff00000001"},
+		{"timestamp64", func(b *buffer.Buffer) { f.appendTime(b, time.Unix(1, 5)) }, "d7ff0000001400000001"},
+		{"timestamp96", func(b *buffer.Buffer) { f.appendTime(b, time.Unix(-1, 0)) }, "c70cff00000000ffffffffffffffff"},
+		{"duration", func(b *buffer.Buffer) { f.appendDuration(b, time.Second) }, "d701000000003b9aca00"},
+		{
+			"map",
+			func(b *buffer.Buffer) {
+				f.openMap(b)
+				f.appendString(b, "a")
+				f.openArray(b)
+				f.appendInt(b, 1)
+				f.closeArray(b, 7, 1)
+				f.closeMap(b, 0, 1)
+			},
+			"df00000001a161dd0000000101",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, appendHex(tt.append), "Unexpected MessagePack encoding.")
+		})
+	}
+}
+
+func TestMsgpackDecoder(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want interface{}
+	}{
+		{"fixmap", "81a16181a16201", map[string]interface{}{"b": int64(1)}},
+		{"map16", "81a161de0001a16201", map[string]interface{}{"b": int64(1)}},
+		{"fixarray", "81a161920102", []interface{}{int64(1), int64(2)}},
+		{"array16", "81a161dc0001c3", []interface{}{true}},
+		{"str16", "81a161da0001" + "62", "b"},
+		{"bin16", "81a161c5000101", []byte{1}},
+		{"int8", "81a161d0ff", int64(-1)},
+		{"uint64", "81a161cfffffffffffffffff", uint64(math.MaxUint64)},
+		{"float32", "81a161ca3fc00000", float32(1.5)},
+		{"unknown fixext", "81a161d40501", []byte{1}},
+		{"unknown ext16", "81a161c800010501", []byte{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			require.NoError(t, err, "Invalid test input.")
+			got, err := NewMsgpackDecoder(bytes.NewReader(in)).Decode()
+			require.NoError(t, err, "Unexpected decoding error.")
+			assert.Equal(t, tt.want, got["a"], "Unexpected decoded value.")
+		})
+	}
+}
+
+func TestMsgpackDecoderErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{"truncated", "81a161", "msgpack: unexpected EOF"},
+		{"truncated string", "81a161db00010000", "msgpack: unexpected EOF"},
+		{"not a map", "01", "msgpack: expected a map, got int64"},
+		{"integer key", "810101", "msgpack: expected a string key, got int64"},
+		{"invalid format byte", "81a161c1", "msgpack: invalid format byte 0xc1"},
+		{"too deep", "81a161" + strings.Repeat("91", _maxDecodeDepth+1) + "00", "msgpack: maximum nesting depth exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			require.NoError(t, err, "Invalid test input.")
+			_, err = NewMsgpackDecoder(bytes.NewReader(in)).Decode()
+			assert.EqualError(t, err, tt.want, "Unexpected decoding error.")
+		})
+	}
+
+	_, err := NewMsgpackDecoder(bytes.NewReader(nil)).Decode()
+	assert.Equal(t, io.EOF, err, "Expected io.EOF from empty input.")
+}
@@ -0,0 +1,755 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+// Defaults for the keys of OTLPConfig.
+const (
+	DefaultOTLPTraceIDKey = "trace_id"
+	DefaultOTLPSpanIDKey  = "span_id"
+)
+
+// Attribute names from the OpenTelemetry semantic conventions.
+const (
+	_otlpFilepathAttr   = "code.filepath"
+	_otlpLinenoAttr     = "code.lineno"
+	_otlpFunctionAttr   = "code.function"
+	_otlpStacktraceAttr = "exception.stacktrace"
+)
+
+// OTLPConfig holds the OpenTelemetry-specific options of an OTLP encoder.
+type OTLPConfig struct {
+	// Resource holds attributes describing the entity producing the logs,
+	// like service.name, which are added to every record's resource.
+	Resource []Field
+	// TraceIDKey and SpanIDKey name the string fields holding the
+	// hex-encoded IDs of the trace and span an entry belongs to. Top-level
+	// fields with valid IDs set the record's traceId and spanId instead of
+	// being added as attributes. They default to DefaultOTLPTraceIDKey and
+	// DefaultOTLPSpanIDKey.
+	TraceIDKey string
+	SpanIDKey  string
+}
+
+// otlpResource is an OTLPConfig prepared for encoding.
+type otlpResource struct {
+	attributes []byte
+	traceIDKey string
+	spanIDKey  string
+}
+
+// OTLPSeverity returns the OpenTelemetry severity number for a level,
+// following the mapping of the OpenTelemetry zap bridge. Levels outside of
+// zap's range are unspecified (zero).
+func OTLPSeverity(l Level) int {
+	switch l {
+	case DebugLevel:
+		return 5 // DEBUG
+	case InfoLevel:
+		return 9 // INFO
+	case WarnLevel:
+		return 13 // WARN
+	case ErrorLevel:
+		return 17 // ERROR
+	case DPanicLevel:
+		return 21 // FATAL
+	case PanicLevel:
+		return 22 // FATAL2
+	case FatalLevel:
+		return 23 // FATAL3
+	}
+	return 0
+}
+
+var _otlpPool = pool.New(func() *otlpEncoder {
+	return &otlpEncoder{}
+})
+
+func putOTLPEncoder(enc *otlpEncoder) {
+	if enc.reflectBuf != nil {
+		enc.reflectBuf.Free()
+	}
+	enc.EncoderConfig = nil
+	enc.resource = nil
+	enc.buf = nil
+	enc.depth = 0
+	enc.openNamespaces = 0
+	enc.traceID = ""
+	enc.spanID = ""
+	enc.reflectBuf = nil
+	enc.reflectEnc = nil
+	_otlpPool.Put(enc)
+}
+
+type otlpEncoder struct {
+	*EncoderConfig
+	resource *otlpResource
+
+	// buf holds the record's attributes, as KeyValue objects.
+	buf            *buffer.Buffer
+	depth          int // open arrays and objects
+	openNamespaces int
+
+	// IDs lifted from the logger's context or the entry's fields.
+	traceID, spanID string
+
+	// for encoding generic values by reflection
+	reflectBuf *buffer.Buffer
+	reflectEnc ReflectedEncoder
+}
+
+// NewOTLPEncoder creates an encoder whose output is OpenTelemetry log
+// records in the OTLP/JSON format. Each entry is written as a complete
+// ExportLogsServiceRequest on its own line, which is the format read by the
+// OpenTelemetry Collector's file receivers.
+//
+// Entries map onto the log data model as follows:
+//
+//   - the entry's time is the record's timeUnixNano
+//   - its level sets severityNumber, as OTLPSeverity, and severityText, as
+//     the level's capitalized name
+//   - its message is the body
+//   - its logger name is the name of the instrumentation scope
+//   - its caller and stack trace are the code.filepath, code.lineno,
+//     code.function, and exception.stacktrace attributes
+//   - fields are attributes, with nested objects and arrays encoded as
+//     kvlistValue and arrayValue
+//
+// The record's structure is fixed, so the keys of the EncoderConfig are only
+// checked for emptiness: as in other encoders, an empty key omits that
+// portion of the entry. Times and durations in attributes are formatted with
+// EncodeTime and EncodeDuration, or written as nanoseconds if those aren't
+// set. Unsigned integers too large for intValue are written as strings.
+func NewOTLPEncoder(cfg EncoderConfig, otlpCfg OTLPConfig) Encoder {
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+	if cfg.SkipLineEnding {
+		cfg.LineEnding = ""
+	} else if cfg.LineEnding == "" {
+		cfg.LineEnding = DefaultLineEnding
+	}
+
+	res := &otlpResource{
+		traceIDKey: otlpCfg.TraceIDKey,
+		spanIDKey:  otlpCfg.SpanIDKey,
+	}
+	if res.traceIDKey == "" {
+		res.traceIDKey = DefaultOTLPTraceIDKey
+	}
+	if res.spanIDKey == "" {
+		res.spanIDKey = DefaultOTLPSpanIDKey
+	}
+
+	// Resource attributes are the same for every record, so encode them
+	// once. Without ID keys, no fields are lifted out of them.
+	enc := &otlpEncoder{
+		EncoderConfig: &cfg,
+		resource:      &otlpResource{},
+		buf:           bufferpool.Get(),
+	}
+	addFields(enc, otlpCfg.Resource)
+	enc.closeOpenNamespaces()
+	res.attributes = append([]byte(nil), enc.buf.Bytes()...)
+	enc.buf.Reset()
+
+	enc.resource = res
+	return enc
+}
+
+func (enc *otlpEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	err := enc.appendArray(arr)
+	enc.buf.AppendByte('}')
+	return err
+}
+
+func (enc *otlpEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	enc.addKey(key)
+	err := enc.appendObject(obj)
+	enc.buf.AppendByte('}')
+	return err
+}
+
+func (enc *otlpEncoder) AddBinary(key string, val []byte) {
+	enc.addKey(key)
+	enc.buf.AppendString(`{"bytesValue":"`)
+	enc.buf.AppendString(base64.StdEncoding.EncodeToString(val))
+	enc.buf.AppendString(`"}}`)
+}
+
+func (enc *otlpEncoder) AddByteString(key string, val []byte) {
+	if enc.liftID(key, string(val)) {
+		return
+	}
+	enc.addKey(key)
+	enc.appendByteString(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.appendBool(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.appendComplex(val, 64)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.appendComplex(complex128(val), 32)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.appendFloat(val, 64)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.appendFloat(float64(val), 32)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.appendInt(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddReflected(key string, obj interface{}) error {
+	valueBytes, err := enc.encodeReflected(obj)
+	if err != nil {
+		return err
+	}
+	enc.addKey(key)
+	enc.appendReflected(valueBytes)
+	enc.buf.AppendByte('}')
+	return nil
+}
+
+func (enc *otlpEncoder) OpenNamespace(key string) {
+	enc.addKey(key)
+	enc.buf.AppendString(`{"kvlistValue":{"values":[`)
+	enc.openNamespaces++
+}
+
+func (enc *otlpEncoder) AddString(key, val string) {
+	if enc.liftID(key, val) {
+		return
+	}
+	enc.addKey(key)
+	enc.appendString(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.appendUint(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) AppendArray(arr ArrayMarshaler) error {
+	enc.addElementSeparator()
+	return enc.appendArray(arr)
+}
+
+func (enc *otlpEncoder) AppendObject(obj ObjectMarshaler) error {
+	enc.addElementSeparator()
+	return enc.appendObject(obj)
+}
+
+func (enc *otlpEncoder) AppendBool(val bool) {
+	enc.addElementSeparator()
+	enc.appendBool(val)
+}
+
+func (enc *otlpEncoder) AppendByteString(val []byte) {
+	enc.addElementSeparator()
+	enc.appendByteString(val)
+}
+
+func (enc *otlpEncoder) AppendComplex128(val complex128) {
+	enc.addElementSeparator()
+	enc.appendComplex(val, 64)
+}
+
+func (enc *otlpEncoder) AppendComplex64(val complex64) {
+	enc.addElementSeparator()
+	enc.appendComplex(complex128(val), 32)
+}
+
+func (enc *otlpEncoder) AppendDuration(val time.Duration) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeDuration; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeDuration is a no-op. Fall back to nanoseconds to keep
+		// JSON valid.
+		enc.AppendInt64(int64(val))
+	}
+}
+
+func (enc *otlpEncoder) AppendFloat64(val float64) {
+	enc.addElementSeparator()
+	enc.appendFloat(val, 64)
+}
+
+func (enc *otlpEncoder) AppendFloat32(val float32) {
+	enc.addElementSeparator()
+	enc.appendFloat(float64(val), 32)
+}
+
+func (enc *otlpEncoder) AppendInt64(val int64) {
+	enc.addElementSeparator()
+	enc.appendInt(val)
+}
+
+func (enc *otlpEncoder) AppendReflected(val interface{}) error {
+	valueBytes, err := enc.encodeReflected(val)
+	if err != nil {
+		return err
+	}
+	enc.addElementSeparator()
+	enc.appendReflected(valueBytes)
+	return nil
+}
+
+func (enc *otlpEncoder) AppendString(val string) {
+	enc.addElementSeparator()
+	enc.appendString(val)
+}
+
+func (enc *otlpEncoder) AppendTime(val time.Time) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeTime; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeTime is a no-op. Fall back to nanos since epoch to keep
+		// output JSON valid.
+		enc.AppendInt64(val.UnixNano())
+	}
+}
+
+func (enc *otlpEncoder) AppendUint64(val uint64) {
+	enc.addElementSeparator()
+	enc.appendUint(val)
+}
+
+func (enc *otlpEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *otlpEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *otlpEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *otlpEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *otlpEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *otlpEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *otlpEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *otlpEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *otlpEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *otlpEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *otlpEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *otlpEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *otlpEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *otlpEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *otlpEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *otlpEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *otlpEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *otlpEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+func (enc *otlpEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	clone.openNamespaces = enc.openNamespaces
+	clone.traceID = enc.traceID
+	clone.spanID = enc.spanID
+	return clone
+}
+
+func (enc *otlpEncoder) clone() *otlpEncoder {
+	clone := _otlpPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.resource = enc.resource
+	clone.buf = bufferpool.Get()
+	return clone
+}
+
+func (enc *otlpEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	// Encode the attributes first, since fields may set the trace and span
+	// IDs.
+	attrs := enc.clone()
+	attrs.traceID = enc.traceID
+	attrs.spanID = enc.spanID
+	if ent.Caller.Defined {
+		if attrs.CallerKey != "" {
+			attrs.AddString(_otlpFilepathAttr, ent.Caller.File)
+			attrs.AddInt(_otlpLinenoAttr, ent.Caller.Line)
+		}
+		if attrs.FunctionKey != "" && ent.Caller.Function != "" {
+			attrs.AddString(_otlpFunctionAttr, ent.Caller.Function)
+		}
+	}
+	if enc.buf.Len() > 0 {
+		attrs.addElementSeparator()
+		attrs.buf.Write(enc.buf.Bytes())
+		attrs.openNamespaces = enc.openNamespaces
+	}
+	addFields(attrs, fields)
+	attrs.closeOpenNamespaces()
+	if ent.Stack != "" && attrs.StacktraceKey != "" {
+		attrs.AddString(_otlpStacktraceAttr, ent.Stack)
+	}
+
+	line := bufferpool.Get()
+	line.AppendString(`{"resourceLogs":[{"resource":{"attributes":[`)
+	line.Write(enc.resource.attributes)
+	line.AppendString(`]},"scopeLogs":[{"scope":{`)
+	if ent.LoggerName != "" && enc.NameKey != "" {
+		line.AppendString(`"name":"`)
+		safeAppendStringLike((*buffer.Buffer).AppendString, utf8.DecodeRuneInString, line, ent.LoggerName)
+		line.AppendByte('"')
+	}
+	line.AppendString(`},"logRecords":[{`)
+	if enc.TimeKey != "" && !ent.Time.IsZero() {
+		line.AppendString(`"timeUnixNano":"`)
+		line.AppendInt(ent.Time.UnixNano())
+		line.AppendString(`",`)
+	}
+	line.AppendString(`"severityNumber":`)
+	line.AppendInt(int64(OTLPSeverity(ent.Level)))
+	line.AppendString(`,"severityText":"`)
+	line.AppendString(ent.Level.CapitalString())
+	line.AppendByte('"')
+	if enc.MessageKey != "" {
+		line.AppendString(`,"body":`)
+		appendOTLPString(line, ent.Message)
+	}
+	line.AppendString(`,"attributes":[`)
+	line.Write(attrs.buf.Bytes())
+	line.AppendByte(']')
+	if attrs.traceID != "" {
+		line.AppendString(`,"traceId":"`)
+		line.AppendString(attrs.traceID)
+		line.AppendByte('"')
+	}
+	if attrs.spanID != "" {
+		line.AppendString(`,"spanId":"`)
+		line.AppendString(attrs.spanID)
+		line.AppendByte('"')
+	}
+	line.AppendString(`}]}]}]}`)
+	line.AppendString(enc.LineEnding)
+
+	attrs.buf.Free()
+	putOTLPEncoder(attrs)
+	return line, nil
+}
+
+// liftID records a top-level trace or span ID, reporting whether it did.
+func (enc *otlpEncoder) liftID(key, val string) bool {
+	if enc.depth > 0 || enc.openNamespaces > 0 || enc.resource.traceIDKey == "" {
+		// Nested, or encoding the resource.
+		return false
+	}
+	switch {
+	case key == enc.resource.traceIDKey && isOTLPID(val, 32):
+		enc.traceID = strings.ToLower(val)
+	case key == enc.resource.spanIDKey && isOTLPID(val, 16):
+		enc.spanID = strings.ToLower(val)
+	default:
+		return false
+	}
+	return true
+}
+
+// isOTLPID reports whether s is a valid, non-zero hex-encoded ID of the given
+// length.
+func isOTLPID(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	zero := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '0':
+		case '1' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+			zero = false
+		default:
+			return false
+		}
+	}
+	return !zero
+}
+
+func (enc *otlpEncoder) closeOpenNamespaces() {
+	for i := 0; i < enc.openNamespaces; i++ {
+		enc.buf.AppendString(`]}}}`)
+	}
+	enc.openNamespaces = 0
+}
+
+// addKey opens a KeyValue object. Callers write its value and then close
+// it.
+func (enc *otlpEncoder) addKey(key string) {
+	enc.addElementSeparator()
+	enc.buf.AppendString(`{"key":"`)
+	safeAppendStringLike((*buffer.Buffer).AppendString, utf8.DecodeRuneInString, enc.buf, key)
+	enc.buf.AppendString(`","value":`)
+}
+
+func (enc *otlpEncoder) addElementSeparator() {
+	last := enc.buf.Len() - 1
+	if last < 0 {
+		return
+	}
+	switch enc.buf.Bytes()[last] {
+	case '[', ':':
+		return
+	default:
+		enc.buf.AppendByte(',')
+	}
+}
+
+func (enc *otlpEncoder) appendArray(arr ArrayMarshaler) error {
+	enc.depth++
+	enc.buf.AppendString(`{"arrayValue":{"values":[`)
+	err := arr.MarshalLogArray(enc)
+	enc.buf.AppendString(`]}}`)
+	enc.depth--
+	return err
+}
+
+func (enc *otlpEncoder) appendObject(obj ObjectMarshaler) error {
+	// Close ONLY new openNamespaces that are created during
+	// AppendObject().
+	old := enc.openNamespaces
+	enc.openNamespaces = 0
+	enc.depth++
+	enc.buf.AppendString(`{"kvlistValue":{"values":[`)
+	err := obj.MarshalLogObject(enc)
+	enc.closeOpenNamespaces()
+	enc.buf.AppendString(`]}}`)
+	enc.depth--
+	enc.openNamespaces = old
+	return err
+}
+
+func (enc *otlpEncoder) appendBool(val bool) {
+	enc.buf.AppendString(`{"boolValue":`)
+	enc.buf.AppendBool(val)
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) appendString(val string) {
+	appendOTLPString(enc.buf, val)
+}
+
+func appendOTLPString(buf *buffer.Buffer, val string) {
+	buf.AppendString(`{"stringValue":"`)
+	safeAppendStringLike((*buffer.Buffer).AppendString, utf8.DecodeRuneInString, buf, val)
+	buf.AppendString(`"}`)
+}
+
+func (enc *otlpEncoder) appendByteString(val []byte) {
+	enc.buf.AppendString(`{"stringValue":"`)
+	safeAppendStringLike((*buffer.Buffer).AppendBytes, utf8.DecodeRune, enc.buf, val)
+	enc.buf.AppendString(`"}`)
+}
+
+// The OTLP/JSON encoding of 64-bit integers is a decimal string.
+func (enc *otlpEncoder) appendInt(val int64) {
+	enc.buf.AppendString(`{"intValue":"`)
+	enc.buf.AppendInt(val)
+	enc.buf.AppendString(`"}`)
+}
+
+func (enc *otlpEncoder) appendUint(val uint64) {
+	if val > math.MaxInt64 {
+		enc.buf.AppendString(`{"stringValue":"`)
+		enc.buf.AppendUint(val)
+		enc.buf.AppendString(`"}`)
+		return
+	}
+	enc.appendInt(int64(val))
+}
+
+// The OTLP/JSON encoding of non-finite doubles is a string.
+func (enc *otlpEncoder) appendFloat(val float64, bitSize int) {
+	enc.buf.AppendString(`{"doubleValue":`)
+	switch {
+	case math.IsNaN(val):
+		enc.buf.AppendString(`"NaN"`)
+	case math.IsInf(val, 1):
+		enc.buf.AppendString(`"Infinity"`)
+	case math.IsInf(val, -1):
+		enc.buf.AppendString(`"-Infinity"`)
+	default:
+		enc.buf.AppendFloat(val, bitSize)
+	}
+	enc.buf.AppendByte('}')
+}
+
+func (enc *otlpEncoder) appendComplex(val complex128, precision int) {
+	r, i := real(val), imag(val)
+	enc.buf.AppendString(`{"stringValue":"`)
+	enc.buf.AppendFloat(r, precision)
+	if i >= 0 {
+		enc.buf.AppendByte('+')
+	}
+	enc.buf.AppendFloat(i, precision)
+	enc.buf.AppendString(`i"}`)
+}
+
+// encodeReflected encodes obj with the configured ReflectedEncoder into
+// enc.reflectBuf, so that nothing is written to the entry if encoding fails.
+// It returns nil for a nil obj.
+func (enc *otlpEncoder) encodeReflected(obj interface{}) ([]byte, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	if enc.reflectBuf == nil {
+		enc.reflectBuf = bufferpool.Get()
+		enc.reflectEnc = enc.NewReflectedEncoder(enc.reflectBuf)
+	} else {
+		enc.reflectBuf.Reset()
+	}
+	if err := enc.reflectEnc.Encode(obj); err != nil {
+		return nil, err
+	}
+	enc.reflectBuf.TrimNewline()
+	return enc.reflectBuf.Bytes(), nil
+}
+
+// appendReflected translates the output of encodeReflected into an
+// AnyValue if it's valid JSON. Anything else is added as a string.
+func (enc *otlpEncoder) appendReflected(data []byte) {
+	switch {
+	case data == nil:
+		enc.buf.AppendString(`{}`)
+	case !json.Valid(data):
+		enc.appendByteString(data)
+	default:
+		enc.appendJSON(data)
+	}
+}
+
+// appendJSON translates a valid JSON value into an AnyValue.
+func (enc *otlpEncoder) appendJSON(data []byte) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// For each open JSON container, whether it's an object, and whether its
+	// next token is a key.
+	type container struct{ object, key bool }
+	var open []container
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			// data is valid, so this is io.EOF.
+			return
+		}
+
+		var top *container
+		if n := len(open); n > 0 {
+			top = &open[n-1]
+		}
+		if top != nil && top.key {
+			if key, ok := tok.(string); ok {
+				top.key = false
+				enc.addKey(key)
+				continue
+			}
+		}
+		if top != nil && !top.object && tok != json.Delim(']') {
+			enc.addElementSeparator()
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{':
+				enc.buf.AppendString(`{"kvlistValue":{"values":[`)
+				open = append(open, container{object: true, key: true})
+				continue
+			case '[':
+				enc.buf.AppendString(`{"arrayValue":{"values":[`)
+				open = append(open, container{})
+				continue
+			}
+			enc.buf.AppendString(`]}}`)
+			open = open[:len(open)-1]
+		case string:
+			enc.appendString(v)
+		case json.Number:
+			if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+				enc.appendInt(i)
+			} else if f, err := v.Float64(); err == nil {
+				enc.appendFloat(f, 64)
+			} else {
+				enc.appendString(string(v))
+			}
+		case bool:
+			enc.appendBool(v)
+		case nil:
+			enc.buf.AppendString(`{}`)
+		}
+
+		// A value completed in the enclosing object closes its KeyValue,
+		// and a key follows.
+		if n := len(open); n > 0 && open[n-1].object {
+			enc.buf.AppendByte('}')
+			open[n-1].key = true
+		}
+	}
+}
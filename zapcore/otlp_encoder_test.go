@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+const (
+	_testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	_testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestOTLPEncodeEntry(t *testing.T) {
+	enc := NewOTLPEncoder(testEncoderConfig(), OTLPConfig{
+		Resource: []Field{
+			zap.String("service.name", "checkout"),
+			zap.Int("service.instance", 3),
+		},
+	})
+	enc.AddString("trace_id", _testTraceID)
+	enc.AddString("ctx", "with")
+
+	tests := []struct {
+		desc     string
+		ent      Entry
+		fields   []Field
+		expected string
+	}{
+		{
+			desc: "entry metadata",
+			ent:  testEntry,
+			expected: `{"resourceLogs":[{
+				"resource":{"attributes":[
+					{"key":"service.name","value":{"stringValue":"checkout"}},
+					{"key":"service.instance","value":{"intValue":"3"}}
+				]},
+				"scopeLogs":[{
+					"scope":{"name":"main"},
+					"logRecords":[{
+						"timeUnixNano":"0",
+						"severityNumber":9,
+						"severityText":"INFO",
+						"body":{"stringValue":"hello"},
+						"attributes":[
+							{"key":"code.filepath","value":{"stringValue":"foo.go"}},
+							{"key":"code.lineno","value":{"intValue":"42"}},
+							{"key":"code.function","value":{"stringValue":"foo.Foo"}},
+							{"key":"ctx","value":{"stringValue":"with"}},
+							{"key":"exception.stacktrace","value":{"stringValue":"fake-stack"}}
+						],
+						"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"
+					}]
+				}]
+			}]}`,
+		},
+		{
+			desc: "typed attributes",
+			ent:  Entry{Level: ErrorLevel, Message: "typed"},
+			fields: []Field{
+				zap.String("span_id", _testSpanID),
+				zap.Bool("bool", true),
+				zap.Int64("int", -1),
+				zap.Uint64("big", math.MaxUint64),
+				zap.Float64("float", 1.5),
+				zap.Float64("nan", math.NaN()),
+				zap.Float64("inf", math.Inf(-1)),
+				zap.Complex128("complex", 1-2i),
+				zap.Binary("binary", []byte("hi")),
+				zap.ByteString("bytes", []byte("b\"s")),
+				zap.Duration("duration", time.Second),
+				zap.Time("time", time.Unix(1, 0)),
+				zap.Strings("strings", []string{"a", "b"}),
+				zap.Object("obj", binaryPoint{1, 2}),
+				zap.Reflect("reflected", map[string]interface{}{
+					"a": []interface{}{1, 1.5, nil, map[string]bool{"b": true}},
+				}),
+				zap.Reflect("nil", nil),
+				zap.Error(errors.New("boom")),
+				zap.Namespace("ns"),
+				zap.String("trace_id", "nested, so not lifted"),
+			},
+			expected: `{"resourceLogs":[{
+				"resource":{"attributes":[
+					{"key":"service.name","value":{"stringValue":"checkout"}},
+					{"key":"service.instance","value":{"intValue":"3"}}
+				]},
+				"scopeLogs":[{
+					"scope":{},
+					"logRecords":[{
+						"severityNumber":17,
+						"severityText":"ERROR",
+						"body":{"stringValue":"typed"},
+						"attributes":[
+							{"key":"ctx","value":{"stringValue":"with"}},
+							{"key":"bool","value":{"boolValue":true}},
+							{"key":"int","value":{"intValue":"-1"}},
+							{"key":"big","value":{"stringValue":"18446744073709551615"}},
+							{"key":"float","value":{"doubleValue":1.5}},
+							{"key":"nan","value":{"doubleValue":"NaN"}},
+							{"key":"inf","value":{"doubleValue":"-Infinity"}},
+							{"key":"complex","value":{"stringValue":"1-2i"}},
+							{"key":"binary","value":{"bytesValue":"aGk="}},
+							{"key":"bytes","value":{"stringValue":"b\"s"}},
+							{"key":"duration","value":{"doubleValue":1}},
+							{"key":"time","value":{"doubleValue":1}},
+							{"key":"strings","value":{"arrayValue":{"values":[
+								{"stringValue":"a"},
+								{"stringValue":"b"}
+							]}}},
+							{"key":"obj","value":{"kvlistValue":{"values":[
+								{"key":"x","value":{"intValue":"1"}},
+								{"key":"meta","value":{"kvlistValue":{"values":[
+									{"key":"y","value":{"intValue":"2"}}
+								]}}}
+							]}}},
+							{"key":"reflected","value":{"kvlistValue":{"values":[
+								{"key":"a","value":{"arrayValue":{"values":[
+									{"intValue":"1"},
+									{"doubleValue":1.5},
+									{},
+									{"kvlistValue":{"values":[
+										{"key":"b","value":{"boolValue":true}}
+									]}}
+								]}}}
+							]}}},
+							{"key":"nil","value":{}},
+							{"key":"error","value":{"stringValue":"boom"}},
+							{"key":"ns","value":{"kvlistValue":{"values":[
+								{"key":"trace_id","value":{"stringValue":"nested, so not lifted"}}
+							]}}}
+						],
+						"traceId":"4bf92f3577b34da6a3ce929d0e0e4736",
+						"spanId":"00f067aa0ba902b7"
+					}]
+				}]
+			}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err, "Unexpected OTLP encoding error.")
+			defer buf.Free()
+
+			assert.JSONEq(t, tt.expected, buf.String(), "Incorrect encoded OTLP record.")
+			assert.Equal(t, byte('\n'), buf.Bytes()[buf.Len()-1], "Expected a line ending.")
+		})
+	}
+}
+
+func TestOTLPEncoderInvalidIDs(t *testing.T) {
+	enc := NewOTLPEncoder(EncoderConfig{}, OTLPConfig{
+		TraceIDKey: "trace",
+		SpanIDKey:  "span",
+	})
+	buf, err := enc.EncodeEntry(Entry{}, []Field{
+		zap.String("trace", "00000000000000000000000000000000"),
+		zap.String("span", "not hex!"),
+		zap.String("trace_id", _testTraceID),
+	})
+	require.NoError(t, err, "Unexpected OTLP encoding error.")
+	defer buf.Free()
+
+	assert.JSONEq(t, `{"resourceLogs":[{
+		"resource":{"attributes":[]},
+		"scopeLogs":[{"scope":{},"logRecords":[{
+			"severityNumber":9,
+			"severityText":"INFO",
+			"attributes":[
+				{"key":"trace","value":{"stringValue":"00000000000000000000000000000000"}},
+				{"key":"span","value":{"stringValue":"not hex!"}},
+				{"key":"trace_id","value":{"stringValue":"4bf92f3577b34da6a3ce929d0e0e4736"}}
+			]
+		}]}]
+	}]}`, buf.String(), "Expected invalid IDs, and IDs under other keys, to be attributes.")
+}
+
+func TestOTLPEncoderReflectedError(t *testing.T) {
+	enc := NewOTLPEncoder(EncoderConfig{
+		NewReflectedEncoder: func(io.Writer) ReflectedEncoder {
+			return failingReflectedEncoder{}
+		},
+	}, OTLPConfig{})
+	failing := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		if err := enc.AppendReflected(42); err != nil {
+			enc.AppendString(err.Error())
+		}
+		return nil
+	})
+
+	buf, err := enc.EncodeEntry(Entry{}, []Field{
+		zap.Reflect("data", 42),
+		zap.Array("arr", failing),
+	})
+	require.NoError(t, err, "Unexpected OTLP encoding error.")
+	defer buf.Free()
+
+	assert.JSONEq(t, `{"resourceLogs":[{
+		"resource":{"attributes":[]},
+		"scopeLogs":[{"scope":{},"logRecords":[{
+			"severityNumber":9,
+			"severityText":"INFO",
+			"attributes":[
+				{"key":"dataError","value":{"stringValue":"can't reflect"}},
+				{"key":"arr","value":{"arrayValue":{"values":[{"stringValue":"can't reflect"}]}}}
+			]
+		}]}]
+	}]}`, buf.String(), "Expected failed reflection to add nothing but the error.")
+}
+
+func TestOTLPSeverity(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  int
+	}{
+		{DebugLevel, 5},
+		{InfoLevel, 9},
+		{WarnLevel, 13},
+		{ErrorLevel, 17},
+		{DPanicLevel, 21},
+		{PanicLevel, 22},
+		{FatalLevel, 23},
+		{InvalidLevel, 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, OTLPSeverity(tt.level), "Unexpected severity for %v.", tt.level)
+	}
+}
+
+func TestOTLPEncoderSkipLineEnding(t *testing.T) {
+	enc := NewOTLPEncoder(EncoderConfig{SkipLineEnding: true}, OTLPConfig{})
+	buf, err := enc.EncodeEntry(Entry{}, nil)
+	require.NoError(t, err, "Unexpected OTLP encoding error.")
+	defer buf.Free()
+	assert.Equal(t, byte('}'), buf.Bytes()[buf.Len()-1], "Expected no line ending.")
+}
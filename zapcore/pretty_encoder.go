@@ -0,0 +1,674 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/color"
+)
+
+// ColorMode controls whether the pretty encoder colors its output.
+type ColorMode uint8
+
+const (
+	// ColorAuto colors output only when it's written to a terminal. Since
+	// encoders don't know where their output goes, the pretty encoder checks
+	// standard error; the Config type in package zap instead checks the
+	// logger's output paths. Either way, setting the NO_COLOR environment
+	// variable disables color.
+	ColorAuto ColorMode = iota
+	// ColorAlways always colors output.
+	ColorAlways
+	// ColorNever never colors output.
+	ColorNever
+)
+
+// String returns a lower-case ASCII representation of the mode.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAuto:
+		return "auto"
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	default:
+		return fmt.Sprintf("ColorMode(%d)", m)
+	}
+}
+
+// MarshalText marshals the ColorMode to text.
+func (m ColorMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText unmarshals text to a ColorMode. "auto" and the empty string
+// are unmarshaled to ColorAuto, "always" to ColorAlways, and "never" to
+// ColorNever.
+func (m *ColorMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "auto", "":
+		*m = ColorAuto
+	case "always":
+		*m = ColorAlways
+	case "never":
+		*m = ColorNever
+	default:
+		return fmt.Errorf("unrecognized color mode: %q", text)
+	}
+	return nil
+}
+
+type prettyKind uint8
+
+const (
+	prettyScalar prettyKind = iota
+	prettyObject
+	prettyArray
+)
+
+// prettyValue is a field value, held until the entry is rendered, since
+// nested objects are laid out after the entry's first line.
+type prettyValue struct {
+	kind   prettyKind
+	text   string // scalars, already formatted
+	err    bool   // part of an error field
+	fields []prettyField
+	elems  []prettyValue
+}
+
+type prettyField struct {
+	key   string
+	value prettyValue
+}
+
+// prettyFrame is an open object, array, or namespace.
+type prettyFrame struct {
+	key   string
+	value *prettyValue
+}
+
+type prettyEncoder struct {
+	*EncoderConfig
+	colored bool
+
+	// frames are the open objects and arrays, outermost first. The first is
+	// the entry's root object.
+	frames         []prettyFrame
+	key            string // pending key for the next value
+	openNamespaces int
+	errors         bool // adding the values of an error field
+
+	// for encoding generic values by reflection
+	reflectBuf *buffer.Buffer
+	reflectEnc ReflectedEncoder
+}
+
+// NewPrettyEncoder creates an encoder for reading logs in a terminal during
+// development. Like the console encoder, it writes the entry's metadata as
+// plain text, but it renders fields as key=value pairs, with nested objects
+// laid out as aligned, indented blocks beneath the entry. Stacktraces are
+// indented, with their paths trimmed as by EntryCaller.TrimmedPath.
+//
+// Output is colored according to the ConsoleColor setting: levels are
+// colored by severity, timestamps and callers are dimmed, keys are
+// highlighted, and fields added with Error or NamedError are red. Levels are
+// always written in capitals, so EncodeLevel is ignored. The message, logger
+// name, and caller are escaped according to ConsoleEscaping. Strings are quoted
+// when they're empty or hold spaces, quotes, equals signs, or unprintable
+// characters.
+func NewPrettyEncoder(cfg EncoderConfig) Encoder {
+	if cfg.SkipLineEnding {
+		cfg.LineEnding = ""
+	} else if cfg.LineEnding == "" {
+		cfg.LineEnding = DefaultLineEnding
+	}
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+
+	colored := cfg.ConsoleColor == ColorAlways
+	if cfg.ConsoleColor == ColorAuto {
+		colored = color.Enabled(os.Stderr)
+	}
+	return &prettyEncoder{
+		EncoderConfig: &cfg,
+		colored:       colored,
+		frames:        []prettyFrame{{value: &prettyValue{kind: prettyObject}}},
+	}
+}
+
+func (enc *prettyEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendArray(arr)
+}
+
+func (enc *prettyEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendObject(obj)
+}
+
+func (enc *prettyEncoder) AddBinary(key string, val []byte) {
+	enc.addKey(key)
+	enc.appendScalar(base64.StdEncoding.EncodeToString(val))
+}
+
+func (enc *prettyEncoder) AddByteString(key string, val []byte) {
+	enc.addKey(key)
+	enc.AppendByteString(val)
+}
+
+func (enc *prettyEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.AppendBool(val)
+}
+
+func (enc *prettyEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.AppendComplex128(val)
+}
+
+func (enc *prettyEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.AppendComplex64(val)
+}
+
+func (enc *prettyEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+}
+
+func (enc *prettyEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.AppendFloat64(val)
+}
+
+func (enc *prettyEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.AppendFloat32(val)
+}
+
+func (enc *prettyEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.AppendInt64(val)
+}
+
+func (enc *prettyEncoder) AddReflected(key string, obj interface{}) error {
+	enc.addKey(key)
+	return enc.AppendReflected(obj)
+}
+
+func (enc *prettyEncoder) OpenNamespace(key string) {
+	enc.addKey(key)
+	enc.openFrame(prettyObject)
+	enc.openNamespaces++
+}
+
+func (enc *prettyEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.AppendString(val)
+}
+
+func (enc *prettyEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+}
+
+func (enc *prettyEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.AppendUint64(val)
+}
+
+func (enc *prettyEncoder) AppendArray(arr ArrayMarshaler) error {
+	enc.openFrame(prettyArray)
+	err := arr.MarshalLogArray(enc)
+	enc.closeFrame()
+	return err
+}
+
+func (enc *prettyEncoder) AppendObject(obj ObjectMarshaler) error {
+	// Close ONLY new openNamespaces that are created during
+	// AppendObject().
+	old := enc.openNamespaces
+	enc.openNamespaces = 0
+	enc.openFrame(prettyObject)
+	err := obj.MarshalLogObject(enc)
+	enc.closeOpenNamespaces()
+	enc.closeFrame()
+	enc.openNamespaces = old
+	return err
+}
+
+func (enc *prettyEncoder) AppendBool(val bool) {
+	enc.appendScalar(strconv.FormatBool(val))
+}
+
+func (enc *prettyEncoder) AppendByteString(val []byte) {
+	enc.AppendString(string(val))
+}
+
+func (enc *prettyEncoder) AppendComplex128(val complex128) {
+	enc.appendComplex(val, 128)
+}
+
+func (enc *prettyEncoder) AppendComplex64(val complex64) {
+	enc.appendComplex(complex128(val), 64)
+}
+
+func (enc *prettyEncoder) appendComplex(val complex128, bitSize int) {
+	// Drop the parentheses that strconv adds.
+	s := strconv.FormatComplex(val, 'f', -1, bitSize)
+	enc.appendScalar(s[1 : len(s)-1])
+}
+
+func (enc *prettyEncoder) AppendDuration(val time.Duration) {
+	n := enc.valueCount()
+	if e := enc.EncodeDuration; e != nil {
+		e(val, enc)
+	}
+	if n == enc.valueCount() {
+		// User-supplied EncodeDuration is a no-op. Fall back to the
+		// duration's string form.
+		enc.appendScalar(val.String())
+	}
+}
+
+func (enc *prettyEncoder) AppendFloat64(val float64) {
+	enc.appendScalar(strconv.FormatFloat(val, 'f', -1, 64))
+}
+
+func (enc *prettyEncoder) AppendFloat32(val float32) {
+	enc.appendScalar(strconv.FormatFloat(float64(val), 'f', -1, 32))
+}
+
+func (enc *prettyEncoder) AppendInt64(val int64) {
+	enc.appendScalar(strconv.FormatInt(val, 10))
+}
+
+// AppendReflected adds the output of the configured ReflectedEncoder
+// verbatim.
+func (enc *prettyEncoder) AppendReflected(val interface{}) error {
+	if val == nil {
+		enc.appendScalar("null")
+		return nil
+	}
+
+	if enc.reflectBuf == nil {
+		enc.reflectBuf = bufferpool.Get()
+		enc.reflectEnc = enc.NewReflectedEncoder(enc.reflectBuf)
+	} else {
+		enc.reflectBuf.Reset()
+	}
+	if err := enc.reflectEnc.Encode(val); err != nil {
+		return err
+	}
+	enc.reflectBuf.TrimNewline()
+	enc.appendScalar(enc.reflectBuf.String())
+	return nil
+}
+
+func (enc *prettyEncoder) AppendString(val string) {
+	if needsPrettyQuotes(val) {
+		val = strconv.Quote(val)
+	}
+	enc.appendScalar(val)
+}
+
+func (enc *prettyEncoder) AppendTime(val time.Time) {
+	n := enc.valueCount()
+	if e := enc.EncodeTime; e != nil {
+		e(val, enc)
+	}
+	if n == enc.valueCount() {
+		// User-supplied EncodeTime is a no-op. Fall back to RFC 3339.
+		enc.appendScalar(val.Format(time.RFC3339Nano))
+	}
+}
+
+func (enc *prettyEncoder) AppendUint64(val uint64) {
+	enc.appendScalar(strconv.FormatUint(val, 10))
+}
+
+func (enc *prettyEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *prettyEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *prettyEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *prettyEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *prettyEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *prettyEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *prettyEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *prettyEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *prettyEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *prettyEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *prettyEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *prettyEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *prettyEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *prettyEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *prettyEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *prettyEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *prettyEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *prettyEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+// Clone copies the open objects, whose fields the clone may add to. Values
+// that are already complete are shared.
+func (enc *prettyEncoder) Clone() Encoder {
+	frames := make([]prettyFrame, len(enc.frames))
+	for i, f := range enc.frames {
+		v := *f.value
+		v.fields = v.fields[:len(v.fields):len(v.fields)]
+		v.elems = v.elems[:len(v.elems):len(v.elems)]
+		f.value = &v
+		frames[i] = f
+	}
+	return &prettyEncoder{
+		EncoderConfig:  enc.EncoderConfig,
+		colored:        enc.colored,
+		frames:         frames,
+		openNamespaces: enc.openNamespaces,
+	}
+}
+
+func (enc *prettyEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.Clone().(*prettyEncoder)
+	for _, f := range fields {
+		final.errors = f.Type == ErrorType
+		f.AddTo(final)
+	}
+	final.errors = false
+	final.closeOpenNamespaces()
+	root := final.frames[0].value
+	if final.reflectBuf != nil {
+		final.reflectBuf.Free()
+	}
+
+	line := bufferpool.Get()
+	arr := getSliceEncoder()
+	if final.TimeKey != "" && final.EncodeTime != nil && !ent.Time.IsZero() {
+		final.EncodeTime(ent.Time, arr)
+		final.writeMetadata(line, arr, color.Faint, false)
+	}
+	if final.LevelKey != "" {
+		level := ent.Level.CapitalString()
+		if pad := 5 - len(level); pad > 0 {
+			level += strings.Repeat(" ", pad)
+		}
+		c, ok := _levelToColor[ent.Level]
+		if !ok {
+			c = _unknownLevelColor
+		}
+		final.addSpace(line)
+		final.paint(line, c, level)
+	}
+	if ent.LoggerName != "" && final.NameKey != "" {
+		nameEncoder := final.EncodeName
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+		nameEncoder(ent.LoggerName, arr)
+		final.writeMetadata(line, arr, color.Bold, true)
+	}
+	if ent.Caller.Defined {
+		if final.CallerKey != "" && final.EncodeCaller != nil {
+			final.EncodeCaller(ent.Caller, arr)
+		}
+		if final.FunctionKey != "" {
+			arr.AppendString(ent.Caller.Function)
+		}
+		final.writeMetadata(line, arr, color.Faint, true)
+	}
+	putSliceEncoder(arr)
+	if final.MessageKey != "" {
+		final.addSpace(line)
+		final.ConsoleEscaping.appendEscaped(line, ent.Message)
+	}
+
+	// Scalars and arrays follow the message, and objects get blocks of their
+	// own beneath it.
+	sep := "  "
+	for _, f := range root.fields {
+		if f.value.isBlock() {
+			continue
+		}
+		line.AppendString(sep)
+		sep = " "
+		final.writeKey(line, f.key, f.value.err)
+		final.paint(line, color.Faint, "=")
+		final.writeInline(line, f.value)
+	}
+	for _, f := range root.fields {
+		if f.value.isBlock() {
+			final.writeBlock(line, f.key, f.value, 4)
+		}
+	}
+
+	if ent.Stack != "" && final.StacktraceKey != "" {
+		final.writeStack(line, ent.Stack)
+	}
+	line.AppendString(final.LineEnding)
+	return line, nil
+}
+
+func (v *prettyValue) isBlock() bool {
+	return v.kind == prettyObject && len(v.fields) > 0
+}
+
+func (enc *prettyEncoder) addSpace(line *buffer.Buffer) {
+	if line.Len() > 0 {
+		line.AppendByte(' ')
+	}
+}
+
+// writeMetadata writes and clears the elements of arr, escaping text that
+// may come from users.
+func (enc *prettyEncoder) writeMetadata(line *buffer.Buffer, arr *sliceArrayEncoder, c color.Color, escape bool) {
+	for _, e := range arr.elems {
+		s := fmt.Sprint(e)
+		if escape && enc.ConsoleEscaping != NoEscaping {
+			escaped := bufferpool.Get()
+			enc.ConsoleEscaping.appendEscaped(escaped, s)
+			s = escaped.String()
+			escaped.Free()
+		}
+		enc.addSpace(line)
+		enc.paint(line, c, s)
+	}
+	arr.elems = arr.elems[:0]
+}
+
+func (enc *prettyEncoder) paint(line *buffer.Buffer, c color.Color, s string) {
+	if !enc.colored {
+		line.AppendString(s)
+		return
+	}
+	line.AppendString("\x1b[")
+	line.AppendUint(uint64(c))
+	line.AppendByte('m')
+	line.AppendString(s)
+	line.AppendString("\x1b[0m")
+}
+
+func (enc *prettyEncoder) writeKey(line *buffer.Buffer, key string, isErr bool) {
+	if isErr {
+		enc.paint(line, color.Red, key)
+		return
+	}
+	enc.paint(line, color.Cyan, key)
+}
+
+func (enc *prettyEncoder) writeInline(line *buffer.Buffer, v prettyValue) {
+	switch v.kind {
+	case prettyScalar:
+		if v.err {
+			enc.paint(line, color.Red, v.text)
+		} else {
+			line.AppendString(v.text)
+		}
+	case prettyArray:
+		line.AppendByte('[')
+		for i, e := range v.elems {
+			if i > 0 {
+				line.AppendString(", ")
+			}
+			enc.writeInline(line, e)
+		}
+		line.AppendByte(']')
+	case prettyObject:
+		line.AppendByte('{')
+		for i, f := range v.fields {
+			if i > 0 {
+				line.AppendString(", ")
+			}
+			enc.writeKey(line, f.key, f.value.err)
+			enc.paint(line, color.Faint, "=")
+			enc.writeInline(line, f.value)
+		}
+		line.AppendByte('}')
+	}
+}
+
+// writeBlock writes an object on its own lines, aligning the values of its
+// members.
+func (enc *prettyEncoder) writeBlock(line *buffer.Buffer, key string, v prettyValue, indent int) {
+	line.AppendByte('\n')
+	line.AppendString(strings.Repeat(" ", indent))
+	enc.writeKey(line, key, v.err)
+	line.AppendByte(':')
+
+	width := 0
+	for _, f := range v.fields {
+		if n := utf8.RuneCountInString(f.key); !f.value.isBlock() && n > width {
+			width = n
+		}
+	}
+	for _, f := range v.fields {
+		if f.value.isBlock() {
+			enc.writeBlock(line, f.key, f.value, indent+2)
+			continue
+		}
+		line.AppendByte('\n')
+		line.AppendString(strings.Repeat(" ", indent+2))
+		enc.writeKey(line, f.key, f.value.err)
+		line.AppendByte(':')
+		line.AppendString(strings.Repeat(" ", width-utf8.RuneCountInString(f.key)+1))
+		enc.writeInline(line, f.value)
+	}
+}
+
+// writeStack indents a stacktrace, trimming the paths of its frames.
+func (enc *prettyEncoder) writeStack(line *buffer.Buffer, stack string) {
+	for _, l := range strings.Split(stack, "\n") {
+		line.AppendByte('\n')
+		if strings.HasPrefix(l, "\t") {
+			line.AppendString("        ")
+			enc.paint(line, color.Faint, trimPath(l[1:]))
+			continue
+		}
+		line.AppendString("    ")
+		line.AppendString(l)
+	}
+}
+
+// trimPath trims all but the final directory from a path, as
+// EntryCaller.TrimmedPath does.
+func trimPath(path string) string {
+	idx := strings.LastIndexByte(path, '/')
+	if idx == -1 {
+		return path
+	}
+	idx = strings.LastIndexByte(path[:idx], '/')
+	if idx == -1 {
+		return path
+	}
+	return path[idx+1:]
+}
+
+func needsPrettyQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == '"' || r == '=' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (enc *prettyEncoder) closeOpenNamespaces() {
+	for i := 0; i < enc.openNamespaces; i++ {
+		enc.closeFrame()
+	}
+	enc.openNamespaces = 0
+}
+
+func (enc *prettyEncoder) addKey(key string) {
+	enc.key = key
+}
+
+// valueCount counts the values in the innermost frame, to detect encoders
+// that add nothing.
+func (enc *prettyEncoder) valueCount() int {
+	v := enc.frames[len(enc.frames)-1].value
+	return len(v.fields) + len(v.elems)
+}
+
+func (enc *prettyEncoder) appendScalar(text string) {
+	enc.add(prettyValue{kind: prettyScalar, text: text, err: enc.errors})
+}
+
+// add adds a value to the innermost frame, under the pending key if it's an
+// object.
+func (enc *prettyEncoder) add(v prettyValue) {
+	enc.attach(enc.key, v)
+	enc.key = ""
+}
+
+func (enc *prettyEncoder) attach(key string, v prettyValue) {
+	parent := enc.frames[len(enc.frames)-1].value
+	if parent.kind == prettyArray {
+		parent.elems = append(parent.elems, v)
+		return
+	}
+	parent.fields = append(parent.fields, prettyField{key: key, value: v})
+}
+
+func (enc *prettyEncoder) openFrame(kind prettyKind) {
+	enc.frames = append(enc.frames, prettyFrame{
+		key:   enc.key,
+		value: &prettyValue{kind: kind, err: enc.errors},
+	})
+	enc.key = ""
+}
+
+func (enc *prettyEncoder) closeFrame() {
+	f := enc.frames[len(enc.frames)-1]
+	enc.frames = enc.frames[:len(enc.frames)-1]
+	enc.attach(f.key, *f.value)
+}
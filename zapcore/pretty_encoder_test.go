@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func prettyEncoderConfig(mode ColorMode) EncoderConfig {
+	cfg := testEncoderConfig()
+	cfg.EncodeTime = ISO8601TimeEncoder
+	cfg.EncodeDuration = StringDurationEncoder
+	cfg.ConsoleColor = mode
+	return cfg
+}
+
+func TestPrettyEncodeEntry(t *testing.T) {
+	enc := NewPrettyEncoder(prettyEncoderConfig(ColorNever))
+	enc.AddString("ctx", "with")
+
+	tests := []struct {
+		desc     string
+		ent      Entry
+		fields   []Field
+		expected string
+	}{
+		{
+			desc: "metadata",
+			ent: Entry{
+				Level:      WarnLevel,
+				Time:       time.Date(2018, 6, 19, 16, 33, 42, 0, time.UTC),
+				LoggerName: "main",
+				Message:    "hello",
+				Caller:     EntryCaller{Defined: true, File: "/src/app/foo.go", Line: 42, Function: "app.Foo"},
+			},
+			expected: "2018-06-19T16:33:42.000Z WARN  main app/foo.go:42 app.Foo hello  ctx=with\n",
+		},
+		{
+			desc: "scalars",
+			ent:  Entry{Level: InfoLevel, Message: "scalars"},
+			fields: []Field{
+				zap.String("plain", "text"),
+				zap.String("spaced", "two words"),
+				zap.String("empty", ""),
+				zap.String("control", "a\nb"),
+				zap.Int("int", -1),
+				zap.Float64("float", 1.5),
+				zap.Bool("bool", true),
+				zap.Duration("duration", 1500*time.Millisecond),
+				zap.Complex128("complex", 1+2i),
+				zap.Binary("binary", []byte("hi")),
+				zap.Ints("ints", []int{1, 2}),
+				zap.Reflect("reflected", map[string]int{"a": 1}),
+				zap.Object("empty_object", emptyObject{}),
+			},
+			expected: "INFO  scalars  ctx=with plain=text spaced=\"two words\" empty=\"\" control=\"a\\nb\" " +
+				"int=-1 float=1.5 bool=true duration=1.5s complex=1+2i binary=aGk= ints=[1, 2] " +
+				"reflected={\"a\":1} empty_object={}\n",
+		},
+		{
+			desc: "blocks",
+			ent:  Entry{Level: ErrorLevel, Message: "blocks", Stack: "app.Foo\n\t/src/app/foo.go:42\nmain.main\n\t/src/main.go:7"},
+			fields: []Field{
+				zap.Object("point", binaryPoint{1, 2}),
+				zap.Error(errors.New("boom")),
+				zap.Namespace("request"),
+				zap.String("method", "GET"),
+				zap.Int("status_code", 200),
+			},
+			expected: "ERROR blocks  ctx=with error=boom\n" +
+				"    point:\n" +
+				"      x: 1\n" +
+				"      meta:\n" +
+				"        y: 2\n" +
+				"    request:\n" +
+				"      method:      GET\n" +
+				"      status_code: 200\n" +
+				"    app.Foo\n" +
+				"        app/foo.go:42\n" +
+				"    main.main\n" +
+				"        src/main.go:7\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err, "Unexpected pretty encoding error.")
+			assert.Equal(t, tt.expected, buf.String(), "Incorrect encoded entry.")
+			buf.Free()
+		})
+	}
+}
+
+func TestPrettyEncoderColor(t *testing.T) {
+	enc := NewPrettyEncoder(prettyEncoderConfig(ColorAlways))
+	buf, err := enc.EncodeEntry(Entry{
+		Level:   ErrorLevel,
+		Time:    time.Date(2018, 6, 19, 16, 33, 42, 0, time.UTC),
+		Message: "hello",
+	}, []Field{zap.Int("a", 1), zap.Error(errors.New("boom"))})
+	require.NoError(t, err, "Unexpected pretty encoding error.")
+	defer buf.Free()
+
+	assert.Equal(t,
+		"\x1b[2m2018-06-19T16:33:42.000Z\x1b[0m \x1b[31mERROR\x1b[0m hello  "+
+			"\x1b[36ma\x1b[0m\x1b[2m=\x1b[0m1 "+
+			"\x1b[31merror\x1b[0m\x1b[2m=\x1b[0m\x1b[31mboom\x1b[0m\n",
+		buf.String(),
+		"Unexpected colored output.",
+	)
+}
+
+func TestPrettyEncoderClone(t *testing.T) {
+	enc := NewPrettyEncoder(prettyEncoderConfig(ColorNever))
+	enc.OpenNamespace("ns")
+	enc.AddInt("a", 1)
+
+	// Clones share the parent's context, but not each other's fields.
+	for _, key := range []string{"b", "c"} {
+		clone := enc.Clone()
+		clone.AddInt(key, 2)
+		buf, err := clone.EncodeEntry(Entry{Message: "msg"}, nil)
+		require.NoError(t, err, "Unexpected pretty encoding error.")
+		assert.Equal(t, "INFO  msg\n    ns:\n      a: 1\n      "+key+": 2\n", buf.String(), "Unexpected output from clone.")
+		buf.Free()
+	}
+}
+
+func TestColorModeText(t *testing.T) {
+	for _, mode := range []ColorMode{ColorAuto, ColorAlways, ColorNever} {
+		text, err := mode.MarshalText()
+		require.NoError(t, err, "Unexpected error marshaling %v.", mode)
+
+		var got ColorMode
+		require.NoError(t, got.UnmarshalText(text), "Unexpected error unmarshaling %q.", text)
+		assert.Equal(t, mode, got, "Expected %q to round-trip.", text)
+	}
+
+	var mode ColorMode
+	assert.NoError(t, mode.UnmarshalText(nil), "Expected the empty string to unmarshal.")
+	assert.Equal(t, ColorAuto, mode, "Expected the empty string to be ColorAuto.")
+	assert.Error(t, mode.UnmarshalText([]byte("rainbow")), "Expected an error for an unknown mode.")
+	assert.Equal(t, "ColorMode(42)", ColorMode(42).String(), "Unexpected string for an unknown mode.")
+}
+
+type emptyObject struct{}
+
+func (emptyObject) MarshalLogObject(ObjectEncoder) error { return nil }
@@ -0,0 +1,619 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// _defaultSpoolMaxBytes is the default limit on a spool's disk usage.
+	_defaultSpoolMaxBytes = 64 * 1024 * 1024 // 64 MB
+
+	// _defaultSpoolSegmentSize is the default size of spool segments.
+	_defaultSpoolSegmentSize = 4 * 1024 * 1024 // 4 MB
+
+	// _defaultSpoolRetryInterval is how often spooled writes are replayed
+	// by default.
+	_defaultSpoolRetryInterval = time.Second
+
+	_spoolSegmentSuffix = ".spool"
+	_spoolCursorFile    = "cursor"
+	_spoolHeaderSize    = 4 // length prefix of each spooled write
+
+	// _spoolReplayBatchSize is roughly how many bytes of spooled writes are
+	// read at a time for replay.
+	_spoolReplayBatchSize = 64 * 1024 // 64 KB
+)
+
+// A SpoolWriteSyncer is a WriteSyncer that spools writes to disk while a
+// wrapped WriteSyncer is failing, and replays them in order once it
+// recovers.
+//
+// Writes go straight to the wrapped WriteSyncer until one fails. From then
+// on, writes are appended to a queue of segment files in Dir, and replayed
+// every RetryInterval until the queue is empty. Spooled writes survive
+// restarts: a SpoolWriteSyncer replays whatever it finds in Dir before
+// anything written since. Writes are delivered at least once; some may be
+// repeated if the process exits while replaying.
+//
+// The queue is limited to MaxBytes on disk. When it's full, the oldest
+// segment is evicted to make room. Stats reports the queue's depth and how
+// much was dropped. Writes evicted while they're being replayed may still be
+// delivered.
+//
+// SpoolWriteSyncer is safe for concurrent use. Like BufferedWriteSyncer, it
+// should be stopped when no longer needed.
+//
+//	ws := &zapcore.SpoolWriteSyncer{
+//	  WS:  agent, // a WriteSyncer that may be unavailable
+//	  Dir: "/var/spool/myapp",
+//	}
+//	defer ws.Stop()
+type SpoolWriteSyncer struct {
+	// WS is the WriteSyncer that writes are delivered to.
+	//
+	// This field is required.
+	WS WriteSyncer
+
+	// Dir is the directory that holds spooled writes. It's created if it
+	// doesn't exist, and shouldn't be shared with another SpoolWriteSyncer.
+	//
+	// This field is required.
+	Dir string
+
+	// MaxBytes limits the disk space used by spooled writes.
+	//
+	// Defaults to 64 MB if unspecified.
+	MaxBytes int64
+
+	// SegmentSize is the size at which a new segment file is started. The
+	// oldest segment is evicted as a whole when the spool is full.
+	//
+	// Defaults to 4 MB if unspecified.
+	SegmentSize int64
+
+	// RetryInterval specifies how often spooled writes are replayed.
+	//
+	// Defaults to one second if unspecified.
+	RetryInterval time.Duration
+
+	// Clock, if specified, provides control of the source of time for the
+	// writer.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+
+	// unexported fields for state
+	replayMu    sync.Mutex // held while replaying, so only one replay runs
+	mu          sync.Mutex
+	initialized bool // whether initialize() has run
+	stopped     bool // whether Stop() has run
+	segments    []*spoolSegment
+	cursor      int64    // offset of the next write to replay in segments[0]
+	tail        *os.File // open for appending to the last segment, if any
+	nextID      uint64
+	diskBytes   int64
+	stats       SpoolStats
+	ticker      *time.Ticker
+	stop        chan struct{} // closed when replayLoop should stop
+	done        chan struct{} // closed when replayLoop has stopped
+}
+
+// SpoolStats describes the state of a SpoolWriteSyncer.
+type SpoolStats struct {
+	// QueuedWrites and QueuedBytes describe the writes waiting to be
+	// replayed.
+	QueuedWrites int64
+	QueuedBytes  int64
+
+	// DroppedWrites and DroppedBytes count the writes evicted from a full
+	// spool, or too big to fit in it.
+	DroppedWrites int64
+	DroppedBytes  int64
+}
+
+// spoolSegment is a file of spooled writes, each prefixed by its length.
+type spoolSegment struct {
+	id     uint64
+	size   int64 // size of the file
+	writes int64 // writes not yet replayed
+	bytes  int64 // bytes not yet replayed
+}
+
+func (s *SpoolWriteSyncer) initialize() error {
+	if s.MaxBytes == 0 {
+		s.MaxBytes = _defaultSpoolMaxBytes
+	}
+	if s.SegmentSize == 0 {
+		s.SegmentSize = _defaultSpoolSegmentSize
+	}
+	retryInterval := s.RetryInterval
+	if retryInterval == 0 {
+		retryInterval = _defaultSpoolRetryInterval
+	}
+	if s.Clock == nil {
+		s.Clock = DefaultClock
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	s.ticker = s.Clock.NewTicker(retryInterval)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.initialized = true
+	go s.replayLoop()
+	return nil
+}
+
+// load finds the segments left by an earlier SpoolWriteSyncer.
+func (s *SpoolWriteSyncer) load() error {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, _spoolSegmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, _spoolSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		s.segments = append(s.segments, &spoolSegment{id: id})
+	}
+	sort.Slice(s.segments, func(i, j int) bool {
+		return s.segments[i].id < s.segments[j].id
+	})
+	if len(s.segments) == 0 {
+		s.nextID = 1
+		return nil
+	}
+	s.nextID = s.segments[len(s.segments)-1].id + 1
+
+	// The cursor only applies if its segment hasn't been removed.
+	if b, err := os.ReadFile(filepath.Join(s.Dir, _spoolCursorFile)); err == nil {
+		var id uint64
+		var offset int64
+		if _, err := fmt.Sscan(string(b), &id, &offset); err == nil && id == s.segments[0].id {
+			s.cursor = offset
+		}
+	}
+
+	for i, seg := range s.segments {
+		var from int64
+		if i == 0 {
+			from = s.cursor
+		}
+		if err := s.scan(seg, from); err != nil {
+			return err
+		}
+		s.diskBytes += seg.size
+		s.stats.QueuedWrites += seg.writes
+		s.stats.QueuedBytes += seg.bytes
+	}
+	return nil
+}
+
+// scan counts the writes in a segment after the given offset. A write cut
+// short by a crash is truncated.
+func (s *SpoolWriteSyncer) scan(seg *spoolSegment, from int64) error {
+	path := s.segmentPath(seg.id)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		n, err := readSpooled(r, io.Discard)
+		if err != nil {
+			break
+		}
+		if offset >= from {
+			seg.writes++
+			seg.bytes += int64(n)
+		}
+		offset += _spoolHeaderSize + int64(n)
+	}
+	seg.size = offset
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if offset < info.Size() {
+		return os.Truncate(path, offset)
+	}
+	return nil
+}
+
+// readSpooled copies a spooled write from r to w, returning its length.
+func readSpooled(r io.Reader, w io.Writer) (int, error) {
+	var header [_spoolHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint32(header[:]))
+	if _, err := io.CopyN(w, r, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *SpoolWriteSyncer) segmentPath(id uint64) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%020d%s", id, _spoolSegmentSuffix))
+}
+
+// Write writes to the wrapped WriteSyncer, or spools the write if the
+// WriteSyncer fails or earlier writes are still spooled. It only returns
+// an error if the write can be neither delivered nor spooled.
+func (s *SpoolWriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.initialized {
+		if err := s.initialize(); err != nil {
+			return 0, err
+		}
+	}
+
+	rest := bs
+	if len(s.segments) == 0 {
+		n, err := s.WS.Write(bs)
+		if err == nil {
+			return n, nil
+		}
+		rest = bs[n:]
+	}
+	if err := s.spool(rest); err != nil {
+		return len(bs) - len(rest), err
+	}
+	return len(bs), nil
+}
+
+// spool appends a write to the queue, evicting old segments to make room.
+func (s *SpoolWriteSyncer) spool(bs []byte) error {
+	size := int64(_spoolHeaderSize + len(bs))
+	if size > s.MaxBytes || uint64(len(bs)) > math.MaxUint32 {
+		s.stats.DroppedWrites++
+		s.stats.DroppedBytes += int64(len(bs))
+		return nil
+	}
+	for len(s.segments) > 0 && s.diskBytes+size > s.MaxBytes {
+		if err := s.evict(); err != nil {
+			return err
+		}
+	}
+
+	if s.tail == nil || s.tailFull(size) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	seg := s.segments[len(s.segments)-1]
+
+	rec := make([]byte, size)
+	binary.BigEndian.PutUint32(rec, uint32(len(bs)))
+	copy(rec[_spoolHeaderSize:], bs)
+	n, err := s.tail.Write(rec)
+	seg.size += int64(n)
+	s.diskBytes += int64(n)
+	if err != nil {
+		// Don't leave part of a write in the segment.
+		_ = s.tail.Truncate(seg.size - int64(n))
+		seg.size -= int64(n)
+		s.diskBytes -= int64(n)
+		return err
+	}
+
+	seg.writes++
+	seg.bytes += int64(len(bs))
+	s.stats.QueuedWrites++
+	s.stats.QueuedBytes += int64(len(bs))
+	return nil
+}
+
+// tailFull reports whether a write of the given size should start a new
+// segment. Writes bigger than SegmentSize get a segment to themselves.
+func (s *SpoolWriteSyncer) tailFull(size int64) bool {
+	seg := s.segments[len(s.segments)-1]
+	return seg.size > 0 && seg.size+size > s.SegmentSize
+}
+
+// rotate starts a new segment.
+func (s *SpoolWriteSyncer) rotate() error {
+	if s.tail != nil {
+		if err := s.tail.Close(); err != nil {
+			return err
+		}
+		s.tail = nil
+	}
+	id := s.nextID
+	f, err := os.OpenFile(s.segmentPath(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	s.nextID++
+	s.tail = f
+	s.segments = append(s.segments, &spoolSegment{id: id})
+	return nil
+}
+
+// evict removes the oldest segment, dropping its writes.
+func (s *SpoolWriteSyncer) evict() error {
+	seg := s.segments[0]
+	s.stats.DroppedWrites += seg.writes
+	s.stats.DroppedBytes += seg.bytes
+	return s.remove()
+}
+
+// remove deletes the oldest segment.
+func (s *SpoolWriteSyncer) remove() error {
+	seg := s.segments[0]
+	if len(s.segments) == 1 && s.tail != nil {
+		if err := s.tail.Close(); err != nil {
+			return err
+		}
+		s.tail = nil
+	}
+	if err := os.Remove(s.segmentPath(seg.id)); err != nil {
+		return err
+	}
+	s.segments = s.segments[1:]
+	s.diskBytes -= seg.size
+	s.stats.QueuedWrites -= seg.writes
+	s.stats.QueuedBytes -= seg.bytes
+	s.cursor = 0
+	return nil
+}
+
+// replay delivers spooled writes until the queue is empty or the wrapped
+// WriteSyncer fails. Writes are read from the spool in batches with the
+// lock held, but delivered without it, so a slow WriteSyncer doesn't block
+// Writes.
+func (s *SpoolWriteSyncer) replay() error {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	for {
+		batch, err := s.nextBatch()
+		if err != nil || batch == nil {
+			return err
+		}
+
+		var delivered int
+		for _, w := range batch.writes {
+			if _, err = s.WS.Write(w); err != nil {
+				break
+			}
+			delivered++
+		}
+		if cerr := s.commit(batch, delivered, err != nil); cerr != nil || err != nil {
+			return multierr.Append(err, cerr)
+		}
+	}
+}
+
+// A spoolBatch is a run of spooled writes read from the oldest segment.
+type spoolBatch struct {
+	segment uint64 // ID of the segment
+	offset  int64  // offset of the first write in the segment
+	writes  [][]byte
+}
+
+// nextBatch reads the next writes to replay from the oldest segment,
+// returning nil if there's nothing left to replay.
+func (s *SpoolWriteSyncer) nextBatch() (*spoolBatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.segments) == 0 {
+		return nil, s.saveCursor()
+	}
+	seg := s.segments[0]
+
+	f, err := os.Open(s.segmentPath(seg.id))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(s.cursor, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	batch := &spoolBatch{segment: seg.id, offset: s.cursor}
+	r := bufio.NewReader(f)
+	var size int
+	for int64(len(batch.writes)) < seg.writes && size < _spoolReplayBatchSize {
+		var buf bytesWriter
+		n, err := readSpooled(r, &buf)
+		if err != nil {
+			return nil, err
+		}
+		batch.writes = append(batch.writes, buf)
+		size += n
+	}
+	return batch, nil
+}
+
+// commit advances the cursor past the first n writes of a batch, removing
+// the oldest segment once it's been replayed. If the segment was evicted
+// while the batch was being delivered, there's nothing to advance.
+func (s *SpoolWriteSyncer) commit(batch *spoolBatch, n int, failed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.segments) == 0 || s.segments[0].id != batch.segment || s.cursor != batch.offset {
+		return nil
+	}
+	seg := s.segments[0]
+	for _, w := range batch.writes[:n] {
+		s.cursor += int64(_spoolHeaderSize + len(w))
+		seg.writes--
+		seg.bytes -= int64(len(w))
+		s.stats.QueuedWrites--
+		s.stats.QueuedBytes -= int64(len(w))
+	}
+
+	if seg.writes == 0 {
+		if err := s.remove(); err != nil {
+			return err
+		}
+		return s.saveCursor()
+	}
+	if failed {
+		return s.saveCursor()
+	}
+	return nil
+}
+
+// saveCursor records how much of the oldest segment has been replayed, so
+// that it isn't replayed again after a restart.
+func (s *SpoolWriteSyncer) saveCursor() error {
+	path := filepath.Join(s.Dir, _spoolCursorFile)
+	if s.cursor == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(fmt.Sprintf("%d %d\n", s.segments[0].id, s.cursor)), 0o644)
+}
+
+// Sync replays spooled writes and syncs the wrapped WriteSyncer. If writes
+// are still spooled, Sync instead makes sure they're on disk.
+func (s *SpoolWriteSyncer) Sync() error {
+	s.mu.Lock()
+	initialized := s.initialized
+	s.mu.Unlock()
+	if !initialized {
+		return s.WS.Sync()
+	}
+
+	// Replay errors leave the writes spooled, which is reported below.
+	_ = s.replay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.segments) == 0 {
+		return s.WS.Sync()
+	}
+	if s.tail != nil {
+		return s.tail.Sync()
+	}
+	return nil
+}
+
+// Stats reports the number of writes spooled and dropped.
+func (s *SpoolWriteSyncer) Stats() SpoolStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// replayLoop replays spooled writes at the configured interval until Stop
+// is called.
+func (s *SpoolWriteSyncer) replayLoop() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.ticker.C:
+			// Failures leave writes spooled for the next attempt.
+			_ = s.replay()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop stops replaying in the background, makes a last attempt to deliver
+// spooled writes, and closes the spool. Writes that are still spooled are
+// replayed by the next SpoolWriteSyncer using the same Dir.
+func (s *SpoolWriteSyncer) Stop() error {
+	// Critical section.
+	stopped := func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.initialized || s.stopped {
+			return false
+		}
+		s.stopped = true
+
+		s.ticker.Stop()
+		close(s.stop) // tell replayLoop to stop
+		return true
+	}()
+	if !stopped {
+		return nil
+	}
+
+	// Wait for replayLoop to end outside of the lock, as it may need the
+	// lock to complete.
+	<-s.done
+
+	err := s.Sync()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tail != nil {
+		err = multierr.Append(err, s.tail.Close())
+		s.tail = nil
+	}
+	return err
+}
+
+// bytesWriter is an io.Writer that appends to a byte slice.
+type bytesWriter []byte
+
+func (w *bytesWriter) Write(p []byte) (int, error) {
+	*w = append(*w, p...)
+	return len(p), nil
+}
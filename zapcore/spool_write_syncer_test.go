@@ -0,0 +1,355 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+)
+
+// flakyWriteSyncer is a WriteSyncer that fails while it's down, or after
+// accepting a number of writes.
+type flakyWriteSyncer struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	down    bool
+	accepts int  // writes to accept before going down, if limited
+	limited bool // whether accepts applies
+	partial int  // bytes of a failed write to accept
+}
+
+func (w *flakyWriteSyncer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.limited {
+		if w.accepts == 0 {
+			w.down = true
+		}
+		w.accepts--
+	}
+	if w.down {
+		n := w.partial
+		w.buf.Write(p[:n])
+		return n, errors.New("unavailable")
+	}
+	return w.buf.Write(p)
+}
+
+func (w *flakyWriteSyncer) Sync() error { return nil }
+
+func (w *flakyWriteSyncer) setDown(down bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.down = down
+	w.limited = false
+}
+
+func (w *flakyWriteSyncer) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// gatedWriteSyncer blocks writes to the wrapped WriteSyncer while closed,
+// signaling entered when a write is blocked.
+type gatedWriteSyncer struct {
+	WriteSyncer
+	closed  atomic.Bool
+	entered chan struct{}
+	open    chan struct{}
+}
+
+func newGatedWriteSyncer(ws WriteSyncer) *gatedWriteSyncer {
+	return &gatedWriteSyncer{
+		WriteSyncer: ws,
+		entered:     make(chan struct{}, 1),
+		open:        make(chan struct{}),
+	}
+}
+
+func (w *gatedWriteSyncer) Write(p []byte) (int, error) {
+	if w.closed.Load() {
+		select {
+		case w.entered <- struct{}{}:
+		default:
+		}
+		<-w.open
+	}
+	return w.WriteSyncer.Write(p)
+}
+
+func writeAll(t *testing.T, ws WriteSyncer, writes ...string) {
+	for _, s := range writes {
+		n, err := ws.Write([]byte(s))
+		require.NoError(t, err, "Unexpected error writing %q.", s)
+		require.Equal(t, len(s), n, "Unexpected number of bytes written.")
+	}
+}
+
+func spoolFiles(t *testing.T, dir string) []string {
+	names, err := filepath.Glob(filepath.Join(dir, "*"+_spoolSegmentSuffix))
+	require.NoError(t, err, "Unexpected error listing spool.")
+	return names
+}
+
+func TestSpoolWriteSyncerHealthy(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyWriteSyncer{}
+	ws := &SpoolWriteSyncer{WS: down, Dir: dir}
+	defer ws.Stop()
+
+	writeAll(t, ws, "a", "b")
+	assert.Equal(t, "ab", down.String(), "Expected writes to go straight through.")
+	assert.Empty(t, spoolFiles(t, dir), "Expected nothing to be spooled.")
+	assert.Equal(t, SpoolStats{}, ws.Stats(), "Unexpected stats.")
+}
+
+func TestSpoolWriteSyncerReplays(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyWriteSyncer{down: true}
+	ws := &SpoolWriteSyncer{WS: down, Dir: dir}
+	defer ws.Stop()
+
+	writeAll(t, ws, "a", "bb")
+	down.setDown(false)
+	writeAll(t, ws, "ccc") // spooled behind the others
+	assert.Empty(t, down.String(), "Expected writes to be spooled.")
+	assert.Equal(t, SpoolStats{QueuedWrites: 3, QueuedBytes: 6}, ws.Stats(), "Unexpected stats.")
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, "abbccc", down.String(), "Expected spooled writes to be replayed in order.")
+	assert.Equal(t, SpoolStats{}, ws.Stats(), "Unexpected stats after replaying.")
+	assert.Empty(t, spoolFiles(t, dir), "Expected replayed segments to be removed.")
+
+	writeAll(t, ws, "d")
+	assert.Equal(t, "abbcccd", down.String(), "Expected writes to go straight through again.")
+}
+
+func TestSpoolWriteSyncerReplaysInBackground(t *testing.T) {
+	clock := ztest.NewMockClock()
+	down := &flakyWriteSyncer{down: true}
+	ws := &SpoolWriteSyncer{WS: down, Dir: t.TempDir(), RetryInterval: time.Second, Clock: clock}
+	defer ws.Stop()
+
+	writeAll(t, ws, "a")
+	down.setDown(false)
+	clock.Add(time.Second)
+	assert.Eventually(t, func() bool {
+		return down.String() == "a"
+	}, time.Second, time.Millisecond, "Expected the spool to be replayed.")
+}
+
+func TestSpoolWriteSyncerSync(t *testing.T) {
+	t.Run("uninitialized", func(t *testing.T) {
+		syncer := &ztest.Discarder{}
+		ws := &SpoolWriteSyncer{WS: syncer, Dir: t.TempDir()}
+		assert.NoError(t, ws.Sync(), "Unexpected error syncing.")
+		assert.True(t, syncer.Called(), "Expected the wrapped WriteSyncer to be synced.")
+	})
+
+	t.Run("still down", func(t *testing.T) {
+		ws := &SpoolWriteSyncer{WS: &flakyWriteSyncer{down: true}, Dir: t.TempDir()}
+		defer ws.Stop()
+		writeAll(t, ws, "a")
+		assert.NoError(t, ws.Sync(), "Expected no error when writes are safely spooled.")
+		assert.Equal(t, int64(1), ws.Stats().QueuedWrites, "Expected the write to stay spooled.")
+	})
+}
+
+func TestSpoolWriteSyncerRestart(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyWriteSyncer{down: true}
+	ws := &SpoolWriteSyncer{WS: down, Dir: dir}
+	writeAll(t, ws, "a", "b", "c")
+
+	// Replay one write, then fail.
+	down.mu.Lock()
+	down.down, down.limited, down.accepts = false, true, 1
+	down.mu.Unlock()
+	assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, "a", down.String(), "Expected one write to be replayed.")
+	assert.NotEmpty(t, spoolFiles(t, dir), "Expected writes to stay spooled.")
+
+	down.setDown(false)
+	ws = &SpoolWriteSyncer{WS: down, Dir: dir}
+	writeAll(t, ws, "d")
+	assert.Equal(t, SpoolStats{QueuedWrites: 3, QueuedBytes: 3}, ws.Stats(), "Expected spooled writes to be found.")
+	assert.NoError(t, ws.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, "abcd", down.String(), "Expected the rest to be replayed once, in order.")
+	assert.Empty(t, spoolFiles(t, dir), "Expected replayed segments to be removed.")
+	assert.NoFileExists(t, filepath.Join(dir, _spoolCursorFile), "Expected the cursor to be removed.")
+}
+
+func TestSpoolWriteSyncerTruncatedSegment(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyWriteSyncer{down: true}
+	ws := &SpoolWriteSyncer{WS: down, Dir: dir}
+	writeAll(t, ws, "a", "b")
+	require.NoError(t, ws.Stop(), "Unexpected error stopping.")
+
+	// Simulate a crash partway through a write.
+	files := spoolFiles(t, dir)
+	require.Len(t, files, 1, "Expected one segment.")
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err, "Unexpected error opening segment.")
+	_, err = f.Write([]byte{0, 0, 0, 9, 'c'})
+	require.NoError(t, err, "Unexpected error writing segment.")
+	require.NoError(t, f.Close(), "Unexpected error closing segment.")
+
+	down.setDown(false)
+	ws = &SpoolWriteSyncer{WS: down, Dir: dir}
+	writeAll(t, ws, "d")
+	require.NoError(t, ws.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, "abd", down.String(), "Expected the partial write to be discarded.")
+}
+
+func TestSpoolWriteSyncerEviction(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyWriteSyncer{down: true}
+	ws := &SpoolWriteSyncer{
+		WS:          down,
+		Dir:         dir,
+		MaxBytes:    30, // three segments
+		SegmentSize: 10, // two five-byte writes
+	}
+	defer ws.Stop()
+
+	writeAll(t, ws, "0", "1", "2", "3", "4", "5", "6", "7")
+	assert.Len(t, spoolFiles(t, dir), 3, "Unexpected number of segments.")
+	assert.Equal(t, SpoolStats{
+		QueuedWrites:  6,
+		QueuedBytes:   6,
+		DroppedWrites: 2,
+		DroppedBytes:  2,
+	}, ws.Stats(), "Expected the oldest segment to be evicted.")
+
+	writeAll(t, ws, strings.Repeat("x", 30))
+	assert.Equal(t, int64(3), ws.Stats().DroppedWrites, "Expected writes bigger than the spool to be dropped.")
+
+	down.setDown(false)
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, "234567", down.String(), "Expected the newest writes to be replayed.")
+}
+
+func TestSpoolWriteSyncerPartialWrite(t *testing.T) {
+	down := &flakyWriteSyncer{down: true, partial: 3}
+	ws := &SpoolWriteSyncer{WS: down, Dir: t.TempDir()}
+	defer ws.Stop()
+
+	writeAll(t, ws, "hello")
+	assert.Equal(t, SpoolStats{QueuedWrites: 1, QueuedBytes: 2}, ws.Stats(), "Expected the rest of the write to be spooled.")
+
+	down.setDown(false)
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, "hello", down.String(), "Expected the write to be completed.")
+}
+
+func TestSpoolWriteSyncerBadDir(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644), "Unexpected error creating file.")
+
+	ws := &SpoolWriteSyncer{WS: &flakyWriteSyncer{}, Dir: file}
+	_, err := ws.Write([]byte("a"))
+	assert.Error(t, err, "Expected an error when Dir isn't a directory.")
+	assert.NoError(t, ws.Stop(), "Expected stopping an uninitialized spool to succeed.")
+}
+
+func TestSpoolWriteSyncerReplayDoesntBlockWrites(t *testing.T) {
+	tests := []struct {
+		desc      string
+		segment   int64    // SegmentSize, if not the default
+		max       int64    // MaxBytes, if not the default
+		spooled   []string // written before replay
+		during    []string // written while replay is blocked
+		wantStats SpoolStats
+	}{
+		{
+			desc:    "appended to replaying segment",
+			spooled: []string{"a", "b"},
+			during:  []string{"c"},
+		},
+		{
+			desc:    "replaying segment evicted",
+			segment: 10,
+			max:     10,
+			spooled: []string{"aaaa"},
+			during:  []string{"bbbb"},
+			// The evicted write was already read for replay, so it's
+			// delivered anyway.
+			wantStats: SpoolStats{DroppedWrites: 1, DroppedBytes: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			down := &flakyWriteSyncer{down: true}
+			gate := newGatedWriteSyncer(down)
+			ws := &SpoolWriteSyncer{
+				WS:            gate,
+				Dir:           t.TempDir(),
+				SegmentSize:   tt.segment,
+				MaxBytes:      tt.max,
+				RetryInterval: time.Hour,
+			}
+			defer ws.Stop()
+
+			writeAll(t, ws, tt.spooled...)
+			down.setDown(false)
+			gate.closed.Store(true)
+
+			synced := make(chan error, 1)
+			go func() { synced <- ws.Sync() }()
+			<-gate.entered
+
+			wrote := make(chan struct{})
+			go func() {
+				defer close(wrote)
+				writeAll(t, ws, tt.during...)
+			}()
+			select {
+			case <-wrote:
+			case <-time.After(5 * time.Second):
+				t.Error("Write blocked by a replay in progress.")
+			}
+
+			gate.closed.Store(false)
+			close(gate.open)
+			<-wrote
+			require.NoError(t, <-synced, "Unexpected error syncing.")
+
+			all := append(append([]string{}, tt.spooled...), tt.during...)
+			assert.Equal(t, strings.Join(all, ""), down.String(), "Unexpected output.")
+			assert.Equal(t, tt.wantStats, ws.Stats(), "Unexpected stats.")
+			assert.Empty(t, spoolFiles(t, ws.Dir), "Expected the spool to be empty.")
+		})
+	}
+}
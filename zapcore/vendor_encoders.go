@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "strconv"
+
+// GCPSeverityEncoder serializes a Level to the name of a Google Cloud Logging
+// LogSeverity. For example, WarnLevel is serialized to "WARNING", and
+// FatalLevel to "EMERGENCY". Unknown levels are serialized to "DEFAULT".
+func GCPSeverityEncoder(l Level, enc PrimitiveArrayEncoder) {
+	switch l {
+	case DebugLevel:
+		enc.AppendString("DEBUG")
+	case InfoLevel:
+		enc.AppendString("INFO")
+	case WarnLevel:
+		enc.AppendString("WARNING")
+	case ErrorLevel:
+		enc.AppendString("ERROR")
+	case DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case PanicLevel:
+		enc.AppendString("ALERT")
+	case FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+// DatadogStatusEncoder serializes a Level to a Datadog log status. For
+// example, WarnLevel is serialized to "warn", and FatalLevel to "emergency".
+func DatadogStatusEncoder(l Level, enc PrimitiveArrayEncoder) {
+	switch l {
+	case DPanicLevel:
+		enc.AppendString("critical")
+	case PanicLevel:
+		enc.AppendString("alert")
+	case FatalLevel:
+		enc.AppendString("emergency")
+	default:
+		enc.AppendString(l.String())
+	}
+}
+
+// GCPSourceLocationEncoder serializes a caller to a Google Cloud Logging
+// LogEntrySourceLocation object, with its file, line, and function. Encoders
+// that can't append objects get the caller in /full/path/to/package/file:line
+// format instead.
+func GCPSourceLocationEncoder(caller EntryCaller, enc PrimitiveArrayEncoder) {
+	arr, ok := enc.(ArrayEncoder)
+	if !ok {
+		enc.AppendString(caller.String())
+		return
+	}
+	_ = arr.AppendObject(gcpSourceLocation(caller))
+}
+
+type gcpSourceLocation EntryCaller
+
+func (c gcpSourceLocation) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("file", c.File)
+	// The line is an int64, which JSON-encoded protocol buffers represent as
+	// a string.
+	enc.AddString("line", strconv.Itoa(c.Line))
+	if c.Function != "" {
+		enc.AddString("function", c.Function)
+	}
+	return nil
+}
+
+// ECSCallerEncoder serializes a caller to the Elastic Common Schema's
+// log.origin object, nesting its file name and line under "file". Encoders
+// that can't append objects get the caller in /full/path/to/package/file:line
+// format instead.
+func ECSCallerEncoder(caller EntryCaller, enc PrimitiveArrayEncoder) {
+	arr, ok := enc.(ArrayEncoder)
+	if !ok {
+		enc.AppendString(caller.String())
+		return
+	}
+	_ = arr.AppendObject(ecsOrigin(caller))
+}
+
+type ecsOrigin EntryCaller
+
+func (c ecsOrigin) MarshalLogObject(enc ObjectEncoder) error {
+	if err := enc.AddObject("file", ecsOriginFile(c)); err != nil {
+		return err
+	}
+	if c.Function != "" {
+		enc.AddString("function", c.Function)
+	}
+	return nil
+}
+
+type ecsOriginFile EntryCaller
+
+func (c ecsOriginFile) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("name", c.File)
+	enc.AddInt("line", c.Line)
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVendorCallerEncoders(t *testing.T) {
+	caller := EntryCaller{Defined: true, File: "/src/app/handler.go", Line: 42, Function: "app.Handle"}
+	tests := []struct {
+		desc string
+		enc  CallerEncoder
+		want interface{}
+	}{
+		{
+			desc: "GCP",
+			enc:  GCPSourceLocationEncoder,
+			want: map[string]interface{}{
+				"file":     "/src/app/handler.go",
+				"line":     "42",
+				"function": "app.Handle",
+			},
+		},
+		{
+			desc: "ECS",
+			enc:  ECSCallerEncoder,
+			want: map[string]interface{}{
+				"file":     map[string]interface{}{"name": "/src/app/handler.go", "line": 42},
+				"function": "app.Handle",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			arr := &sliceArrayEncoder{}
+			tt.enc(caller, arr)
+			assert.Equal(t, []interface{}{tt.want}, arr.elems, "Unexpected object for caller.")
+
+			// Encoders that can only append primitives get the full caller.
+			arr = &sliceArrayEncoder{}
+			tt.enc(caller, struct{ PrimitiveArrayEncoder }{arr})
+			assert.Equal(t, []interface{}{"/src/app/handler.go:42"}, arr.elems, "Unexpected fallback for caller.")
+		})
+	}
+}
+
+func TestVendorLevelEncoders(t *testing.T) {
+	tests := []struct {
+		level   Level
+		gcp     string
+		datadog string
+	}{
+		{DebugLevel, "DEBUG", "debug"},
+		{InfoLevel, "INFO", "info"},
+		{WarnLevel, "WARNING", "warn"},
+		{ErrorLevel, "ERROR", "error"},
+		{DPanicLevel, "CRITICAL", "critical"},
+		{PanicLevel, "ALERT", "alert"},
+		{FatalLevel, "EMERGENCY", "emergency"},
+		{Level(-42), "DEFAULT", "Level(-42)"},
+	}
+
+	for _, tt := range tests {
+		arr := &sliceArrayEncoder{}
+		GCPSeverityEncoder(tt.level, arr)
+		DatadogStatusEncoder(tt.level, arr)
+		assert.Equal(t, []interface{}{tt.gcp, tt.datadog}, arr.elems, "Unexpected encoding of %v.", tt.level)
+	}
+}
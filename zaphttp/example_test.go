@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaphttp"
+)
+
+func Example() {
+	logger := zap.NewExample()
+
+	// Omit the latency so that the output is reproducible.
+	names := zaphttp.DefaultFieldNames()
+	names.Latency = ""
+
+	hello := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zaphttp.FromContext(r.Context()).Info("saying hello")
+		_, _ = w.Write([]byte("hello\n"))
+	})
+	handler := zaphttp.NewHandler(logger, hello,
+		zaphttp.WithFieldNames(names),
+		zaphttp.Headers("User-Agent"),
+		zaphttp.RequestFields(func(r *http.Request) []zap.Field {
+			return []zap.Field{zap.String("request_id", r.Header.Get("X-Request-Id"))}
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	req.Header.Set("User-Agent", "example")
+	req.Header.Set("X-Request-Id", "1234")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Output:
+	// {"level":"info","msg":"saying hello","request_id":"1234"}
+	// {"level":"info","msg":"HTTP request","request_id":"1234","method":"GET","path":"/hello","status":200,"bytes":6,"remote_addr":"192.0.2.1:1234","headers":{"User-Agent":"example"}}
+}
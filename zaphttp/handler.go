@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zaphttp provides net/http middleware that writes an access log
+// entry for each request to a zap.Logger.
+//
+// By default, entries for successful requests are logged at InfoLevel,
+// client errors at WarnLevel, and server errors at ErrorLevel. Handlers can
+// retrieve a logger for the request with FromContext.
+package zaphttp // import "go.uber.org/zap/zaphttp"
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_requestMsg = "HTTP request"
+	_panicMsg   = "Recovered from panic serving HTTP request"
+)
+
+type handler struct {
+	next      http.Handler
+	logger    *zap.Logger
+	names     FieldNames
+	headers   []string
+	levels    statusLevels
+	route     func(*http.Request) string
+	reqFields func(*http.Request) []zap.Field
+	sampler   *sampler
+	clock     zapcore.Clock
+}
+
+type statusLevels struct {
+	success, clientError, serverError zapcore.Level
+}
+
+// NewHandler wraps next in a handler that logs every request to the given
+// logger once next returns.
+//
+// If next panics, the handler recovers, replies with a 500 Internal Server
+// Error unless next already wrote a status, and logs the panic and its
+// stacktrace at ErrorLevel in place of the usual entry. As in net/http,
+// panics with http.ErrAbortHandler are logged as usual and then re-panicked
+// to abort the response.
+func NewHandler(logger *zap.Logger, next http.Handler, opts ...Option) http.Handler {
+	h := &handler{
+		next:   next,
+		logger: logger,
+		names:  DefaultFieldNames(),
+		levels: statusLevels{
+			success:     zapcore.InfoLevel,
+			clientError: zapcore.WarnLevel,
+			serverError: zapcore.ErrorLevel,
+		},
+		route: defaultRoute,
+		clock: zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(h)
+	}
+	return h
+}
+
+// Middleware returns a function that wraps handlers with NewHandler, for use
+// with routers that accept middleware of that shape.
+func Middleware(logger *zap.Logger, opts ...Option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return NewHandler(logger, next, opts...)
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := h.clock.Now()
+	log := h.logger
+	if h.reqFields != nil {
+		log = log.With(h.reqFields(r)...)
+	}
+	r = r.WithContext(NewContext(r.Context(), log))
+	rw := &responseWriter{ResponseWriter: w}
+
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+		if p == http.ErrAbortHandler {
+			h.logRequest(log, r, rw, start)
+			panic(p)
+		}
+
+		stack := zap.StackSkip(h.names.Stack, 1) // skip this func
+		if !rw.wroteHeader && !rw.hijacked {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+		h.write(log, h.levels.serverError, _panicMsg, r, rw, start, zap.Any(h.names.Panic, p), stack)
+	}()
+
+	h.next.ServeHTTP(rw, r)
+	h.logRequest(log, r, rw, start)
+}
+
+// logRequest writes the access log entry for a request that didn't panic.
+func (h *handler) logRequest(log *zap.Logger, r *http.Request, rw *responseWriter, start time.Time) {
+	status := rw.Status()
+	lvl := h.levels.success
+	switch {
+	case status >= 500:
+		lvl = h.levels.serverError
+	case status >= 400:
+		lvl = h.levels.clientError
+	default:
+		if h.sampler != nil && !h.sampler.allow(h.clock.Now()) {
+			return
+		}
+	}
+	h.write(log, lvl, _requestMsg, r, rw, start)
+}
+
+func (h *handler) write(log *zap.Logger, lvl zapcore.Level, msg string, r *http.Request, rw *responseWriter, start time.Time, extra ...zap.Field) {
+	latency := h.clock.Now().Sub(start)
+	ce := log.Check(lvl, msg)
+	if ce == nil {
+		return
+	}
+
+	n := h.names
+	fields := make([]zap.Field, 0, 9+len(extra))
+	if n.Method != "" {
+		fields = append(fields, zap.String(n.Method, r.Method))
+	}
+	if n.Path != "" {
+		fields = append(fields, zap.String(n.Path, r.URL.Path))
+	}
+	if n.Route != "" {
+		if route := h.route(r); route != "" {
+			fields = append(fields, zap.String(n.Route, route))
+		}
+	}
+	if n.Status != "" {
+		fields = append(fields, zap.Int(n.Status, rw.Status()))
+	}
+	if n.Bytes != "" {
+		fields = append(fields, zap.Int64(n.Bytes, rw.bytes))
+	}
+	if n.Latency != "" {
+		fields = append(fields, zap.Duration(n.Latency, latency))
+	}
+	if n.RemoteAddr != "" {
+		fields = append(fields, zap.String(n.RemoteAddr, r.RemoteAddr))
+	}
+	if n.Headers != "" && hasAny(r.Header, h.headers) {
+		fields = append(fields, zap.Object(n.Headers, headerFields{r.Header, h.headers}))
+	}
+	for _, f := range extra {
+		if f.Key != "" {
+			fields = append(fields, f)
+		}
+	}
+	ce.Write(fields...)
+}
+
+func hasAny(header http.Header, names []string) bool {
+	for _, name := range names {
+		if len(header.Values(name)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// headerFields logs the selected headers of a request, joining repeated
+// headers with commas.
+type headerFields struct {
+	header http.Header
+	names  []string
+}
+
+func (hf headerFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, name := range hf.names {
+		if vals := hf.header.Values(name); len(vals) > 0 {
+			enc.AddString(name, strings.Join(vals, ", "))
+		}
+	}
+	return nil
+}
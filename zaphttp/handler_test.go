@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func withHandler(t *testing.T, next http.HandlerFunc, opts []Option, f func(http.Handler, *zaptest.MockClock, *observer.ObservedLogs)) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	clock := zaptest.NewMockClock()
+	opts = append([]Option{WithClock(clock)}, opts...)
+	f(NewHandler(zap.New(core), next, opts...), clock, logs)
+}
+
+func serve(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestHandlerFields(t *testing.T) {
+	var clock *zaptest.MockClock
+	next := func(w http.ResponseWriter, r *http.Request) {
+		clock.Add(42 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	}
+
+	withHandler(t, next, nil, func(h http.Handler, c *zaptest.MockClock, logs *observer.ObservedLogs) {
+		clock = c
+		rec := serve(h, http.MethodPost, "/users?name=foo")
+		assert.Equal(t, http.StatusCreated, rec.Code, "Unexpected response status.")
+		assert.Equal(t, "hello", rec.Body.String(), "Unexpected response body.")
+
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		entry := logs.AllUntimed()[0]
+		assert.Equal(t, zapcore.InfoLevel, entry.Level, "Unexpected level.")
+		assert.Equal(t, "HTTP request", entry.Message, "Unexpected message.")
+		assert.Equal(t, map[string]interface{}{
+			"method":      "POST",
+			"path":        "/users",
+			"status":      int64(201),
+			"bytes":       int64(5),
+			"latency":     42 * time.Millisecond,
+			"remote_addr": "192.0.2.1:1234",
+		}, entry.ContextMap(), "Unexpected fields.")
+	})
+}
+
+func TestHandlerFieldNames(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {}
+	names := FieldNames{
+		Method: "http.method",
+		Status: "http.status_code",
+	}
+
+	withHandler(t, next, []Option{WithFieldNames(names)}, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+		serve(h, http.MethodGet, "/")
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		assert.Equal(t, map[string]interface{}{
+			"http.method":      "GET",
+			"http.status_code": int64(200),
+		}, logs.AllUntimed()[0].ContextMap(), "Unexpected fields.")
+	})
+}
+
+func TestHandlerLevels(t *testing.T) {
+	tests := []struct {
+		status int
+		opts   []Option
+		want   zapcore.Level
+	}{
+		{http.StatusSwitchingProtocols, nil, zapcore.InfoLevel},
+		{http.StatusOK, nil, zapcore.InfoLevel},
+		{http.StatusNotModified, nil, zapcore.InfoLevel},
+		{http.StatusNotFound, nil, zapcore.WarnLevel},
+		{http.StatusServiceUnavailable, nil, zapcore.ErrorLevel},
+		{
+			status: http.StatusOK,
+			opts:   []Option{StatusLevels(zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel)},
+			want:   zapcore.DebugLevel,
+		},
+		{
+			status: http.StatusBadRequest,
+			opts:   []Option{StatusLevels(zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel)},
+			want:   zapcore.InfoLevel,
+		},
+		{
+			status: http.StatusInternalServerError,
+			opts:   []Option{StatusLevels(zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel)},
+			want:   zapcore.WarnLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		next := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}
+		withHandler(t, next, tt.opts, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+			serve(h, http.MethodGet, "/")
+			require.Equal(t, 1, logs.Len(), "Expected exactly one entry for status %d.", tt.status)
+			assert.Equal(t, tt.want, logs.All()[0].Level, "Unexpected level for status %d.", tt.status)
+		})
+	}
+}
+
+func TestHandlerLevelDisabled(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	h := NewHandler(zap.New(core), http.NotFoundHandler())
+
+	serve(h, http.MethodGet, "/")
+	assert.Equal(t, 1, logs.Len(), "Expected an entry for a client error.")
+
+	h = NewHandler(zap.New(core), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	serve(h, http.MethodGet, "/")
+	assert.Equal(t, 1, logs.Len(), "Unexpected entry below the core's level.")
+}
+
+func TestHandlerHeaders(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {}
+	names := FieldNames{Headers: "headers"}
+	opts := []Option{WithFieldNames(names), Headers("User-Agent", "x-forwarded-for", "X-Missing")}
+
+	withHandler(t, next, opts, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("User-Agent", "curl/8.0")
+		req.Header.Add("X-Forwarded-For", "203.0.113.1")
+		req.Header.Add("X-Forwarded-For", "203.0.113.2")
+		req.Header.Set("Authorization", "secret")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Requests without any of the selected headers omit the object.
+		serve(h, http.MethodGet, "/")
+
+		entries := logs.AllUntimed()
+		require.Equal(t, 2, len(entries), "Unexpected number of entries.")
+		assert.Equal(t, map[string]interface{}{
+			"headers": map[string]interface{}{
+				"User-Agent":      "curl/8.0",
+				"x-forwarded-for": "203.0.113.1, 203.0.113.2",
+			},
+		}, entries[0].ContextMap(), "Unexpected fields with headers.")
+		assert.Empty(t, entries[1].Context, "Unexpected fields without headers.")
+	})
+}
+
+func TestHandlerRequestLogger(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("handling")
+	}
+	requestID := RequestFields(func(r *http.Request) []zap.Field {
+		return []zap.Field{zap.String("request_id", r.Header.Get("X-Request-Id"))}
+	})
+	names := FieldNames{Status: "status"}
+
+	withHandler(t, next, []Option{requestID, WithFieldNames(names)}, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-Id", "abc")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		entries := logs.AllUntimed()
+		require.Equal(t, 2, len(entries), "Unexpected number of entries.")
+		assert.Equal(t, "handling", entries[0].Message, "Unexpected message from the request logger.")
+		assert.Equal(t, map[string]interface{}{"request_id": "abc"}, entries[0].ContextMap(),
+			"Unexpected fields from the request logger.")
+		assert.Equal(t, map[string]interface{}{"request_id": "abc", "status": int64(200)}, entries[1].ContextMap(),
+			"Unexpected fields in the access log entry.")
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, zap.L(), FromContext(context.Background()), "Expected the global logger without a logger in the context.")
+
+	log := zap.NewExample()
+	assert.Equal(t, log, FromContext(NewContext(context.Background(), log)), "Unexpected logger from the context.")
+	assert.Equal(t, zap.L(), FromContext(NewContext(context.Background(), nil)), "Expected the global logger for a nil logger.")
+}
+
+func TestHandlerPanic(t *testing.T) {
+	tests := []struct {
+		desc       string
+		next       http.HandlerFunc
+		wantStatus int
+		wantPanic  interface{}
+	}{
+		{
+			desc:       "before writing a header",
+			next:       func(http.ResponseWriter, *http.Request) { panic("boom") },
+			wantStatus: http.StatusInternalServerError,
+			wantPanic:  "boom",
+		},
+		{
+			desc: "after writing a header",
+			next: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				panic(errors.New("boom"))
+			},
+			wantStatus: http.StatusAccepted,
+			wantPanic:  "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			names := FieldNames{Status: "status", Panic: "panic", Stack: "stack"}
+			withHandler(t, tt.next, []Option{WithFieldNames(names)}, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+				var rec *httptest.ResponseRecorder
+				require.NotPanics(t, func() { rec = serve(h, http.MethodGet, "/") }, "Expected the panic to be recovered.")
+				assert.Equal(t, tt.wantStatus, rec.Code, "Unexpected response status.")
+
+				require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+				entry := logs.AllUntimed()[0]
+				assert.Equal(t, zapcore.ErrorLevel, entry.Level, "Unexpected level.")
+				assert.Equal(t, "Recovered from panic serving HTTP request", entry.Message, "Unexpected message.")
+
+				fields := entry.ContextMap()
+				assert.Equal(t, int64(tt.wantStatus), fields["status"], "Unexpected status.")
+				assert.Equal(t, tt.wantPanic, fields["panic"], "Unexpected panic value.")
+				assert.Contains(t, fields["stack"], "zaphttp.TestHandlerPanic", "Expected the stack to include the panicking func.")
+				assert.NotContains(t, fields["stack"], "zaphttp.(*handler).ServeHTTP.func1", "Expected the stack to skip the recovering func.")
+			})
+		})
+	}
+}
+
+func TestHandlerAbortPanic(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		panic(http.ErrAbortHandler)
+	}
+
+	withHandler(t, next, []Option{WithFieldNames(FieldNames{Status: "status"})}, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() { serve(h, http.MethodGet, "/") },
+			"Expected http.ErrAbortHandler to be re-panicked.")
+
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		entry := logs.AllUntimed()[0]
+		assert.Equal(t, "HTTP request", entry.Message, "Unexpected message.")
+		assert.Equal(t, map[string]interface{}{"status": int64(200)}, entry.ContextMap(), "Unexpected fields.")
+	})
+}
+
+func TestHandlerSampleSuccess(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	opts := []Option{SampleSuccess(time.Minute, 2, 3)}
+
+	withHandler(t, next, opts, func(h http.Handler, clock *zaptest.MockClock, logs *observer.ObservedLogs) {
+		for i := 0; i < 10; i++ {
+			serve(h, http.MethodGet, "/ok")
+			serve(h, http.MethodGet, "/error")
+		}
+		// Requests 1, 2, 5, and 8 are logged.
+		assert.Equal(t, 4, logs.FilterField(zap.Int("status", 200)).Len(), "Unexpected number of successes logged.")
+		assert.Equal(t, 10, logs.FilterField(zap.Int("status", 500)).Len(), "Expected every server error to be logged.")
+
+		clock.Add(time.Minute)
+		serve(h, http.MethodGet, "/ok")
+		assert.Equal(t, 5, logs.FilterField(zap.Int("status", 200)).Len(), "Expected sampling to reset after a tick.")
+	})
+}
+
+func TestHandlerSampleNoneThereafter(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {}
+	withHandler(t, next, []Option{SampleSuccess(time.Minute, 1, 0)}, func(h http.Handler, _ *zaptest.MockClock, logs *observer.ObservedLogs) {
+		for i := 0; i < 5; i++ {
+			serve(h, http.MethodGet, "/")
+		}
+		assert.Equal(t, 1, logs.Len(), "Expected only the first success to be logged.")
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	wrap := Middleware(zap.New(core), WithFieldNames(FieldNames{Path: "path"}))
+	h := wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve(h, http.MethodGet, "/foo")
+	require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+	assert.Equal(t, map[string]interface{}{"path": "/foo"}, logs.AllUntimed()[0].ContextMap(), "Unexpected fields.")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldNames are the keys of the fields in access log entries. An empty name
+// omits the field.
+type FieldNames struct {
+	Method     string // request method
+	Path       string // request URL path
+	Route      string // route that matched the request, if known
+	Status     string // response status code
+	Bytes      string // response body size
+	Latency    string // time spent serving the request
+	RemoteAddr string // network address of the client
+	Headers    string // object holding the headers selected with Headers
+	Panic      string // value recovered from a panic
+	Stack      string // stacktrace of a panic
+}
+
+// DefaultFieldNames returns the field names used unless WithFieldNames is
+// given.
+func DefaultFieldNames() FieldNames {
+	return FieldNames{
+		Method:     "method",
+		Path:       "path",
+		Route:      "route",
+		Status:     "status",
+		Bytes:      "bytes",
+		Latency:    "latency",
+		RemoteAddr: "remote_addr",
+		Headers:    "headers",
+		Panic:      "panic",
+		Stack:      "stack",
+	}
+}
+
+// An Option configures the handler returned by NewHandler.
+type Option interface {
+	apply(*handler)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*handler)
+
+func (f optionFunc) apply(h *handler) {
+	f(h)
+}
+
+// WithFieldNames sets the keys of the fields in access log entries.
+func WithFieldNames(names FieldNames) Option {
+	return optionFunc(func(h *handler) {
+		h.names = names
+	})
+}
+
+// Headers logs the values of the given request headers, if present, in an
+// object keyed by their names as given here. Repeated headers are joined
+// with commas. By default, no headers are logged.
+func Headers(names ...string) Option {
+	return optionFunc(func(h *handler) {
+		h.headers = append(h.headers, names...)
+	})
+}
+
+// StatusLevels sets the levels at which requests are logged, based on the
+// class of their response status: success for 1xx, 2xx, and 3xx responses,
+// clientError for 4xx responses, and serverError for 5xx responses and
+// panics. The defaults are InfoLevel, WarnLevel, and ErrorLevel.
+func StatusLevels(success, clientError, serverError zapcore.Level) Option {
+	return optionFunc(func(h *handler) {
+		h.levels = statusLevels{
+			success:     success,
+			clientError: clientError,
+			serverError: serverError,
+		}
+	})
+}
+
+// RouteFunc sets the function that reports the route a request matched,
+// like "/users/{id}". It's called after the wrapped handler returns. Routes
+// are omitted from entries when it returns an empty string.
+//
+// When built with Go 1.23 or later, the default reports the pattern that
+// an http.ServeMux matched, if any. Otherwise, or if the httpmuxgo121
+// GODEBUG setting selects the older mux, routes are omitted by default.
+func RouteFunc(f func(*http.Request) string) Option {
+	return optionFunc(func(h *handler) {
+		h.route = f
+	})
+}
+
+// RequestFields adds the fields returned by f to the logger for each request,
+// and so to its access log entry and any entries logged by handlers with the
+// logger from FromContext. This is useful for fields like request IDs.
+func RequestFields(f func(*http.Request) []zap.Field) Option {
+	return optionFunc(func(h *handler) {
+		h.reqFields = f
+	})
+}
+
+// SampleSuccess samples the access log entries of successful requests - those
+// with 1xx, 2xx, and 3xx responses - the same way as zapcore.NewSampler:
+// within each tick, the first entries are logged, and then every thereafter-th
+// entry. If thereafter is zero, all entries after the first are dropped
+// until the next tick. Client and server errors and panics are never
+// sampled.
+func SampleSuccess(tick time.Duration, first, thereafter int) Option {
+	return optionFunc(func(h *handler) {
+		h.sampler = &sampler{
+			tick:       tick,
+			first:      uint64(first),
+			thereafter: uint64(thereafter),
+		}
+	})
+}
+
+// WithClock specifies the clock used to measure latency and to sample
+// requests.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(h *handler) {
+		h.clock = clock
+	})
+}
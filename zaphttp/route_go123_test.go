@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+
+// The module's go version otherwise selects the Go 1.21 http.ServeMux,
+// which doesn't match patterns with methods or report them.
+//
+//go:debug httpmuxgo121=0
+
+package zaphttp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandlerRoute(t *testing.T) {
+	names := FieldNames{Route: "route"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(http.ResponseWriter, *http.Request) {})
+
+	tests := []struct {
+		desc   string
+		handle func(log *zap.Logger) http.Handler
+		target string
+		want   map[string]interface{}
+	}{
+		{
+			desc: "wrapping a mux",
+			handle: func(log *zap.Logger) http.Handler {
+				return NewHandler(log, mux, WithFieldNames(names))
+			},
+			target: "/users/42",
+			want:   map[string]interface{}{"route": "GET /users/{id}"},
+		},
+		{
+			desc: "wrapped by a mux",
+			handle: func(log *zap.Logger) http.Handler {
+				mux := http.NewServeMux()
+				mux.Handle("/files/", NewHandler(log, http.NotFoundHandler(), WithFieldNames(names)))
+				return mux
+			},
+			target: "/files/a.txt",
+			want:   map[string]interface{}{"route": "/files/"},
+		},
+		{
+			desc: "no match",
+			handle: func(log *zap.Logger) http.Handler {
+				return NewHandler(log, http.NotFoundHandler(), WithFieldNames(names))
+			},
+			target: "/users/42",
+			want:   map[string]interface{}{},
+		},
+		{
+			desc: "custom",
+			handle: func(log *zap.Logger) http.Handler {
+				route := RouteFunc(func(*http.Request) string { return "custom" })
+				return NewHandler(log, mux, WithFieldNames(names), route)
+			},
+			target: "/users/42",
+			want:   map[string]interface{}{"route": "custom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			serve(tt.handle(zap.New(core)), http.MethodGet, tt.target)
+			require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+			assert.Equal(t, tt.want, logs.AllUntimed()[0].ContextMap(), "Unexpected fields.")
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// sampler counts successful requests like zapcore's sampler counts entries
+// with the same level and message.
+type sampler struct {
+	tick              time.Duration
+	first, thereafter uint64
+
+	resetAt atomic.Int64
+	counter atomic.Uint64
+}
+
+// allow reports whether the request completed at t should be logged.
+func (s *sampler) allow(t time.Time) bool {
+	n := s.incCheckReset(t)
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+func (s *sampler) incCheckReset(t time.Time) uint64 {
+	tn := t.UnixNano()
+	resetAfter := s.resetAt.Load()
+	if resetAfter > tn {
+		return s.counter.Add(1)
+	}
+
+	s.counter.Store(1)
+	if !s.resetAt.CompareAndSwap(resetAfter, tn+s.tick.Nanoseconds()) {
+		// We raced with another goroutine trying to reset, and it also reset
+		// the counter to 1, so we need to reincrement the counter.
+		return s.counter.Add(1)
+	}
+	return 1
+}
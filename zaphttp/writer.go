@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// responseWriter records the status and body size of a response.
+//
+// It implements http.Flusher, http.Hijacker, and io.ReaderFrom by delegating
+// to the wrapped ResponseWriter, and Unwrap for http.ResponseController.
+type responseWriter struct {
+	http.ResponseWriter
+
+	status      int
+	bytes       int64
+	wroteHeader bool
+	hijacked    bool
+}
+
+var (
+	_ http.Flusher  = (*responseWriter)(nil)
+	_ http.Hijacker = (*responseWriter)(nil)
+	_ io.ReaderFrom = (*responseWriter)(nil)
+)
+
+// Status returns the status code of the response. As in net/http, it's 200
+// if the handler never wrote a header. It's 0 if the handler hijacked the
+// connection without writing a header.
+func (w *responseWriter) Status() int {
+	switch {
+	case w.wroteHeader:
+		return w.status
+	case w.hijacked:
+		return 0
+	default:
+		return http.StatusOK
+	}
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	// Informational responses, except for 101 Switching Protocols, precede
+	// the final status.
+	if !w.wroteHeader && (code < 100 || code >= 200 || code == http.StatusSwitchingProtocols) {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.implicitHeader()
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.implicitHeader()
+	// io.Copy uses the wrapped ResponseWriter's ReadFrom, if any.
+	n, err := io.Copy(w.ResponseWriter, src)
+	w.bytes += n
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.implicitHeader()
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T doesn't support hijacking", w.ResponseWriter)
+	}
+	conn, buf, err := h.Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, buf, err
+}
+
+// Unwrap returns the wrapped ResponseWriter.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// implicitHeader records the 200 OK that net/http sends if a handler writes
+// a body or flushes without writing a header.
+func (w *responseWriter) implicitHeader() {
+	if !w.wroteHeader && !w.hijacked {
+		w.status = http.StatusOK
+		w.wroteHeader = true
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseWriterStatus(t *testing.T) {
+	tests := []struct {
+		desc   string
+		handle func(http.ResponseWriter)
+		want   int
+	}{
+		{
+			desc:   "nothing written",
+			handle: func(http.ResponseWriter) {},
+			want:   http.StatusOK,
+		},
+		{
+			desc:   "header",
+			handle: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) },
+			want:   http.StatusNotFound,
+		},
+		{
+			desc: "header twice",
+			handle: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			desc: "informational header",
+			handle: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusEarlyHints)
+				w.WriteHeader(http.StatusNoContent)
+			},
+			want: http.StatusNoContent,
+		},
+		{
+			desc:   "switching protocols",
+			handle: func(w http.ResponseWriter) { w.WriteHeader(http.StatusSwitchingProtocols) },
+			want:   http.StatusSwitchingProtocols,
+		},
+		{
+			desc: "body",
+			handle: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte("foo"))
+				w.WriteHeader(http.StatusNotFound)
+			},
+			want: http.StatusOK,
+		},
+		{
+			desc: "flush",
+			handle: func(w http.ResponseWriter) {
+				w.(http.Flusher).Flush()
+				w.WriteHeader(http.StatusNotFound)
+			},
+			want: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rw := &responseWriter{ResponseWriter: httptest.NewRecorder()}
+			tt.handle(rw)
+			assert.Equal(t, tt.want, rw.Status(), "Unexpected status.")
+		})
+	}
+}
+
+func TestResponseWriterBytes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := &responseWriter{ResponseWriter: rec}
+
+	n, err := rw.Write([]byte("foo"))
+	require.NoError(t, err, "Unexpected error writing.")
+	assert.Equal(t, 3, n, "Unexpected number of bytes written.")
+
+	n64, err := rw.ReadFrom(strings.NewReader("barbaz"))
+	require.NoError(t, err, "Unexpected error reading from a reader.")
+	assert.Equal(t, int64(6), n64, "Unexpected number of bytes read.")
+
+	assert.Equal(t, int64(9), rw.bytes, "Unexpected body size.")
+	assert.Equal(t, "foobarbaz", rec.Body.String(), "Unexpected body.")
+	assert.Equal(t, rec, rw.Unwrap(), "Expected Unwrap to return the wrapped ResponseWriter.")
+}
+
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (r hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.conn, nil, nil
+}
+
+func TestResponseWriterHijack(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		rw := &responseWriter{ResponseWriter: httptest.NewRecorder()}
+		_, _, err := rw.Hijack()
+		assert.ErrorContains(t, err, "doesn't support hijacking", "Unexpected error hijacking.")
+		assert.Equal(t, http.StatusOK, rw.Status(), "Unexpected status.")
+	})
+
+	t.Run("supported", func(t *testing.T) {
+		server, client := net.Pipe()
+		defer server.Close()
+		defer client.Close()
+
+		rw := &responseWriter{ResponseWriter: hijackRecorder{httptest.NewRecorder(), server}}
+		conn, _, err := rw.Hijack()
+		require.NoError(t, err, "Unexpected error hijacking.")
+		assert.Equal(t, server, conn, "Unexpected hijacked connection.")
+
+		_, _ = rw.Write([]byte("foo"))
+		assert.Equal(t, 0, rw.Status(), "Unexpected status after hijacking.")
+	})
+}
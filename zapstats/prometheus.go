@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _prometheusContentType is the content type of the Prometheus text format.
+const _prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler returns an HTTP handler that serves the metrics in the Prometheus
+// text format.
+func (s *Stats) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", _prometheusContentType)
+		_ = s.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes the metrics in the Prometheus text format.
+func (s *Stats) WritePrometheus(w io.Writer) error {
+	snap := s.Snapshot()
+	pw := promWriter{w: bufio.NewWriter(w)}
+
+	pw.header("zap_entries_total", "counter", "Entries checked by cores, by outcome.")
+	for _, name := range sortedKeys(snap.Cores) {
+		levels := snap.Cores[name]
+		for _, lvl := range sortedLevels(levels) {
+			stats := levels[lvl]
+			pw.sample("zap_entries_total", stats.Skipped, "core", name, "level", lvl.String(), "outcome", "skipped")
+			pw.sample("zap_entries_total", stats.Written, "core", name, "level", lvl.String(), "outcome", "written")
+			pw.sample("zap_entries_total", stats.Failed, "core", name, "level", lvl.String(), "outcome", "failed")
+		}
+	}
+
+	pw.header("zap_sampler_decisions_total", "counter", "Sampler decisions, by level.")
+	for _, name := range sortedKeys(snap.Samplers) {
+		levels := snap.Samplers[name]
+		for _, lvl := range sortedLevels(levels) {
+			stats := levels[lvl]
+			pw.sample("zap_sampler_decisions_total", stats.Sampled, "sampler", name, "level", lvl.String(), "decision", "sampled")
+			pw.sample("zap_sampler_decisions_total", stats.Dropped, "sampler", name, "level", lvl.String(), "decision", "dropped")
+		}
+	}
+
+	encoders := sortedKeys(snap.Encoders)
+	pw.header("zap_encoder_entries_total", "counter", "Entries encoded.")
+	for _, name := range encoders {
+		pw.sample("zap_encoder_entries_total", snap.Encoders[name].Entries, "encoder", name)
+	}
+	pw.header("zap_encoder_errors_total", "counter", "Entries for which encoding returned an error.")
+	for _, name := range encoders {
+		pw.sample("zap_encoder_errors_total", snap.Encoders[name].Errors, "encoder", name)
+	}
+
+	sinks := sortedKeys(snap.Sinks)
+	for _, m := range []struct {
+		name, help string
+		value      func(SinkStats) int64
+	}{
+		{"zap_sink_writes_total", "Writes to sinks.", func(s SinkStats) int64 { return s.Writes }},
+		{"zap_sink_bytes_total", "Bytes written to sinks.", func(s SinkStats) int64 { return s.Bytes }},
+		{"zap_sink_write_errors_total", "Writes to sinks that failed.", func(s SinkStats) int64 { return s.WriteErrors }},
+		{"zap_sink_syncs_total", "Syncs of sinks.", func(s SinkStats) int64 { return s.Syncs }},
+		{"zap_sink_sync_errors_total", "Syncs of sinks that failed.", func(s SinkStats) int64 { return s.SyncErrors }},
+	} {
+		pw.header(m.name, "counter", m.help)
+		for _, name := range sinks {
+			pw.sample(m.name, m.value(snap.Sinks[name]), "sink", name)
+		}
+	}
+
+	pw.header("zap_sink_write_duration_seconds", "histogram", "How long writes to sinks took.")
+	for _, name := range sinks {
+		latency := snap.Sinks[name].Latency
+		for _, b := range latency.Buckets {
+			pw.sample("zap_sink_write_duration_seconds_bucket", b.Count, "sink", name, "le", formatFloat(b.UpperBound.Seconds()))
+		}
+		pw.sample("zap_sink_write_duration_seconds_bucket", latency.Count, "sink", name, "le", "+Inf")
+		pw.sampleFloat("zap_sink_write_duration_seconds_sum", latency.Sum.Seconds(), "sink", name)
+		pw.sample("zap_sink_write_duration_seconds_count", latency.Count, "sink", name)
+	}
+
+	return pw.w.Flush()
+}
+
+func sortedLevels[T any](m map[zapcore.Level]T) []zapcore.Level {
+	levels := make([]zapcore.Level, 0, len(m))
+	for lvl := range m {
+		levels = append(levels, lvl)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	return levels
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// promWriter writes metrics in the Prometheus text format. Write errors
+// are reported by flushing the bufio.Writer.
+type promWriter struct {
+	w *bufio.Writer
+}
+
+func (pw promWriter) header(name, typ, help string) {
+	fmt.Fprintf(pw.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (pw promWriter) sample(name string, value int64, labels ...string) {
+	pw.labels(name, labels)
+	pw.w.WriteString(strconv.FormatInt(value, 10))
+	pw.w.WriteByte('\n')
+}
+
+func (pw promWriter) sampleFloat(name string, value float64, labels ...string) {
+	pw.labels(name, labels)
+	pw.w.WriteString(formatFloat(value))
+	pw.w.WriteByte('\n')
+}
+
+// labels writes a metric's name and labels, given as name-value pairs.
+func (pw promWriter) labels(name string, labels []string) {
+	pw.w.WriteString(name)
+	pw.w.WriteByte('{')
+	for i := 0; i < len(labels); i += 2 {
+		if i > 0 {
+			pw.w.WriteByte(',')
+		}
+		pw.w.WriteString(labels[i])
+		pw.w.WriteString(`="`)
+		pw.w.WriteString(_labelEscaper.Replace(labels[i+1]))
+		pw.w.WriteByte('"')
+	}
+	pw.w.WriteString("} ")
+}
+
+var _labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapstats collects metrics about zap's own logging pipeline: how
+// many entries each core wrote, skipped, or failed to write, what samplers
+// decided, how many entries encoders failed to encode, and how many bytes
+// each sink wrote and how long that took.
+//
+// A Stats wraps each part of the pipeline it measures:
+//
+//	stats := zapstats.New()
+//	sink := stats.WriteSyncer("file", file)
+//	enc := stats.Encoder("json", zapcore.NewJSONEncoder(cfg))
+//	core := stats.Core("file", zapcore.NewCore(enc, sink, zap.InfoLevel))
+//	core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100,
+//		zapcore.SamplerHook(stats.SamplerHook("file")))
+//
+// Metrics can be read with Snapshot, published with expvar using Publish,
+// or served in the Prometheus text format with Handler.
+package zapstats // import "go.uber.org/zap/zapstats"
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_minLevel = zapcore.DebugLevel
+	_maxLevel = zapcore.FatalLevel
+	_numLevel = _maxLevel - _minLevel + 1
+)
+
+// _latencyBuckets are the upper bounds of the buckets that write latencies
+// are counted in.
+var _latencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// Stats collects metrics from the parts of a logging pipeline it wraps. Each
+// part is identified by a name; parts of the same kind with the same name
+// share their metrics.
+//
+// Stats is safe for concurrent use.
+type Stats struct {
+	mu       sync.Mutex
+	cores    map[string]*levelCounters
+	samplers map[string]*levelCounters
+	encoders map[string]*encoderCounters
+	sinks    map[string]*sinkCounters
+	now      func() time.Time // for timing writes
+}
+
+// New creates an empty Stats.
+func New() *Stats {
+	return &Stats{
+		cores:    make(map[string]*levelCounters),
+		samplers: make(map[string]*levelCounters),
+		encoders: make(map[string]*encoderCounters),
+		sinks:    make(map[string]*sinkCounters),
+		now:      time.Now,
+	}
+}
+
+// Snapshot is a point-in-time copy of the metrics in a Stats, keyed by the
+// names of the parts they describe.
+type Snapshot struct {
+	Cores    map[string]map[zapcore.Level]CoreStats    `json:"cores"`
+	Samplers map[string]map[zapcore.Level]SamplerStats `json:"samplers"`
+	Encoders map[string]EncoderStats                   `json:"encoders"`
+	Sinks    map[string]SinkStats                      `json:"sinks"`
+}
+
+// CoreStats counts the entries checked by a core at one level.
+type CoreStats struct {
+	// Skipped counts entries below the core's level.
+	Skipped int64 `json:"skipped"`
+	// Written counts entries written successfully.
+	Written int64 `json:"written"`
+	// Failed counts entries whose writes returned an error.
+	Failed int64 `json:"failed"`
+}
+
+// SamplerStats counts a sampler's decisions at one level.
+type SamplerStats struct {
+	Sampled int64 `json:"sampled"`
+	Dropped int64 `json:"dropped"`
+}
+
+// EncoderStats counts the entries an encoder encoded.
+type EncoderStats struct {
+	Entries int64 `json:"entries"`
+	// Errors counts entries for which the encoder's EncodeEntry returned an
+	// error, so they weren't written at all. Zap's encoders don't fail when
+	// a field can't be marshaled; they add an error field like "keyError" to
+	// the entry instead, which isn't counted here.
+	Errors int64 `json:"errors"`
+}
+
+// SinkStats describes the writes and syncs of a WriteSyncer.
+type SinkStats struct {
+	Writes      int64        `json:"writes"`
+	Bytes       int64        `json:"bytes"`
+	WriteErrors int64        `json:"writeErrors"`
+	Syncs       int64        `json:"syncs"`
+	SyncErrors  int64        `json:"syncErrors"`
+	Latency     LatencyStats `json:"latency"`
+}
+
+// LatencyStats is a histogram of write latencies.
+type LatencyStats struct {
+	Count int64         `json:"count"`
+	Sum   time.Duration `json:"sum"`
+	// Buckets holds the cumulative number of writes that took at most each
+	// bucket's upper bound.
+	Buckets []Bucket `json:"buckets"`
+}
+
+// A Bucket counts the writes that took at most UpperBound.
+type Bucket struct {
+	UpperBound time.Duration `json:"upperBound"`
+	Count      int64         `json:"count"`
+}
+
+// Snapshot copies the current metrics.
+func (s *Stats) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := Snapshot{
+		Cores:    make(map[string]map[zapcore.Level]CoreStats, len(s.cores)),
+		Samplers: make(map[string]map[zapcore.Level]SamplerStats, len(s.samplers)),
+		Encoders: make(map[string]EncoderStats, len(s.encoders)),
+		Sinks:    make(map[string]SinkStats, len(s.sinks)),
+	}
+	for name, c := range s.cores {
+		levels := make(map[zapcore.Level]CoreStats)
+		c.each(func(lvl zapcore.Level, counts *[3]atomic.Int64) {
+			levels[lvl] = CoreStats{
+				Skipped: counts[_skipped].Load(),
+				Written: counts[_written].Load(),
+				Failed:  counts[_failed].Load(),
+			}
+		})
+		snap.Cores[name] = levels
+	}
+	for name, c := range s.samplers {
+		levels := make(map[zapcore.Level]SamplerStats)
+		c.each(func(lvl zapcore.Level, counts *[3]atomic.Int64) {
+			levels[lvl] = SamplerStats{
+				Sampled: counts[_sampled].Load(),
+				Dropped: counts[_dropped].Load(),
+			}
+		})
+		snap.Samplers[name] = levels
+	}
+	for name, c := range s.encoders {
+		snap.Encoders[name] = EncoderStats{
+			Entries: c.entries.Load(),
+			Errors:  c.errors.Load(),
+		}
+	}
+	for name, c := range s.sinks {
+		snap.Sinks[name] = c.snapshot()
+	}
+	return snap
+}
+
+// Publish publishes the metrics with expvar under the given name, as a JSON
+// Snapshot. Like expvar.Publish, it panics if the name is already taken.
+func (s *Stats) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return s.Snapshot()
+	}))
+}
+
+// Indexes of per-level counters.
+const (
+	_skipped = 0
+	_written = 1
+	_failed  = 2
+
+	_sampled = 0
+	_dropped = 1
+)
+
+// levelCounters holds up to three counters for each level.
+type levelCounters [_numLevel][3]atomic.Int64
+
+func (c *levelCounters) add(lvl zapcore.Level, i int) {
+	if lvl >= _minLevel && lvl <= _maxLevel {
+		c[lvl-_minLevel][i].Add(1)
+	}
+}
+
+// each calls f for each level that has been counted.
+func (c *levelCounters) each(f func(zapcore.Level, *[3]atomic.Int64)) {
+	for i := range c {
+		counts := &c[i]
+		if counts[0].Load() != 0 || counts[1].Load() != 0 || counts[2].Load() != 0 {
+			f(_minLevel+zapcore.Level(i), counts)
+		}
+	}
+}
+
+func lookup[T any](s *Stats, m map[string]*T, name string) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := m[name]
+	if !ok {
+		c = new(T)
+		m[name] = c
+	}
+	return c
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstats
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+// newTestStats returns a Stats whose writes each take the given latencies,
+// in turn.
+func newTestStats(latencies ...time.Duration) *Stats {
+	s := New()
+	var now time.Time
+	var calls int
+	s.now = func() time.Time {
+		// Writes call now before and after.
+		if calls%2 == 1 && len(latencies) > 0 {
+			now = now.Add(latencies[0])
+			latencies = latencies[1:]
+		}
+		calls++
+		return now
+	}
+	return s
+}
+
+// failingEncoder fails to encode entries with the message "bad".
+type failingEncoder struct{ zapcore.Encoder }
+
+func (e failingEncoder) Clone() zapcore.Encoder {
+	return failingEncoder{e.Encoder.Clone()}
+}
+
+func (e failingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	if ent.Message == "bad" {
+		return nil, errors.New("can't encode")
+	}
+	return e.Encoder.EncodeEntry(ent, fields)
+}
+
+// newTestPipeline builds a logger that exercises every kind of metric.
+func newTestPipeline(s *Stats) *zap.Logger {
+	enc := s.Encoder("json", failingEncoder{zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})})
+	good := s.Core("good", zapcore.NewCore(enc, s.WriteSyncer("good", &zaptest.Discarder{}), zapcore.InfoLevel))
+	broken := s.Core("broken", zapcore.NewCore(enc, s.WriteSyncer("broken", &zaptest.FailWriter{}), zapcore.WarnLevel))
+	core := zapcore.NewSamplerWithOptions(zapcore.NewTee(good, broken), time.Minute, 2, 0,
+		zapcore.SamplerHook(s.SamplerHook("sampler")))
+	return zap.New(core, zap.ErrorOutput(zapcore.AddSync(io.Discard)))
+}
+
+func TestSnapshot(t *testing.T) {
+	s := newTestStats(time.Microsecond, 5*time.Millisecond, 2*time.Second)
+	logger := newTestPipeline(s)
+
+	logger.Debug("skipped")
+	logger.Info("info")
+	logger.Info("info")
+	logger.Info("info") // dropped by the sampler
+	logger.Warn("warn")
+	logger.Info("bad")
+	require.NoError(t, logger.Core().Sync(), "Unexpected error syncing.")
+
+	snap := s.Snapshot()
+	assert.Equal(t, map[string]map[zapcore.Level]CoreStats{
+		"good": {
+			zapcore.InfoLevel: {Written: 2, Failed: 1},
+			zapcore.WarnLevel: {Written: 1},
+		},
+		"broken": {
+			zapcore.InfoLevel: {Skipped: 3},
+			zapcore.WarnLevel: {Failed: 1},
+		},
+	}, snap.Cores, "Unexpected core stats.")
+	assert.Equal(t, map[string]map[zapcore.Level]SamplerStats{
+		"sampler": {
+			zapcore.InfoLevel: {Sampled: 3, Dropped: 1},
+			zapcore.WarnLevel: {Sampled: 1},
+		},
+	}, snap.Samplers, "Unexpected sampler stats.")
+	assert.Equal(t, map[string]EncoderStats{"json": {Entries: 5, Errors: 1}}, snap.Encoders, "Unexpected encoder stats.")
+
+	good := snap.Sinks["good"]
+	assert.Equal(t, int64(3), good.Writes, "Unexpected writes.")
+	assert.Positive(t, good.Bytes, "Expected bytes to be counted.")
+	assert.Equal(t, int64(1), good.Syncs, "Unexpected syncs.")
+	assert.Equal(t, LatencyStats{
+		Count: 3,
+		Sum:   2*time.Second + 5*time.Millisecond + time.Microsecond,
+		Buckets: []Bucket{
+			{100 * time.Microsecond, 1},
+			{time.Millisecond, 1},
+			{10 * time.Millisecond, 2},
+			{100 * time.Millisecond, 2},
+			{time.Second, 2},
+		},
+	}, good.Latency, "Unexpected latency.")
+
+	broken := snap.Sinks["broken"]
+	assert.Equal(t, int64(1), broken.Writes, "Unexpected writes.")
+	assert.Equal(t, int64(1), broken.WriteErrors, "Unexpected write errors.")
+	assert.Equal(t, int64(1), broken.Syncs, "Unexpected syncs.")
+}
+
+func TestEncoderErrors(t *testing.T) {
+	s := New()
+	enc := s.Encoder("json", failingEncoder{zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})})
+	var out strings.Builder
+	logger := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&out), zapcore.InfoLevel),
+		zap.ErrorOutput(zapcore.AddSync(io.Discard)))
+
+	broken := zapcore.ObjectMarshalerFunc(func(zapcore.ObjectEncoder) error {
+		return errors.New("can't marshal")
+	})
+	logger.Info("field", zap.Object("obj", broken))
+	assert.Equal(t, EncoderStats{Entries: 1}, s.Snapshot().Encoders["json"],
+		"Expected a field that fails to marshal not to count as an error.")
+	assert.Contains(t, out.String(), `"objError":"can't marshal"`, "Expected the field's error in the entry.")
+
+	logger.Info("bad")
+	assert.Equal(t, EncoderStats{Entries: 2, Errors: 1}, s.Snapshot().Encoders["json"],
+		"Expected an entry that fails to encode to count as an error.")
+}
+
+func TestSharedNames(t *testing.T) {
+	s := New()
+	a := zap.New(s.Core("app", zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &zaptest.Discarder{}, zapcore.InfoLevel)))
+	b := zap.New(s.Core("app", zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &zaptest.Discarder{}, zapcore.InfoLevel)))
+	a.Info("one")
+	b.With(zap.String("k", "v")).Info("two")
+
+	assert.Equal(t, CoreStats{Written: 2}, s.Snapshot().Cores["app"][zapcore.InfoLevel], "Expected cores with the same name to share stats.")
+}
+
+func TestPublish(t *testing.T) {
+	s := New()
+	zap.New(s.Core("app", zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &zaptest.Discarder{}, zapcore.InfoLevel))).Info("hi")
+	// expvar names are global, so keep them unique across -count runs.
+	name := fmt.Sprintf("zapstats_test_%p", s)
+	s.Publish(name)
+
+	var snap map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &snap), "Expected a JSON snapshot.")
+	assert.Equal(t, map[string]interface{}{
+		"app": map[string]interface{}{
+			"info": map[string]interface{}{"skipped": 0.0, "written": 1.0, "failed": 0.0},
+		},
+	}, snap["cores"], "Unexpected published cores.")
+}
+
+func TestWritePrometheus(t *testing.T) {
+	s := newTestStats(time.Microsecond, 5*time.Millisecond, 2*time.Second)
+	logger := newTestPipeline(s)
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Info("bad")
+	logger.Debug("skipped")
+
+	var out strings.Builder
+	require.NoError(t, s.WritePrometheus(&out), "Unexpected error writing metrics.")
+	assert.Equal(t, `# HELP zap_entries_total Entries checked by cores, by outcome.
+# TYPE zap_entries_total counter
+zap_entries_total{core="broken",level="info",outcome="skipped"} 2
+zap_entries_total{core="broken",level="info",outcome="written"} 0
+zap_entries_total{core="broken",level="info",outcome="failed"} 0
+zap_entries_total{core="broken",level="warn",outcome="skipped"} 0
+zap_entries_total{core="broken",level="warn",outcome="written"} 0
+zap_entries_total{core="broken",level="warn",outcome="failed"} 1
+zap_entries_total{core="good",level="info",outcome="skipped"} 0
+zap_entries_total{core="good",level="info",outcome="written"} 1
+zap_entries_total{core="good",level="info",outcome="failed"} 1
+zap_entries_total{core="good",level="warn",outcome="skipped"} 0
+zap_entries_total{core="good",level="warn",outcome="written"} 1
+zap_entries_total{core="good",level="warn",outcome="failed"} 0
+# HELP zap_sampler_decisions_total Sampler decisions, by level.
+# TYPE zap_sampler_decisions_total counter
+zap_sampler_decisions_total{sampler="sampler",level="info",decision="sampled"} 2
+zap_sampler_decisions_total{sampler="sampler",level="info",decision="dropped"} 0
+zap_sampler_decisions_total{sampler="sampler",level="warn",decision="sampled"} 1
+zap_sampler_decisions_total{sampler="sampler",level="warn",decision="dropped"} 0
+# HELP zap_encoder_entries_total Entries encoded.
+# TYPE zap_encoder_entries_total counter
+zap_encoder_entries_total{encoder="json"} 4
+# HELP zap_encoder_errors_total Entries for which encoding returned an error.
+# TYPE zap_encoder_errors_total counter
+zap_encoder_errors_total{encoder="json"} 1
+# HELP zap_sink_writes_total Writes to sinks.
+# TYPE zap_sink_writes_total counter
+zap_sink_writes_total{sink="broken"} 1
+zap_sink_writes_total{sink="good"} 2
+# HELP zap_sink_bytes_total Bytes written to sinks.
+# TYPE zap_sink_bytes_total counter
+zap_sink_bytes_total{sink="broken"} 30
+zap_sink_bytes_total{sink="good"} 60
+# HELP zap_sink_write_errors_total Writes to sinks that failed.
+# TYPE zap_sink_write_errors_total counter
+zap_sink_write_errors_total{sink="broken"} 1
+zap_sink_write_errors_total{sink="good"} 0
+# HELP zap_sink_syncs_total Syncs of sinks.
+# TYPE zap_sink_syncs_total counter
+zap_sink_syncs_total{sink="broken"} 0
+zap_sink_syncs_total{sink="good"} 0
+# HELP zap_sink_sync_errors_total Syncs of sinks that failed.
+# TYPE zap_sink_sync_errors_total counter
+zap_sink_sync_errors_total{sink="broken"} 0
+zap_sink_sync_errors_total{sink="good"} 0
+# HELP zap_sink_write_duration_seconds How long writes to sinks took.
+# TYPE zap_sink_write_duration_seconds histogram
+zap_sink_write_duration_seconds_bucket{sink="broken",le="0.0001"} 0
+zap_sink_write_duration_seconds_bucket{sink="broken",le="0.001"} 0
+zap_sink_write_duration_seconds_bucket{sink="broken",le="0.01"} 0
+zap_sink_write_duration_seconds_bucket{sink="broken",le="0.1"} 0
+zap_sink_write_duration_seconds_bucket{sink="broken",le="1"} 0
+zap_sink_write_duration_seconds_bucket{sink="broken",le="+Inf"} 1
+zap_sink_write_duration_seconds_sum{sink="broken"} 2
+zap_sink_write_duration_seconds_count{sink="broken"} 1
+zap_sink_write_duration_seconds_bucket{sink="good",le="0.0001"} 1
+zap_sink_write_duration_seconds_bucket{sink="good",le="0.001"} 1
+zap_sink_write_duration_seconds_bucket{sink="good",le="0.01"} 2
+zap_sink_write_duration_seconds_bucket{sink="good",le="0.1"} 2
+zap_sink_write_duration_seconds_bucket{sink="good",le="1"} 2
+zap_sink_write_duration_seconds_bucket{sink="good",le="+Inf"} 2
+zap_sink_write_duration_seconds_sum{sink="good"} 0.005001
+zap_sink_write_duration_seconds_count{sink="good"} 2
+`, out.String(), "Unexpected Prometheus output.")
+}
+
+func TestHandler(t *testing.T) {
+	s := New()
+	s.WriteSyncer(`a "quoted"`+"\n"+`\sink`, &zaptest.Discarder{})
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, _prometheusContentType, rec.Header().Get("Content-Type"), "Unexpected content type.")
+	assert.Contains(t, rec.Body.String(), `zap_sink_writes_total{sink="a \"quoted\"\n\\sink"} 0`, "Expected escaped label values.")
+}
+
+func TestUnknownLevels(t *testing.T) {
+	s := New()
+	core := s.Core("app", zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &zaptest.Discarder{}, zapcore.DebugLevel))
+	require.NoError(t, core.Write(zapcore.Entry{Level: zapcore.Level(42)}, nil), "Unexpected error writing.")
+	assert.Empty(t, s.Snapshot().Cores["app"], "Expected levels out of range not to be counted.")
+}
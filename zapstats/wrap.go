@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstats
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core wraps a core to count the entries it skips, writes, and fails to
+// write at each level.
+//
+// Wrap the cores that actually write entries, like those created by
+// zapcore.NewCore, before sampling or teeing them: a wrapped core only
+// checks the level of each entry, and writes every entry it accepts.
+func (s *Stats) Core(name string, core zapcore.Core) zapcore.Core {
+	return &countingCore{Core: core, counts: lookup(s, s.cores, name)}
+}
+
+type countingCore struct {
+	zapcore.Core
+	counts *levelCounters
+}
+
+func (c *countingCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+func (c *countingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &countingCore{Core: c.Core.With(fields), counts: c.counts}
+}
+
+func (c *countingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	c.counts.add(ent.Level, _skipped)
+	return ce
+}
+
+func (c *countingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.Core.Write(ent, fields)
+	if err != nil {
+		c.counts.add(ent.Level, _failed)
+	} else {
+		c.counts.add(ent.Level, _written)
+	}
+	return err
+}
+
+// SamplerHook returns a hook for zapcore.SamplerHook, or the Hook field of
+// zap.SamplingConfig, that counts a sampler's decisions at each level.
+func (s *Stats) SamplerHook(name string) func(zapcore.Entry, zapcore.SamplingDecision) {
+	counts := lookup(s, s.samplers, name)
+	return func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped != 0 {
+			counts.add(ent.Level, _dropped)
+		}
+		if dec&zapcore.LogSampled != 0 {
+			counts.add(ent.Level, _sampled)
+		}
+	}
+}
+
+// Encoder wraps an encoder to count the entries it encodes, and how many
+// of those it fails to encode. Fields that fail to marshal don't fail the
+// entry; see EncoderStats.
+func (s *Stats) Encoder(name string, enc zapcore.Encoder) zapcore.Encoder {
+	return &countingEncoder{Encoder: enc, counts: lookup(s, s.encoders, name)}
+}
+
+type encoderCounters struct {
+	entries atomic.Int64
+	errors  atomic.Int64
+}
+
+type countingEncoder struct {
+	zapcore.Encoder
+	counts *encoderCounters
+}
+
+func (e *countingEncoder) Clone() zapcore.Encoder {
+	return &countingEncoder{Encoder: e.Encoder.Clone(), counts: e.counts}
+}
+
+func (e *countingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf, err := e.Encoder.EncodeEntry(ent, fields)
+	e.counts.entries.Add(1)
+	if err != nil {
+		e.counts.errors.Add(1)
+	}
+	return buf, err
+}
+
+// WriteSyncer wraps a WriteSyncer to count its writes, bytes, syncs, and
+// errors, and to record how long writes take.
+//
+// To measure how long a BufferedWriteSyncer takes to flush, wrap the
+// WriteSyncer it flushes to.
+func (s *Stats) WriteSyncer(name string, ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	return &countingWriteSyncer{ws: ws, counts: lookup(s, s.sinks, name), now: s.now}
+}
+
+type sinkCounters struct {
+	writes      atomic.Int64
+	bytes       atomic.Int64
+	writeErrors atomic.Int64
+	syncs       atomic.Int64
+	syncErrors  atomic.Int64
+
+	latencySum atomic.Int64
+	// latency counts the writes in each of _latencyBuckets, and then those
+	// that took longer.
+	latency [len(_latencyBuckets) + 1]atomic.Int64
+}
+
+func (c *sinkCounters) observe(d time.Duration) {
+	c.latencySum.Add(int64(d))
+	for i, bound := range _latencyBuckets {
+		if d <= bound {
+			c.latency[i].Add(1)
+			return
+		}
+	}
+	c.latency[len(_latencyBuckets)].Add(1)
+}
+
+func (c *sinkCounters) snapshot() SinkStats {
+	stats := SinkStats{
+		Writes:      c.writes.Load(),
+		Bytes:       c.bytes.Load(),
+		WriteErrors: c.writeErrors.Load(),
+		Syncs:       c.syncs.Load(),
+		SyncErrors:  c.syncErrors.Load(),
+	}
+	stats.Latency.Sum = time.Duration(c.latencySum.Load())
+	stats.Latency.Buckets = make([]Bucket, len(_latencyBuckets))
+	var count int64
+	for i, bound := range _latencyBuckets {
+		count += c.latency[i].Load()
+		stats.Latency.Buckets[i] = Bucket{UpperBound: bound, Count: count}
+	}
+	stats.Latency.Count = count + c.latency[len(_latencyBuckets)].Load()
+	return stats
+}
+
+type countingWriteSyncer struct {
+	ws     zapcore.WriteSyncer
+	counts *sinkCounters
+	now    func() time.Time
+}
+
+func (w *countingWriteSyncer) Write(p []byte) (int, error) {
+	start := w.now()
+	n, err := w.ws.Write(p)
+	w.counts.observe(w.now().Sub(start))
+	w.counts.writes.Add(1)
+	w.counts.bytes.Add(int64(n))
+	if err != nil {
+		w.counts.writeErrors.Add(1)
+	}
+	return n, err
+}
+
+func (w *countingWriteSyncer) Sync() error {
+	err := w.ws.Sync()
+	w.counts.syncs.Add(1)
+	if err != nil {
+		w.counts.syncErrors.Add(1)
+	}
+	return err
+}
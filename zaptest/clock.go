@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"time"
+
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+// MockClock is a fake source of time that implements zapcore.Clock. Time
+// only passes when the test calls its Add or AdvanceTo methods, which fire
+// any tickers created with NewTicker that fall due, in chronological order.
+//
+// Use it with the Clock option of NewLogger, zap.WithClock, or the Clock
+// field of zapcore.BufferedWriteSyncer to test time-dependent behavior, such
+// as sampling and periodic flushing, without sleeping.
+//
+//	clock := zaptest.NewMockClock()
+//	ws := &zapcore.BufferedWriteSyncer{WS: out, Clock: clock}
+//	...
+//	clock.Add(30 * time.Second) // flushes ws
+type MockClock = ztest.MockClock
+
+var _ zapcore.Clock = (*MockClock)(nil)
+
+// NewMockClock builds a new MockClock using the current actual time as the
+// initial time.
+func NewMockClock() *MockClock {
+	return ztest.NewMockClock()
+}
+
+// NewMockClockAt builds a new MockClock using the given time as the initial
+// time. Use this when the time appears in log output that tests compare.
+func NewMockClockAt(t time.Time) *MockClock {
+	return ztest.NewMockClockAt(t)
+}
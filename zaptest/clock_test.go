@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTestLoggerSupportsClock(t *testing.T) {
+	ts := newTestLogSpy(t)
+	defer ts.AssertPassed()
+
+	clock := NewMockClock()
+	log := NewLogger(ts, Clock(clock), WrapOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		// Log the first entry with each message every second.
+		return zapcore.NewSamplerWithOptions(core, time.Second, 1, 0)
+	})))
+
+	log.Info("tick")
+	log.Info("tick")
+	clock.Add(time.Second)
+	log.Info("tick")
+
+	ts.AssertMessages("INFO	tick", "INFO	tick")
+}
+
+func TestMockClockAt(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewMockClockAt(start)
+	assert.Equal(t, start, clock.Now(), "Unexpected initial time.")
+
+	clock.AdvanceTo(start.Add(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), clock.Now(), "Unexpected time after advancing.")
+}
+
+// chanWriter sends each write to a channel.
+type chanWriter chan string
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+func TestMockClockFlushesBufferedWriteSyncer(t *testing.T) {
+	clock := NewMockClock()
+	out := make(chanWriter, 1)
+	ws := &zapcore.BufferedWriteSyncer{
+		WS:            zapcore.AddSync(out),
+		FlushInterval: time.Minute,
+		Clock:         clock,
+	}
+	defer func() { assert.NoError(t, ws.Stop(), "Unexpected error stopping buffered syncer.") }()
+
+	_, err := ws.Write([]byte("foo\n"))
+	assert.NoError(t, err, "Unexpected error writing.")
+	assert.Empty(t, out, "Expected the write to be buffered.")
+
+	clock.Add(time.Minute)
+	select {
+	case got := <-out:
+		assert.Equal(t, "foo\n", got, "Unexpected flushed output.")
+	case <-time.After(time.Second):
+		t.Fatal("Expected the buffer to be flushed when the clock ticks.")
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encodertest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// lenientEqual is the default Suite.Equal. See its documentation.
+func lenientEqual(want, got interface{}) bool {
+	got = normalizeNumber(got)
+	switch w := want.(type) {
+	case nil:
+		return got == nil
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !lenientEqual(wv, gv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !lenientEqual(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		return stringEqual(w, got)
+	case bool:
+		g, ok := got.(bool)
+		return ok && g == w
+	case []byte:
+		switch g := got.(type) {
+		case []byte:
+			return bytes.Equal(g, w)
+		case string:
+			return g == base64.StdEncoding.EncodeToString(w)
+		}
+		return false
+	case time.Time:
+		return timeEqual(w, got)
+	case time.Duration:
+		return durationEqual(w, got)
+	case complex128:
+		return complexEqual(w, got, 64)
+	case complex64:
+		return complexEqual(complex128(w), got, 32)
+	case float64:
+		return floatEqual(w, got, 64)
+	case float32:
+		return floatEqual(float64(w), got, 32)
+	case json.Number:
+		return lenientEqual(normalizeNumber(w), got)
+	}
+	if isNumber(want) {
+		return numbersEqual(want, got)
+	}
+
+	// Reflected values are compared with their JSON representation.
+	b, err := json.Marshal(want)
+	if err != nil {
+		return reflect.DeepEqual(want, got)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return reflect.DeepEqual(want, got)
+	}
+	return lenientEqual(normalizeJSON(v), got)
+}
+
+// normalizeJSON converts the json.Numbers in a decoded JSON value.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	}
+	return normalizeNumber(v)
+}
+
+// normalizeNumber converts a json.Number to an int64, uint64 or float64.
+func normalizeNumber(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u
+	}
+	if f, err := strconv.ParseFloat(string(n), 64); err == nil {
+		return f
+	}
+	return v
+}
+
+func stringEqual(want string, got interface{}) bool {
+	var g string
+	switch v := got.(type) {
+	case string:
+		g = v
+	case []byte:
+		g = string(v)
+	default:
+		return false
+	}
+	if g == want || utf8.ValidString(want) {
+		return g == want
+	}
+	// Encoders may replace invalid UTF-8 with U+FFFD either per byte or per
+	// run of bytes.
+	return g == strings.ToValidUTF8(want, "\uFFFD") || g == replaceInvalidBytes(want)
+}
+
+func replaceInvalidBytes(s string) string {
+	var sb strings.Builder
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && size == 1 {
+			sb.WriteString("\uFFFD")
+		} else {
+			sb.WriteString(s[:size])
+		}
+		s = s[size:]
+	}
+	return sb.String()
+}
+
+// floatEqual compares floats with the given precision. NaNs match each
+// other, and special values may be strings.
+func floatEqual(want float64, got interface{}, bits int) bool {
+	if s, ok := got.(string); ok {
+		switch {
+		case math.IsNaN(want):
+			return strings.EqualFold(s, "NaN")
+		case math.IsInf(want, 1):
+			return s == "+Inf" || s == "Inf" || s == "inf" || s == "+inf" || s == "Infinity"
+		case math.IsInf(want, -1):
+			return s == "-Inf" || s == "-inf" || s == "-Infinity"
+		}
+		return false
+	}
+	g, ok := toFloat(got)
+	if !ok {
+		return false
+	}
+	if math.IsNaN(want) {
+		return math.IsNaN(g)
+	}
+	if bits == 32 {
+		return float32(g) == float32(want)
+	}
+	return g == want
+}
+
+func complexEqual(want complex128, got interface{}, bits int) bool {
+	switch g := got.(type) {
+	case string:
+		c, err := strconv.ParseComplex(g, 128)
+		return err == nil && floatEqual(real(want), real(c), bits) && floatEqual(imag(want), imag(c), bits)
+	case []interface{}:
+		return len(g) == 2 && floatEqual(real(want), normalizeNumber(g[0]), bits) && floatEqual(imag(want), normalizeNumber(g[1]), bits)
+	}
+	return false
+}
+
+var _timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700"}
+
+func timeEqual(want time.Time, got interface{}) bool {
+	switch g := got.(type) {
+	case time.Time:
+		return g.Equal(want)
+	case string:
+		for _, layout := range _timeLayouts {
+			if t, err := time.Parse(layout, g); err == nil && t.Equal(want) {
+				return true
+			}
+		}
+		return false
+	}
+	secs := float64(want.Unix()) + float64(want.Nanosecond())/1e9
+	return scaledEqual(secs, got)
+}
+
+func durationEqual(want time.Duration, got interface{}) bool {
+	switch g := got.(type) {
+	case time.Duration:
+		return g == want
+	case string:
+		d, err := time.ParseDuration(g)
+		return err == nil && d == want
+	}
+	return scaledEqual(want.Seconds(), got)
+}
+
+// scaledEqual reports whether got is a number equal to the given number of
+// seconds, expressed in seconds, milliseconds, or nanoseconds.
+func scaledEqual(secs float64, got interface{}) bool {
+	g, ok := toFloat(got)
+	if !ok {
+		return false
+	}
+	for _, scale := range []float64{1, 1e3, 1e9} {
+		want := secs * scale
+		// Allow for rounding in float64 seconds since the epoch, which have
+		// microsecond precision.
+		if math.Abs(g-want) <= 1e-15*math.Max(1, math.Abs(want)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// numbersEqual compares two numbers of any type by value.
+func numbersEqual(want, got interface{}) bool {
+	if !isNumber(got) {
+		return false
+	}
+	wv, gv := reflect.ValueOf(want), reflect.ValueOf(got)
+	wi, wSigned := asInt(wv)
+	gi, gSigned := asInt(gv)
+	if wSigned && gSigned {
+		return wi == gi
+	}
+	wu, wUnsigned := asUint(wv)
+	gu, gUnsigned := asUint(gv)
+	if wUnsigned && gUnsigned {
+		return wu == gu
+	}
+	wf, _ := toFloat(want)
+	gf, _ := toFloat(got)
+	return wf == gf
+}
+
+// asInt returns the value of v if it's an integer that fits in an int64.
+func asInt(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	}
+	return 0, false
+}
+
+// asUint returns the value of v if it's a non-negative integer.
+func asUint(v reflect.Value) (uint64, bool) {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := v.Int(); i >= 0 {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	if !isNumber(v) {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	}
+	return float64(rv.Int()), true
+}
@@ -0,0 +1,421 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package encodertest checks that zapcore.Encoder implementations behave
+// like the encoders built into zap.
+//
+// It's separate from package zaptest so that zaptest doesn't depend on the
+// testing package.
+package encodertest // import "go.uber.org/zap/zaptest/encodertest"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+// Suite checks that an Encoder implements the semantics of
+// zapcore.ObjectEncoder and zapcore.ArrayEncoder: every field type, nested
+// objects, arrays and namespaces, inline and skipped fields, edge cases like
+// NaN and invalid UTF-8, fields added to the encoder as context, and the
+// isolation of clones, including clones used concurrently.
+//
+// Each case is encoded with EncodeEntry and compared against the fields that
+// a zapcore.MapObjectEncoder builds from the same input, either by decoding
+// the output with Decode or by handing it to Check.
+//
+//	func TestEncoderConformance(t *testing.T) {
+//		encodertest.Suite{
+//			NewEncoder: func() zapcore.Encoder { return newMyEncoder(cfg) },
+//			Decode:     decodeMyFormat,
+//		}.Run(t)
+//	}
+type Suite struct {
+	// NewEncoder builds an empty instance of the encoder under test.
+	// Required.
+	NewEncoder func() zapcore.Encoder
+
+	// Decode parses the output of one EncodeEntry call into a map of its
+	// top-level keys, with nested objects as map[string]interface{} and
+	// arrays as []interface{}. The keys that the encoder writes for the
+	// entry itself, such as its message, are found by encoding an entry
+	// without fields and ignored.
+	//
+	// Exactly one of Decode and Check must be set.
+	Decode func([]byte) (map[string]interface{}, error)
+
+	// Check verifies the output of one EncodeEntry call itself, failing t
+	// if it doesn't hold the fields in want. The output includes the entry
+	// passed to EncodeEntry, while want only holds the fields, as built by a
+	// zapcore.MapObjectEncoder.
+	Check func(t zaptest.TestingT, want map[string]interface{}, output []byte)
+
+	// Equal reports whether a decoded value matches the value in the
+	// zapcore.MapObjectEncoder for the same field. It's only used with
+	// Decode. Defaults to a comparison that accepts the common ways of
+	// encoding each type:
+	//
+	//   - numbers of any type with the same value, including json.Number;
+	//   - strings, or []byte holding the same bytes; invalid UTF-8 may be
+	//     replaced with U+FFFD;
+	//   - NaN and infinite floats, or strings like "NaN" and "-Inf";
+	//   - []byte from Binary fields, or their standard base64 encoding;
+	//   - complex numbers as arrays of their parts or strings like "1+2i";
+	//   - times as time.Time, RFC 3339 strings, or seconds, milliseconds or
+	//     nanoseconds since the Unix epoch;
+	//   - durations as time.Duration, strings like "1.5s", or seconds,
+	//     milliseconds or nanoseconds;
+	//   - reflected values, compared with their encoding/json
+	//     representation.
+	Equal func(want, got interface{}) bool
+
+	// Skip lists the names of cases to skip, for limitations of the encoder
+	// that are known and accepted. For example, the epoch time encoders
+	// can't represent the times after 2262 used by the "TimeFull" case.
+	Skip []string
+}
+
+// Run runs the suite as subtests of t.
+func (s Suite) Run(t *testing.T) {
+	t.Helper()
+	if s.NewEncoder == nil || (s.Decode == nil) == (s.Check == nil) {
+		t.Fatal("Suite needs NewEncoder and exactly one of Decode and Check")
+	}
+	if s.Equal == nil {
+		s.Equal = lenientEqual
+	}
+
+	var metadata map[string]struct{}
+	if s.Decode != nil {
+		baseline, err := s.Decode(s.encode(s.NewEncoder(), nil))
+		if err != nil {
+			t.Fatalf("can't decode entry without fields: %v", err)
+		}
+		metadata = make(map[string]struct{}, len(baseline))
+		for k := range baseline {
+			metadata[k] = struct{}{}
+		}
+	}
+
+	for _, tc := range encoderCases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range s.Skip {
+				if name == tc.name {
+					t.Skip("skipped by Suite.Skip")
+				}
+			}
+
+			want := mapFields(tc.fields...)
+			split := len(tc.fields) / 2
+			variants := []struct {
+				name           string
+				context, entry []zapcore.Field
+			}{
+				{"fields", nil, tc.fields},
+				{"context", tc.fields, nil},
+				{"split", tc.fields[:split], tc.fields[split:]},
+			}
+			for _, v := range variants {
+				enc := s.NewEncoder()
+				for _, f := range v.context {
+					f.AddTo(enc)
+				}
+				s.verify(&prefixT{t, v.name + ": "}, metadata, want, s.encode(enc, v.entry))
+			}
+		})
+	}
+
+	t.Run("EncodeEntryLeavesEncoderUnchanged", func(t *testing.T) {
+		enc := s.NewEncoder()
+		enc.AddString("context", "value")
+		s.encode(enc, []zapcore.Field{zap.Namespace("ns"), zap.String("entry", "value")})
+		s.verify(t, metadata, mapFields(zap.String("context", "value")), s.encode(enc, nil))
+	})
+
+	t.Run("CloneIsolation", func(t *testing.T) {
+		enc := s.NewEncoder()
+		enc.AddString("shared", "value")
+		clone := enc.Clone()
+		clone.AddString("clone", "value")
+		enc.AddString("original", "value")
+		clone.OpenNamespace("ns")
+		clone.AddString("nested", "value")
+
+		s.verify(&prefixT{t, "original: "}, metadata, mapFields(
+			zap.String("shared", "value"),
+			zap.String("original", "value"),
+		), s.encode(enc, nil))
+		s.verify(&prefixT{t, "clone: "}, metadata, mapFields(
+			zap.String("shared", "value"),
+			zap.String("clone", "value"),
+			zap.Namespace("ns"),
+			zap.String("nested", "value"),
+		), s.encode(clone, nil))
+	})
+
+	t.Run("ConcurrentClones", func(t *testing.T) {
+		const goroutines, iterations = 8, 20
+		base := s.NewEncoder()
+		base.AddString("base", "value")
+
+		var (
+			wg      sync.WaitGroup
+			outputs [goroutines][iterations][]byte
+		)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					clone := base.Clone()
+					clone.AddInt("goroutine", g)
+					outputs[g][i] = s.encode(clone, []zapcore.Field{zap.Int("iteration", i)})
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		for g := range outputs {
+			for i, out := range outputs[g] {
+				s.verify(&prefixT{t, fmt.Sprintf("goroutine %d, iteration %d: ", g, i)}, metadata, mapFields(
+					zap.String("base", "value"),
+					zap.Int("goroutine", g),
+					zap.Int("iteration", i),
+				), out)
+			}
+		}
+	})
+}
+
+var _suiteEntry = zapcore.Entry{
+	Level:      zapcore.InfoLevel,
+	Time:       time.Unix(1000000000, 0).UTC(),
+	LoggerName: "conformance",
+	Message:    "conformance test",
+}
+
+// encode encodes the suite's entry with the given fields and returns a copy
+// of the output.
+func (s Suite) encode(enc zapcore.Encoder, fields []zapcore.Field) []byte {
+	buf, err := enc.EncodeEntry(_suiteEntry, fields)
+	if err != nil {
+		return []byte(fmt.Sprintf("EncodeEntry failed: %v", err))
+	}
+	out := append([]byte(nil), buf.Bytes()...)
+	buf.Free()
+	return out
+}
+
+// verify checks that output holds the fields in want, ignoring the given
+// metadata keys.
+func (s Suite) verify(t zaptest.TestingT, metadata map[string]struct{}, want map[string]interface{}, output []byte) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if s.Check != nil {
+		s.Check(t, want, output)
+		return
+	}
+
+	got, err := s.Decode(output)
+	if err != nil {
+		t.Errorf("can't decode output %q: %v", output, err)
+		return
+	}
+	for k := range metadata {
+		delete(got, k)
+	}
+	for _, k := range sortedKeys(want) {
+		g, ok := got[k]
+		switch {
+		case !ok:
+			t.Errorf("field %q is missing from output %q", k, output)
+		case !s.Equal(want[k], g):
+			t.Errorf("field %q is %#v, want %#v, in output %q", k, g, want[k], output)
+		}
+	}
+	for _, k := range sortedKeys(got) {
+		if _, ok := want[k]; !ok {
+			t.Errorf("unexpected field %q in output %q", k, output)
+		}
+	}
+}
+
+// prefixT adds a prefix to failure messages.
+type prefixT struct {
+	zaptest.TestingT
+
+	prefix string
+}
+
+func (t *prefixT) Errorf(format string, args ...interface{}) {
+	t.TestingT.Errorf("%s%s", t.prefix, fmt.Sprintf(format, args...))
+}
+
+func mapFields(fields ...zapcore.Field) map[string]interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type encoderCase struct {
+	name   string
+	fields []zapcore.Field
+}
+
+func encoderCases() []encoderCase {
+	c := func(name string, fields ...zapcore.Field) encoderCase {
+		return encoderCase{name, fields}
+	}
+	return []encoderCase{
+		c("Binary", zap.Binary("k", []byte{0, 1, 0xff})),
+		c("Bool", zap.Bool("k", true)),
+		c("ByteString", zap.ByteString("k", []byte("bytes"))),
+		c("Complex128", zap.Complex128("k", 1+2i)),
+		c("Complex64", zap.Complex64("k", -1.5+0.5i)),
+		c("Duration", zap.Duration("k", 1500*time.Millisecond)),
+		c("Float64", zap.Float64("k", 3.25)),
+		c("Float32", zap.Float32("k", 1.1)),
+		c("Int64", zap.Int64("k", math.MinInt64)),
+		c("Int32", zap.Int32("k", math.MaxInt32)),
+		c("Int16", zap.Int16("k", math.MinInt16)),
+		c("Int8", zap.Int8("k", math.MaxInt8)),
+		c("String", zap.String("k", "value")),
+		c("Time", zap.Time("k", time.Unix(1000000000, 500000000).UTC())),
+		c("TimeFull", zap.Time("k", time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC))),
+		c("Uint64", zap.Uint64("k", math.MaxUint64)),
+		c("Uint32", zap.Uint32("k", math.MaxUint32)),
+		c("Uint16", zap.Uint16("k", math.MaxUint16)),
+		c("Uint8", zap.Uint8("k", math.MaxUint8)),
+		c("Uintptr", zap.Uintptr("k", 0xdeadbeef)),
+		c("Reflect", zap.Reflect("k", struct {
+			Name string   `json:"name"`
+			Tags []string `json:"tags"`
+		}{"reflected", []string{"a", "b"}})),
+		c("ReflectNil", zap.Reflect("k", nil)),
+		c("Stringer", zap.Stringer("k", time.Second)),
+		c("Error", zap.Error(errors.New("boom"))),
+		c("NamedError", zap.NamedError("k", fmt.Errorf("wrapped: %w", errors.New("boom")))),
+		c("Errors", zap.Errors("k", []error{errors.New("a"), errors.New("b")})),
+		c("Skip", zap.Skip(), zap.String("k", "value"), zap.Error(nil)),
+		c("Namespace", zap.String("outer", "value"), zap.Namespace("ns"), zap.String("k", "value")),
+		c("EmptyNamespace", zap.String("outer", "value"), zap.Namespace("ns")),
+		c("NestedNamespaces",
+			zap.String("a", "1"),
+			zap.Namespace("ns1"),
+			zap.String("b", "2"),
+			zap.Namespace("ns2"),
+			zap.String("c", "3"),
+		),
+		c("Array", zap.Ints("k", []int{1, 2, 3})),
+		c("EmptyArray", zap.Strings("k", nil)),
+		c("NestedArrays", zap.Array("k", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			enc.AppendString("first")
+			if err := enc.AppendArray(zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+				enc.AppendInt(1)
+				return enc.AppendArray(zapcore.ArrayMarshalerFunc(func(zapcore.ArrayEncoder) error { return nil }))
+			})); err != nil {
+				return err
+			}
+			return enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddBool("inArray", true)
+				return nil
+			}))
+		}))),
+		c("Object", zap.Object("k", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("name", "value")
+			if err := enc.AddArray("list", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+				enc.AppendFloat64(0.5)
+				return nil
+			})); err != nil {
+				return err
+			}
+			return enc.AddObject("nested", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddUint8("n", 7)
+				return nil
+			}))
+		}))),
+		c("ObjectWithNamespace",
+			zap.Object("k", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("before", "value")
+				enc.OpenNamespace("ns")
+				enc.AddString("inside", "value")
+				return nil
+			})),
+			zap.String("after", "value"),
+		),
+		c("ObjectError", zap.Object("k", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("partial", "value")
+			return errors.New("marshal failed")
+		}))),
+		c("Inline", zap.String("outer", "value"), zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("inlined", "value")
+			enc.AddInt("n", 1)
+			return nil
+		}))),
+		c("Dict", zap.Dict("k", zap.String("a", "b"), zap.Dict("nested", zap.Int("n", 1)))),
+		c("Objects", zap.Objects("k", []zapcore.ObjectMarshaler{
+			zap.DictObject(zap.Int("n", 1)),
+			zap.DictObject(zap.Int("n", 2)),
+		})),
+		c("NaN", zap.Float64("k", math.NaN())),
+		c("PositiveInfinity", zap.Float64("k", math.Inf(1))),
+		c("NegativeInfinity", zap.Float64("k", math.Inf(-1))),
+		c("Float32NaN", zap.Float32("k", float32(math.NaN()))),
+		c("Float32Infinity", zap.Float32("k", float32(math.Inf(1)))),
+		c("NegativeZero", zap.Float64("k", math.Copysign(0, -1))),
+		c("InvalidUTF8", zap.String("k", "a\xffb\xc0")),
+		c("InvalidUTF8ByteString", zap.ByteString("k", []byte("a\xffb"))),
+		c("ControlCharacters", zap.String("k", "\x00\x1f\n\r\t\"\\ ")),
+		c("Unicode", zap.String("k", "☃ 😀 é")),
+		c("EscapedKeys", zap.String("quote\"key", "value"), zap.String("new\nline", "value"), zap.String("☃", "value")),
+		c("EmptyKey", zap.String("", "value")),
+		c("EmptyString", zap.String("k", "")),
+		c("LongString", zap.String("k", string(make([]byte, 4096)))),
+		c("ManyFields",
+			zap.String("a", "1"),
+			zap.Int("b", 2),
+			zap.Bool("c", false),
+			zap.Float64("d", -4.5),
+			zap.Strings("e", []string{"x", "y"}),
+			zap.Duration("f", time.Minute),
+		),
+	}
+}
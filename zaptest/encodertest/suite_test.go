@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encodertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+func decodeJSON(b []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var m map[string]interface{}
+	err := dec.Decode(&m)
+	return m, err
+}
+
+func TestSuite(t *testing.T) {
+	// Epoch time encoders overflow for times after 2262.
+	epochTimes := []string{"TimeFull"}
+
+	epochMillis := zap.NewProductionEncoderConfig()
+	epochMillis.EncodeTime = zapcore.EpochMillisTimeEncoder
+	epochMillis.EncodeDuration = zapcore.MillisDurationEncoder
+
+	tests := []struct {
+		name  string
+		suite Suite
+	}{
+		{
+			name: "json",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()) },
+				Decode:     decodeJSON,
+				Skip:       epochTimes,
+			},
+		},
+		{
+			name: "json with development config",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewJSONEncoder(zap.NewDevelopmentEncoderConfig()) },
+				Decode:     decodeJSON,
+			},
+		},
+		{
+			name: "json with epoch millis",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewJSONEncoder(epochMillis) },
+				Decode:     decodeJSON,
+				Skip:       epochTimes,
+			},
+		},
+		{
+			name: "console",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()) },
+				Decode: func(b []byte) (map[string]interface{}, error) {
+					// The context follows the metadata as a JSON object.
+					i := bytes.Index(b, []byte("\t{"))
+					if i < 0 {
+						return map[string]interface{}{}, nil
+					}
+					return decodeJSON(b[i+1:])
+				},
+			},
+		},
+		{
+			name: "cbor",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewCBOREncoder(zap.NewProductionEncoderConfig()) },
+				Decode: func(b []byte) (map[string]interface{}, error) {
+					return zapcore.NewCBORDecoder(bytes.NewReader(b)).Decode()
+				},
+			},
+		},
+		{
+			name: "msgpack",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewMsgpackEncoder(zap.NewProductionEncoderConfig()) },
+				Decode: func(b []byte) (map[string]interface{}, error) {
+					return zapcore.NewMsgpackDecoder(bytes.NewReader(b)).Decode()
+				},
+			},
+		},
+		{
+			name: "check",
+			suite: Suite{
+				NewEncoder: func() zapcore.Encoder { return zapcore.NewJSONEncoder(zapcore.EncoderConfig{}) },
+				Check: func(t zaptest.TestingT, want map[string]interface{}, output []byte) {
+					if !json.Valid(output) {
+						t.Errorf("invalid JSON %q", output)
+					}
+					for k := range want {
+						if !bytes.Contains(output, []byte(fmt.Sprintf("%q:", k))) && !strings.ContainsAny(k, "\"\n☃") {
+							t.Errorf("missing key %q in %q", k, output)
+						}
+					}
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.suite.Run)
+	}
+}
+
+func TestLenientEqual(t *testing.T) {
+	t0 := time.Unix(1000000000, 500000000).UTC()
+	tests := []struct {
+		desc      string
+		want, got interface{}
+		equal     bool
+	}{
+		{"nil", nil, nil, true},
+		{"not nil", nil, "x", false},
+		{"json number", int64(42), json.Number("42"), true},
+		{"big json number", uint64(math.MaxUint64), json.Number("18446744073709551615"), true},
+		{"int and uint", int64(7), uint64(7), true},
+		{"negative and uint", int64(-1), uint64(math.MaxUint64), false},
+		{"int and float", 3, 3.0, true},
+		{"float32", float32(1.1), 1.1, true},
+		{"float32 mismatch", float32(1.1), 1.2, false},
+		{"float64", 1.1, float64(float32(1.1)), false},
+		{"NaN", math.NaN(), math.NaN(), true},
+		{"NaN string", math.NaN(), "NaN", true},
+		{"infinity string", math.Inf(1), "+Inf", true},
+		{"negative infinity string", math.Inf(-1), "-Inf", true},
+		{"other string for float", 1.0, "1", false},
+		{"string", "a", "a", true},
+		{"string as bytes", "a", []byte("a"), true},
+		{"invalid UTF-8 per byte", "a\xff\xfe", "a��", true},
+		{"invalid UTF-8 per run", "a\xff\xfe", "a�", true},
+		{"valid UTF-8 mismatch", "a", "a�", false},
+		{"bool", true, true, true},
+		{"bool mismatch", true, "true", false},
+		{"binary", []byte{1, 2}, []byte{1, 2}, true},
+		{"base64", []byte{1, 2}, "AQI=", true},
+		{"complex string", 1 + 2i, "1+2i", true},
+		{"complex array", complex64(1 + 2i), []interface{}{float32(1), float32(2)}, true},
+		{"complex mismatch", 1 + 2i, "1+3i", false},
+		{"time", t0, t0.In(time.FixedZone("x", 3600)), true},
+		{"time string", t0, "2001-09-09T01:46:40.500Z", true},
+		{"time seconds", t0, 1000000000.5, true},
+		{"time millis", t0, json.Number("1000000000500"), true},
+		{"time nanos", t0, int64(1000000000500000000), true},
+		{"time mismatch", t0, 1000000001.5, false},
+		{"duration", time.Second, time.Second, true},
+		{"duration string", 1500 * time.Millisecond, "1.5s", true},
+		{"duration seconds", 1500 * time.Millisecond, 1.5, true},
+		{"duration nanos", 1500 * time.Millisecond, int64(1500000000), true},
+		{"map", map[string]interface{}{"a": int64(1)}, map[string]interface{}{"a": 1.0}, true},
+		{"map with extra keys", map[string]interface{}{}, map[string]interface{}{"a": 1}, false},
+		{"array", []interface{}{"a"}, []interface{}{"a"}, true},
+		{"array length", []interface{}{"a"}, []interface{}{"a", "b"}, false},
+		{"reflected", struct{ A int }{1}, map[string]interface{}{"A": int64(1)}, true},
+		{"reflected mismatch", struct{ A int }{1}, map[string]interface{}{"A": int64(2)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.equal, lenientEqual(tt.want, tt.got), "Unexpected result comparing %#v to %#v.", tt.got, tt.want)
+		})
+	}
+}
+
+// failureSpy is a zaptest.TestingT that records failures.
+type failureSpy struct {
+	zaptest.TestingT
+
+	failures []string
+}
+
+func (s *failureSpy) Errorf(format string, args ...interface{}) {
+	s.failures = append(s.failures, fmt.Sprintf(format, args...))
+}
+
+func TestSuiteVerify(t *testing.T) {
+	s := Suite{Decode: decodeJSON, Equal: lenientEqual}
+	metadata := map[string]struct{}{"msg": {}}
+	want := map[string]interface{}{"a": "x", "b": int64(1)}
+
+	tests := []struct {
+		desc     string
+		output   string
+		failures []string
+	}{
+		{"match", `{"msg":"hi","a":"x","b":1}`, nil},
+		{"missing", `{"msg":"hi","a":"x"}`, []string{`field "b" is missing from output "{\"msg\":\"hi\",\"a\":\"x\"}"`}},
+		{"mismatch", `{"a":"y","b":1}`, []string{`field "a" is "y", want "x", in output "{\"a\":\"y\",\"b\":1}"`}},
+		{"unexpected", `{"a":"x","b":1,"c":2}`, []string{`unexpected field "c" in output "{\"a\":\"x\",\"b\":1,\"c\":2}"`}},
+		{"invalid", `{`, []string{`can't decode output "{": unexpected EOF`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			spy := &failureSpy{}
+			s.verify(spy, metadata, want, []byte(tt.output))
+			assert.Equal(t, tt.failures, spy.failures, "Unexpected failures.")
+		})
+	}
+}
+
+// sharingEncoder is a broken Encoder whose clones share their context.
+type sharingEncoder struct{ zapcore.Encoder }
+
+func (e sharingEncoder) Clone() zapcore.Encoder { return e }
+
+func TestSuiteDetectsSharedClones(t *testing.T) {
+	base := sharingEncoder{zapcore.NewJSONEncoder(zapcore.EncoderConfig{})}
+	clone := base.Clone()
+	clone.AddString("clone", "value")
+
+	spy := &failureSpy{}
+	s := Suite{Decode: decodeJSON, Equal: lenientEqual}
+	s.verify(spy, nil, map[string]interface{}{}, s.encode(base, nil))
+	assert.Equal(t, []string{`unexpected field "clone" in output "{\"clone\":\"value\"}\n"`}, spy.failures, "Expected the shared context to be detected.")
+}
+
+func TestSuiteEncodeError(t *testing.T) {
+	s := Suite{}
+	out := s.encode(failingEncoder{}, nil)
+	assert.Equal(t, "EncodeEntry failed: can't encode", string(out), "Unexpected output for a failing encoder.")
+}
+
+type failingEncoder struct{ zapcore.Encoder }
+
+func (failingEncoder) EncodeEntry(zapcore.Entry, []zapcore.Field) (*buffer.Buffer, error) {
+	return nil, errors.New("can't encode")
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"bytes"
+	"flag"
+	"sync"
+)
+
+var _showLogs = flag.Bool("zaptest.showlogs", false,
+	"write output buffered by zaptest.BufferUntilFailure even if the test passes")
+
+type cleanuper interface {
+	Cleanup(func())
+}
+
+// failureBuffer is a WriteSyncer that holds on to the lines written to it
+// until the test completes, and then writes them to the test log if the test
+// failed.
+type failureBuffer struct {
+	t        TestingT
+	maxBytes int
+
+	mu           sync.Mutex
+	lines        []string
+	size         int // total length of lines
+	droppedLines int
+	droppedBytes int
+}
+
+func newFailureBuffer(t TestingT, c cleanuper, maxBytes int) *failureBuffer {
+	b := &failureBuffer{t: t, maxBytes: maxBytes}
+	c.Cleanup(b.flush)
+	return b
+}
+
+func (b *failureBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	// Strip trailing newline because t.Log always adds one.
+	line := string(bytes.TrimRight(p, "\n"))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines = append(b.lines, line)
+	b.size += len(line)
+	if b.maxBytes <= 0 {
+		return n, nil
+	}
+
+	// Drop the oldest lines to stay under the cap, but always keep the
+	// latest.
+	var drop int
+	for b.size > b.maxBytes && drop < len(b.lines)-1 {
+		b.size -= len(b.lines[drop])
+		b.droppedBytes += len(b.lines[drop])
+		drop++
+	}
+	if drop > 0 {
+		b.droppedLines += drop
+		b.lines = append(b.lines[:0], b.lines[drop:]...)
+	}
+	return n, nil
+}
+
+func (b *failureBuffer) Sync() error {
+	return nil
+}
+
+// flush writes the buffered lines to the test log if the test failed or
+// -zaptest.showlogs is set.
+func (b *failureBuffer) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.t.Failed() && !*_showLogs {
+		return
+	}
+	if b.droppedLines > 0 {
+		b.t.Logf("... %d earlier lines (%d bytes) of log output truncated ...", b.droppedLines, b.droppedBytes)
+	}
+	for _, line := range b.lines {
+		b.t.Logf("%s", line)
+	}
+	b.lines = nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cleanupSpy is a testLogSpy that runs cleanup functions on demand.
+type cleanupSpy struct {
+	*testLogSpy
+
+	cleanups []func()
+}
+
+func newCleanupSpy(t testing.TB) *cleanupSpy {
+	return &cleanupSpy{testLogSpy: newTestLogSpy(t)}
+}
+
+func (s *cleanupSpy) Cleanup(f func()) {
+	s.cleanups = append(s.cleanups, f)
+}
+
+func (s *cleanupSpy) finish() {
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		s.cleanups[i]()
+	}
+	s.cleanups = nil
+}
+
+func TestBufferUntilFailure(t *testing.T) {
+	t.Run("passed", func(t *testing.T) {
+		ts := newCleanupSpy(t)
+		log := NewLogger(ts, BufferUntilFailure(0))
+		log.Info("received work order")
+		ts.finish()
+
+		ts.AssertMessages()
+		ts.AssertPassed()
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		ts := newCleanupSpy(t)
+		log := NewLogger(ts, BufferUntilFailure(0))
+		log.Info("received work order")
+		log.Warn("work may fail")
+		ts.AssertMessages()
+
+		ts.Fail()
+		ts.finish()
+		ts.AssertMessages(
+			"INFO	received work order",
+			"WARN	work may fail",
+		)
+	})
+
+	t.Run("showlogs", func(t *testing.T) {
+		defer func(show bool) { *_showLogs = show }(*_showLogs)
+		*_showLogs = true
+
+		ts := newCleanupSpy(t)
+		NewLogger(ts, BufferUntilFailure(0)).Info("received work order")
+		ts.finish()
+		ts.AssertMessages("INFO	received work order")
+		ts.AssertPassed()
+	})
+
+	t.Run("without cleanup", func(t *testing.T) {
+		ts := newTestLogSpy(t)
+		// Hide the Cleanup method of the underlying testing.TB.
+		NewLogger(struct{ TestingT }{ts}, BufferUntilFailure(0)).Info("received work order")
+		ts.AssertMessages("INFO	received work order")
+	})
+}
+
+func TestBufferUntilFailureTruncates(t *testing.T) {
+	ts := newCleanupSpy(t)
+	b := newFailureBuffer(ts, ts, 10)
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", strings.Repeat("d", 20) + "\n"} {
+		n, err := b.Write([]byte(line))
+		assert.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, len(line), n, "Unexpected length written.")
+	}
+
+	ts.Fail()
+	ts.finish()
+	ts.AssertMessages(
+		"... 3 earlier lines (12 bytes) of log output truncated ...",
+		strings.Repeat("d", 20),
+	)
+}
+
+func TestBufferUntilFailureKeepsRecentLines(t *testing.T) {
+	ts := newCleanupSpy(t)
+	// Each line is about 35 bytes long, depending on the time zone.
+	log := NewLogger(ts, BufferUntilFailure(80))
+	for _, msg := range []string{"one", "two", "three"} {
+		log.Info(msg)
+	}
+
+	ts.Fail()
+	ts.finish()
+	assert.Len(t, ts.Messages, 3, "Expected a marker and the two latest lines.")
+	assert.Regexp(t, `^\.\.\. 1 earlier lines \(\d+ bytes\) of log output truncated \.\.\.$`, ts.Messages[0], "Unexpected marker.")
+	assert.Equal(t, []string{"INFO	two", "INFO	three"}, ts.Messages[1:], "Unexpected retained lines.")
+}
+
+func TestNamedAfterTest(t *testing.T) {
+	t.Run("sub test", func(t *testing.T) {
+		ts := newTestLogSpy(t)
+		defer ts.AssertPassed()
+
+		NewLogger(ts, NamedAfterTest()).Info("received work order")
+		ts.AssertMessages("INFO	TestNamedAfterTest/sub_test	received work order")
+	})
+}
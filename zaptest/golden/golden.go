@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package golden compares the log output of tests against golden files.
+//
+// NewLogger builds a Logger whose output is made deterministic: entries are
+// timestamped by a fixed clock, callers are reported relative to their
+// package, stack traces are omitted, and variable values can be scrubbed.
+// When the test finishes, the output is compared against a file in the
+// package's testdata directory.
+//
+//	func TestHandler(t *testing.T) {
+//		logger := golden.NewLogger(t, golden.ScrubDurations())
+//		handle(logger, request)
+//	} // output is compared against testdata/TestHandler.golden
+//
+// Run the tests with the -update flag to write the golden files instead of
+// comparing against them.
+//
+// Importing this package registers the -update flag, so it can't be
+// imported by test packages that define a flag with the same name.
+package golden // import "go.uber.org/zap/zaptest/golden"
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+var _update = flag.Bool("update", false, "rewrite golden files of zap log output")
+
+// Time is the time at which entries are logged, unless another Clock is
+// supplied.
+var Time = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// TestingT is a subset of the API provided by *testing.T and *testing.B.
+type TestingT interface {
+	zaptest.TestingT
+
+	// Marks the calling function as a test helper.
+	Helper()
+
+	// Registers a function to be called when the test completes.
+	Cleanup(func())
+}
+
+// Option configures the Logger built by NewLogger.
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(opts *options) {
+	f(opts)
+}
+
+type options struct {
+	path           string
+	newEncoder     func(zapcore.EncoderConfig) zapcore.Encoder
+	encoderConfig  zapcore.EncoderConfig
+	level          zapcore.LevelEnabler
+	clock          zapcore.Clock
+	scrubDurations bool
+	scrubbers      []scrubber
+	zapOptions     []zap.Option
+}
+
+type scrubber struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// File sets the path of the golden file. Defaults to
+// testdata/<test name>.golden, where subtests are placed in a directory
+// named after their parent test.
+func File(path string) Option {
+	return optionFunc(func(opts *options) {
+		opts.path = path
+	})
+}
+
+// Encoding sets the constructor of the encoder that the output is written
+// with, such as zapcore.NewConsoleEncoder. Defaults to
+// zapcore.NewJSONEncoder.
+func Encoding(newEncoder func(zapcore.EncoderConfig) zapcore.Encoder) Option {
+	return optionFunc(func(opts *options) {
+		opts.newEncoder = newEncoder
+	})
+}
+
+// EncoderConfig sets the configuration of the encoder. Defaults to
+// zap.NewProductionEncoderConfig with ISO8601 times, string durations, and
+// no stack traces, because stack traces vary between Go versions.
+func EncoderConfig(cfg zapcore.EncoderConfig) Option {
+	return optionFunc(func(opts *options) {
+		opts.encoderConfig = cfg
+	})
+}
+
+// Level controls which entries are logged. Defaults to DebugLevel.
+func Level(enab zapcore.LevelEnabler) Option {
+	return optionFunc(func(opts *options) {
+		opts.level = enab
+	})
+}
+
+// Clock sets the clock that timestamps entries, such as a zaptest.MockClock
+// built with zaptest.NewMockClockAt. Defaults to a clock that always reports
+// Time.
+func Clock(clock zapcore.Clock) Option {
+	return optionFunc(func(opts *options) {
+		opts.clock = clock
+	})
+}
+
+// ScrubDurations writes "<duration>" in place of every duration field, since
+// durations measured by the code under test vary from run to run.
+func ScrubDurations() Option {
+	return optionFunc(func(opts *options) {
+		opts.scrubDurations = true
+	})
+}
+
+// Scrub replaces every match of the regular expression in the output with
+// the replacement, which may refer to submatches as in
+// regexp.Regexp.ReplaceAllString. Use it for values like request IDs that
+// change from run to run:
+//
+//	golden.Scrub(`"request_id":"[^"]*"`, `"request_id":"<id>"`)
+//
+// Scrubbers are applied in the order given. Scrub panics if the expression
+// can't be compiled.
+func Scrub(pattern, replacement string) Option {
+	re := regexp.MustCompile(pattern)
+	return optionFunc(func(opts *options) {
+		opts.scrubbers = append(opts.scrubbers, scrubber{re, replacement})
+	})
+}
+
+// WrapOptions adds zap.Options to the Logger. They're applied after the
+// golden package's own options, so zap.WithClock overrides Clock.
+func WrapOptions(zapOpts ...zap.Option) Option {
+	return optionFunc(func(opts *options) {
+		opts.zapOptions = append(opts.zapOptions, zapOpts...)
+	})
+}
+
+// NewLogger builds a Logger whose output is compared against a golden file
+// when the test and its subtests complete. If the output doesn't match, the
+// test fails with a line-by-line diff. If the -update flag is set, the
+// golden file is written instead.
+//
+// The Logger reports callers; use WrapOptions(zap.WithCaller(false)) to
+// omit them. Errors encountered by zap itself are written to the test log
+// and fail the test.
+func NewLogger(t TestingT, opts ...Option) *zap.Logger {
+	t.Helper()
+
+	cfg := options{
+		path:          filepath.Join("testdata", filepath.FromSlash(t.Name())+".golden"),
+		newEncoder:    zapcore.NewJSONEncoder,
+		encoderConfig: defaultEncoderConfig(),
+		level:         zapcore.DebugLevel,
+		clock:         fixedClock{},
+	}
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	encCfg := cfg.encoderConfig
+	if cfg.scrubDurations {
+		encCfg.EncodeDuration = scrubDuration
+	}
+
+	var buf bytes.Buffer
+	core := zapcore.NewCore(cfg.newEncoder(encCfg), zapcore.Lock(zapcore.AddSync(&buf)), cfg.level)
+	zapOptions := []zap.Option{
+		zap.AddCaller(),
+		zap.WithClock(cfg.clock),
+		zap.ErrorOutput(zaptest.NewTestingWriter(t).WithMarkFailed(true)),
+	}
+	zapOptions = append(zapOptions, cfg.zapOptions...)
+	logger := zap.New(core, zapOptions...)
+
+	t.Cleanup(func() {
+		t.Helper()
+		_ = logger.Sync()
+		got := buf.Bytes()
+		for _, s := range cfg.scrubbers {
+			got = s.re.ReplaceAll(got, []byte(s.repl))
+		}
+		check(t, cfg.path, got)
+	})
+	return logger
+}
+
+func defaultEncoderConfig() zapcore.EncoderConfig {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	cfg.EncodeDuration = zapcore.StringDurationEncoder
+	cfg.StacktraceKey = zapcore.OmitKey
+	return cfg
+}
+
+func scrubDuration(_ time.Duration, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString("<duration>")
+}
+
+type fixedClock struct{}
+
+func (fixedClock) Now() time.Time { return Time }
+
+func (fixedClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+// check compares got against the golden file at path, or writes it if
+// -update is set.
+func check(t TestingT, path string, got []byte) {
+	t.Helper()
+
+	if *_update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("can't create directory for golden file: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Errorf("can't update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("golden file %s doesn't exist; run the test with -update to create it", path)
+		return
+	}
+	if err != nil {
+		t.Errorf("can't read golden file: %v", err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("log output doesn't match golden file %s; run the test with -update to rewrite it\n%s",
+			path, diffLines(string(want), string(got)))
+	}
+}
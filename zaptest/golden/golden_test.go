@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func logRequest(logger *zap.Logger, id string, latency time.Duration) {
+	logger.Info("handled request",
+		zap.String("request_id", id),
+		zap.Duration("latency", latency),
+	)
+	logger.Named("db").Error("query failed", zap.Error(errors.New("timeout")))
+}
+
+func TestNewLogger(t *testing.T) {
+	opts := []Option{
+		ScrubDurations(),
+		Scrub(`"request_id":"[^"]*"`, `"request_id":"<id>"`),
+	}
+	logRequest(NewLogger(t, opts...), "0f3a", 42*time.Millisecond)
+
+	t.Run("console", func(t *testing.T) {
+		logger := NewLogger(t, ScrubDurations(), Encoding(zapcore.NewConsoleEncoder), Level(zap.WarnLevel))
+		logRequest(logger, "ignored", time.Second)
+	})
+}
+
+// fakeT is a TestingT that records failures and cleanup functions.
+type fakeT struct {
+	name     string
+	errors   []string
+	failed   bool
+	cleanups []func()
+}
+
+func (t *fakeT) Logf(string, ...interface{}) {}
+func (t *fakeT) Fail()                       { t.failed = true }
+func (t *fakeT) Failed() bool                { return t.failed }
+func (t *fakeT) Name() string                { return t.name }
+func (t *fakeT) FailNow()                    { t.failed = true }
+func (t *fakeT) Helper()                     {}
+func (t *fakeT) Cleanup(f func())            { t.cleanups = append(t.cleanups, f) }
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.failed = true
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.golden")
+	require.NoError(t, os.WriteFile(path, []byte("{\"msg\":\"a\"}\n{\"msg\":\"b\"}\n"), 0o644), "Failed to write golden file.")
+	newLogger := func(ft *fakeT) *zap.Logger {
+		return NewLogger(ft, File(path), EncoderConfig(zapcore.EncoderConfig{MessageKey: "msg"}))
+	}
+
+	t.Run("match", func(t *testing.T) {
+		ft := &fakeT{}
+		logger := newLogger(ft)
+		logger.Info("a")
+		logger.Info("b")
+		ft.finish()
+		assert.Empty(t, ft.errors, "Unexpected failures.")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		ft := &fakeT{}
+		logger := newLogger(ft)
+		logger.Info("a")
+		logger.Info("c")
+		ft.finish()
+		assert.Equal(t, []string{
+			"log output doesn't match golden file " + path + "; run the test with -update to rewrite it\n" +
+				"--- golden\n" +
+				"+++ output\n" +
+				"  {\"msg\":\"a\"}\n" +
+				"- {\"msg\":\"b\"}\n" +
+				"+ {\"msg\":\"c\"}\n",
+		}, ft.errors, "Unexpected failure.")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing.golden")
+		ft := &fakeT{}
+		NewLogger(ft, File(missing)).Info("a")
+		ft.finish()
+		assert.Equal(t, []string{
+			"golden file " + missing + " doesn't exist; run the test with -update to create it",
+		}, ft.errors, "Unexpected failure.")
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	defer func(update bool) { *_update = update }(*_update)
+	*_update = true
+
+	wd, err := os.Getwd()
+	require.NoError(t, err, "Failed to get working directory.")
+	dir := t.TempDir()
+	require.NoError(t, os.Chdir(dir), "Failed to change directory.")
+	defer func() { require.NoError(t, os.Chdir(wd), "Failed to restore directory.") }()
+
+	ft := &fakeT{name: "TestUpdate/sub_test"}
+	NewLogger(ft, EncoderConfig(zapcore.EncoderConfig{MessageKey: "msg"})).Info("hello")
+	ft.finish()
+	assert.Empty(t, ft.errors, "Unexpected failures.")
+
+	got, err := os.ReadFile(filepath.Join(dir, "testdata", "TestUpdate", "sub_test.golden"))
+	require.NoError(t, err, "Expected the golden file to be written.")
+	assert.Equal(t, "{\"msg\":\"hello\"}\n", string(got), "Unexpected golden file contents.")
+}
+
+func TestClock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.golden")
+	require.NoError(t, os.WriteFile(path, []byte(`{"ts":"1999-12-31T23:59:59.000Z","msg":"a"}`+"\n"), 0o644), "Failed to write golden file.")
+
+	ft := &fakeT{}
+	logger := NewLogger(ft, File(path), Clock(stoppedClock{Time.Add(-time.Second)}), EncoderConfig(zapcore.EncoderConfig{
+		MessageKey: "msg",
+		TimeKey:    "ts",
+		EncodeTime: zapcore.ISO8601TimeEncoder,
+	}))
+	logger.Info("a")
+	ft.finish()
+	assert.Empty(t, ft.errors, "Unexpected failures.")
+}
+
+type stoppedClock struct{ t time.Time }
+
+func (c stoppedClock) Now() time.Time                       { return c.t }
+func (c stoppedClock) NewTicker(time.Duration) *time.Ticker { return nil }
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		desc      string
+		want, got string
+		diff      string
+	}{
+		{"empty", "", "", ""},
+		{"added", "", "a\n", "+ a\n"},
+		{"removed", "a\n", "", "- a\n"},
+		{"changed middle", "a\nb\nc\n", "a\nx\nc\n", "  a\n- b\n+ x\n  c\n"},
+		{"inserted", "a\nc\n", "a\nb\nc\n", "  a\n+ b\n  c\n"},
+		{"no trailing newline", "a", "a\n", "  a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, "--- golden\n+++ output\n"+tt.diff, diffLines(tt.want, tt.got), "Unexpected diff.")
+		})
+	}
+}
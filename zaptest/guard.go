@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// A LogGuard fails a test when the code under test logs entries at or above
+// a level that the test didn't expect, such as errors. Apply it to a test
+// Logger with the Guard option, or to any other Core with Wrap.
+//
+//	guard := zaptest.NewLogGuard(t, zap.ErrorLevel)
+//	guard.Expect(observer.MatchMessage("^connection refused$"))
+//	logger := zaptest.NewLogger(t, zaptest.Guard(guard))
+//
+// Expected entries are also verified: when the test completes, it fails for
+// each expectation that no entry matched.
+type LogGuard struct {
+	t    TestingT
+	enab zapcore.LevelEnabler
+
+	mu           sync.Mutex
+	expectations []*expectation
+}
+
+type expectation struct {
+	m    observer.Matcher
+	seen bool
+}
+
+// NewLogGuard builds a LogGuard that fails the test when entries enabled by
+// enab are logged without being expected.
+//
+// If the TestingT has a Cleanup method, as *testing.T and *testing.B do,
+// expectations are verified automatically when the test completes.
+// Otherwise, call Verify.
+func NewLogGuard(t TestingT, enab zapcore.LevelEnabler) *LogGuard {
+	g := &LogGuard{t: t, enab: enab}
+	if c, ok := t.(cleanuper); ok {
+		c.Cleanup(g.Verify)
+	}
+	return g
+}
+
+// Expect allows entries that match m, and requires that at least one such
+// entry is logged before the test completes. Entries at any level can be
+// expected, but only entries logged after the call are considered.
+func (g *LogGuard) Expect(m observer.Matcher) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expectations = append(g.expectations, &expectation{m: m})
+}
+
+// Verify fails the test for each expectation that no entry has matched.
+func (g *LogGuard) Verify() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range g.expectations {
+		if !e.seen {
+			g.t.Errorf("expected an entry matching %v, but none was logged", e.m)
+		}
+	}
+}
+
+// Wrap returns a Core that writes to core and checks each entry against the
+// guard. It has the signature expected by zap.WrapCore.
+func (g *LogGuard) Wrap(core zapcore.Core) zapcore.Core {
+	return zapcore.NewTee(core, &guardCore{guard: g})
+}
+
+// check records which expectations the entry meets, and fails the test if
+// it meets none and should have.
+func (g *LogGuard) check(entry observer.LoggedEntry) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	expected := false
+	for _, e := range g.expectations {
+		if e.m.Matches(entry) {
+			e.seen = true
+			expected = true
+		}
+	}
+	if expected || !g.enab.Enabled(entry.Level) {
+		return
+	}
+
+	caller := "unknown caller"
+	if entry.Caller.Defined {
+		caller = entry.Caller.TrimmedPath()
+	} else if frame, ok := callerOutsideZap(); ok {
+		caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true).TrimmedPath()
+	}
+	msg := fmt.Sprintf("unexpected %v entry logged at %s: %q", entry.Level, caller, entry.Message)
+	if len(entry.Context) > 0 {
+		msg += fmt.Sprintf(" %v", entry.ContextMap())
+	}
+	g.t.Errorf("%s", msg)
+}
+
+func (g *LogGuard) enabled(lvl zapcore.Level) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Entries below the threshold only matter if they may be expected.
+	return len(g.expectations) > 0 || g.enab.Enabled(lvl)
+}
+
+// callerOutsideZap finds the innermost frame on the stack that's not in one
+// of zap's packages, other than their tests.
+func callerOutsideZap() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "go.uber.org/zap") || strings.HasSuffix(frame.File, "_test.go") {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+type guardCore struct {
+	guard   *LogGuard
+	context []zapcore.Field
+}
+
+func (c *guardCore) Enabled(lvl zapcore.Level) bool {
+	return c.guard.enabled(lvl)
+}
+
+func (c *guardCore) With(fields []zapcore.Field) zapcore.Core {
+	return &guardCore{
+		guard:   c.guard,
+		context: append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+func (c *guardCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *guardCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+	c.guard.check(observer.LoggedEntry{Entry: ent, Context: all})
+	return nil
+}
+
+func (c *guardCore) Sync() error {
+	return nil
+}
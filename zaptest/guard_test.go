@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// errorSpy is a cleanupSpy that records failures instead of reporting them.
+type errorSpy struct {
+	*cleanupSpy
+
+	Errors []string
+}
+
+func newErrorSpy(t testing.TB) *errorSpy {
+	return &errorSpy{cleanupSpy: newCleanupSpy(t)}
+}
+
+func (s *errorSpy) Errorf(format string, args ...interface{}) {
+	s.Fail()
+	s.Errors = append(s.Errors, fmt.Sprintf(format, args...))
+}
+
+func TestLogGuard(t *testing.T) {
+	ts := newErrorSpy(t)
+	log := NewLogger(ts, Guard(NewLogGuard(ts, zap.ErrorLevel)))
+
+	log.Warn("work may fail")
+	assert.Empty(t, ts.Errors, "Unexpected failure for an entry below the threshold.")
+
+	log.With(zap.String("job", "backup")).Error("work failed", zap.Error(errors.New("great sadness")))
+	ts.finish()
+
+	require.Len(t, ts.Errors, 1, "Expected a failure for the error entry.")
+	assert.Regexp(t,
+		`^unexpected error entry logged at zaptest/guard_test.go:\d+: "work failed" map\[error:great sadness job:backup\]$`,
+		ts.Errors[0], "Unexpected failure message.")
+	ts.AssertMessages(
+		"WARN	work may fail",
+		`ERROR	work failed	{"job": "backup", "error": "great sadness"}`,
+	)
+}
+
+func TestLogGuardReportsEntryCaller(t *testing.T) {
+	ts := newErrorSpy(t)
+	guard := NewLogGuard(ts, zap.WarnLevel)
+	log := zap.New(zapcore.NewNopCore(), zap.WrapCore(guard.Wrap), zap.AddCaller())
+
+	log.Warn("work may fail")
+	require.Len(t, ts.Errors, 1, "Expected a failure for the warning.")
+	assert.Regexp(t, `^unexpected warn entry logged at zaptest/guard_test.go:\d+: "work may fail"$`, ts.Errors[0], "Unexpected failure message.")
+}
+
+func TestLogGuardExpect(t *testing.T) {
+	t.Run("met", func(t *testing.T) {
+		ts := newErrorSpy(t)
+		guard := NewLogGuard(ts, zap.ErrorLevel)
+		guard.Expect(observer.MatchMessage("^work failed$"))
+		guard.Expect(observer.MatchAll(observer.MatchLevel(zap.InfoLevel), observer.MatchMessage("retrying")))
+		log := NewLogger(ts, Guard(guard))
+
+		log.Error("work failed")
+		log.Error("work failed")
+		log.Info("retrying work")
+		ts.finish()
+
+		assert.Empty(t, ts.Errors, "Unexpected failures.")
+		ts.AssertPassed()
+	})
+
+	t.Run("unmet", func(t *testing.T) {
+		ts := newErrorSpy(t)
+		guard := NewLogGuard(ts, zap.ErrorLevel)
+		guard.Expect(observer.MatchMessage("^work failed$"))
+		log := NewLogger(ts, Guard(guard))
+
+		log.Error("work exploded")
+		ts.finish()
+
+		require.Len(t, ts.Errors, 2, "Expected failures for the unexpected entry and the unmet expectation.")
+		assert.Contains(t, ts.Errors[0], `"work exploded"`, "Unexpected failure for the unexpected entry.")
+		assert.Equal(t, `expected an entry matching message matching "^work failed$", but none was logged`, ts.Errors[1],
+			"Unexpected failure for the unmet expectation.")
+	})
+
+	t.Run("verify without cleanup", func(t *testing.T) {
+		ts := newErrorSpy(t)
+		guard := NewLogGuard(struct{ TestingT }{ts}, zap.ErrorLevel)
+		guard.Expect(observer.MatchLevel(zap.WarnLevel))
+		ts.finish()
+		assert.Empty(t, ts.Errors, "Expected no automatic verification.")
+
+		guard.Verify()
+		assert.Len(t, ts.Errors, 1, "Expected a failure for the unmet expectation.")
+	})
+}
+
+func TestLogGuardLevels(t *testing.T) {
+	ts := newErrorSpy(t)
+	guard := NewLogGuard(ts, zap.ErrorLevel)
+	core := guard.Wrap(zapcore.NewNopCore())
+	assert.False(t, core.Enabled(zap.InfoLevel), "Expected entries below the threshold to be disabled.")
+	assert.True(t, core.Enabled(zap.ErrorLevel), "Expected entries at the threshold to be enabled.")
+
+	guard.Expect(observer.MatchLevel(zap.DebugLevel))
+	assert.True(t, core.Enabled(zap.DebugLevel), "Expected entries below the threshold to be enabled once expected.")
+}
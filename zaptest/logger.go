@@ -33,8 +33,13 @@ type LoggerOption interface {
 }
 
 type loggerOptions struct {
-	Level      zapcore.LevelEnabler
-	zapOptions []zap.Option
+	Level          zapcore.LevelEnabler
+	clock          zapcore.Clock
+	buffered       bool
+	maxBufferBytes int
+	named          bool
+	guard          *LogGuard
+	zapOptions     []zap.Option
 }
 
 type loggerOptionFunc func(*loggerOptions)
@@ -51,6 +56,50 @@ func Level(enab zapcore.LevelEnabler) LoggerOption {
 	})
 }
 
+// Clock sets the source of time for a test Logger built by NewLogger, such
+// as a MockClock. It's equivalent to wrapping zap.WithClock.
+func Clock(clock zapcore.Clock) LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.clock = clock
+	})
+}
+
+// BufferUntilFailure holds on to the output of a test Logger built by
+// NewLogger until the test completes, and only writes it to the test log if
+// the test failed. This keeps the output of go test -v focused on failures.
+// Run tests with -zaptest.showlogs to write the output of passing tests too.
+//
+// At most maxBytes of output are retained, discarding the oldest lines
+// first and noting how much was discarded. If maxBytes is zero or negative,
+// all output is retained.
+//
+// The option has no effect unless the TestingT passed to NewLogger has a
+// Cleanup method, as *testing.T and *testing.B do. Errors encountered by zap
+// itself are always written to the test log immediately.
+func BufferUntilFailure(maxBytes int) LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.buffered = true
+		opts.maxBufferBytes = maxBytes
+	})
+}
+
+// NamedAfterTest names a test Logger built by NewLogger after the test, as
+// if by Logger.Named(t.Name()). This tells apart the output of subtests that
+// share the test log of their parent, such as parallel subtests.
+func NamedAfterTest() LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.named = true
+	})
+}
+
+// Guard applies a LogGuard to a test Logger built by NewLogger, failing the
+// test when it logs unexpected entries.
+func Guard(g *LogGuard) LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.guard = g
+	})
+}
+
 // WrapOptions adds zap.Option's to a test Logger built by NewLogger.
 func WrapOptions(zapOpts ...zap.Option) LoggerOption {
 	return loggerOptionFunc(func(opts *loggerOptions) {
@@ -71,6 +120,15 @@ func WrapOptions(zapOpts ...zap.Option) LoggerOption {
 //
 //	logger := zaptest.NewLogger(t, zaptest.Level(zap.WarnLevel))
 //
+// Use the Clock option to control the time of entries, which also
+// determines which entries a sampler drops.
+//
+//	logger := zaptest.NewLogger(t, zaptest.Clock(zaptest.NewMockClock()))
+//
+// To only see the output of tests that fail, buffer it.
+//
+//	logger := zaptest.NewLogger(t, zaptest.BufferUntilFailure(1<<20))
+//
 // You may also pass zap.Option's to customize test logger.
 //
 //	logger := zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller()))
@@ -83,21 +141,33 @@ func NewLogger(t TestingT, opts ...LoggerOption) *zap.Logger {
 	}
 
 	writer := NewTestingWriter(t)
+	var out zapcore.WriteSyncer = writer
+	if c, ok := t.(cleanuper); ok && cfg.buffered {
+		out = newFailureBuffer(t, c, cfg.maxBufferBytes)
+	}
 	zapOptions := []zap.Option{
 		// Send zap errors to the same writer and mark the test as failed if
 		// that happens.
 		zap.ErrorOutput(writer.WithMarkFailed(true)),
 	}
+	if cfg.clock != nil {
+		zapOptions = append(zapOptions, zap.WithClock(cfg.clock))
+	}
 	zapOptions = append(zapOptions, cfg.zapOptions...)
 
-	return zap.New(
-		zapcore.NewCore(
-			zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
-			writer,
-			cfg.Level,
-		),
-		zapOptions...,
+	core := zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		out,
+		cfg.Level,
 	)
+	if cfg.guard != nil {
+		core = cfg.guard.Wrap(core)
+	}
+	logger := zap.New(core, zapOptions...)
+	if cfg.named {
+		logger = logger.Named(t.Name())
+	}
+	return logger
 }
 
 // TestingWriter is a WriteSyncer that writes to the given testing.TB.
@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TestingT is the subset of testing.TB used by the assertion methods of
+// ObservedLogs.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertLogged asserts that at least one observed entry matches m. On
+// failure, it reports every observed entry and why it didn't match.
+//
+// Like the functions in testify's assert package, it returns whether the
+// assertion succeeded.
+func (o *ObservedLogs) AssertLogged(t TestingT, m Matcher) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	logs := o.All()
+	for _, e := range logs {
+		if m.Matches(e) {
+			return true
+		}
+	}
+	t.Errorf("no entry matched: %v\n%s", m, describeEntries(logs, m))
+	return false
+}
+
+// AssertNotLogged asserts that no observed entry matches m.
+func (o *ObservedLogs) AssertNotLogged(t TestingT, m Matcher) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	logs := o.All()
+	var matched []string
+	for i, e := range logs {
+		if m.Matches(e) {
+			matched = append(matched, formatEntry(i, e))
+		}
+	}
+	if len(matched) == 0 {
+		return true
+	}
+	t.Errorf("unexpected entries matched: %v\n%s", m, strings.Join(matched, "\n"))
+	return false
+}
+
+// AssertLoggedInOrder asserts that entries matching each of the given
+// matchers were observed in the given order. Other entries may be
+// interleaved with them, and each entry satisfies at most one matcher.
+func (o *ObservedLogs) AssertLoggedInOrder(t TestingT, matchers ...Matcher) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	logs := o.All()
+	next := 0 // index of the first entry not yet considered
+	for i, m := range matchers {
+		found := false
+		for ; next < len(logs); next++ {
+			if m.Matches(logs[next]) {
+				found = true
+				next++
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no entry matched matcher %d of %d in order: %v\n%s",
+				i+1, len(matchers), m, describeEntries(logs, m))
+			return false
+		}
+	}
+	return true
+}
+
+// AssertLoggedInAnyOrder asserts that, for each of the given matchers, a
+// distinct observed entry matches it. The order of the entries doesn't
+// matter, and other entries may be observed too.
+func (o *ObservedLogs) AssertLoggedInAnyOrder(t TestingT, matchers ...Matcher) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	logs := o.All()
+
+	// Find a maximum matching between matchers and entries with augmenting
+	// paths. entryOwner[j] is the index of the matcher that entry j is
+	// assigned to, or -1.
+	entryOwner := make([]int, len(logs))
+	for j := range entryOwner {
+		entryOwner[j] = -1
+	}
+	var assign func(i int, visited []bool) bool
+	assign = func(i int, visited []bool) bool {
+		for j, e := range logs {
+			if visited[j] || !matchers[i].Matches(e) {
+				continue
+			}
+			visited[j] = true
+			if entryOwner[j] < 0 || assign(entryOwner[j], visited) {
+				entryOwner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	var unmatched []string
+	for i, m := range matchers {
+		if !assign(i, make([]bool, len(logs))) {
+			unmatched = append(unmatched, fmt.Sprintf("  %v", m))
+		}
+	}
+	if len(unmatched) == 0 {
+		return true
+	}
+	t.Errorf("no distinct entries matched %d of %d matchers:\n%s\n%s",
+		len(unmatched), len(matchers), strings.Join(unmatched, "\n"), describeEntries(logs, nil))
+	return false
+}
+
+// AssertEventuallyLogged asserts that an entry matching m is observed
+// within the timeout, waiting for it with WaitFor.
+func (o *ObservedLogs) AssertEventuallyLogged(t TestingT, m Matcher, timeout time.Duration) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if _, ok := o.WaitFor(m, timeout); ok {
+		return true
+	}
+	logs := o.All()
+	t.Errorf("no entry matched within %v: %v\n%s", timeout, m, describeEntries(logs, m))
+	return false
+}
+
+// describeEntries lists the given entries for a failure message. If m isn't
+// nil, each entry is followed by the reasons it doesn't match m.
+func describeEntries(logs []LoggedEntry, m Matcher) string {
+	if len(logs) == 0 {
+		return "observed no entries"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "observed %d entries:", len(logs))
+	for i, e := range logs {
+		sb.WriteString("\n")
+		sb.WriteString(formatEntry(i, e))
+		if m == nil {
+			continue
+		}
+		for _, diff := range explain(m, e) {
+			sb.WriteString("\n      - ")
+			sb.WriteString(diff)
+		}
+	}
+	return sb.String()
+}
+
+func formatEntry(i int, e LoggedEntry) string {
+	s := fmt.Sprintf("  [%d] %v %q", i, e.Level, e.Message)
+	if e.LoggerName != "" {
+		s += " logger=" + e.LoggerName
+	}
+	if len(e.Context) > 0 {
+		s += " " + formatFields(e.ContextMap())
+	}
+	return s
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zaptest/observer"
+)
+
+// recordingT is a TestingT that records failures.
+type recordingT struct{ errors []string }
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// callerT is a TestingT that records where each failure is reported,
+// skipping the frames of functions marked with Helper like testing.T does.
+type callerT struct {
+	helpers map[string]struct{}
+	callers []string
+}
+
+func (t *callerT) Helper() {
+	pc, _, _, _ := runtime.Caller(1)
+	if t.helpers == nil {
+		t.helpers = make(map[string]struct{})
+	}
+	t.helpers[runtime.FuncForPC(pc).Name()] = struct{}{}
+}
+
+func (t *callerT) Errorf(string, ...interface{}) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if _, ok := t.helpers[frame.Function]; !ok || !more {
+			t.callers = append(t.callers, fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line))
+			return
+		}
+	}
+}
+
+// here returns the file and line that it's called from.
+func here() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+func newObservedLogger() (*zap.Logger, *ObservedLogs) {
+	core, logs := New(zap.DebugLevel)
+	return zap.New(core), logs
+}
+
+func TestAssertLogged(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("starting", zap.Int("attempt", 1))
+	logger.Named("client").Warn("retrying", zap.Object("user", user{Name: "alice", ID: 42}))
+
+	rt := &recordingT{}
+	assert.True(t, logs.AssertLogged(rt, MatchMessage("^retry")), "Expected a matching entry.")
+	assert.Empty(t, rt.errors, "Unexpected failures.")
+
+	assert.False(t, logs.AssertLogged(rt, MatchAll(
+		MatchLevel(zap.WarnLevel),
+		MatchFields(map[string]interface{}{"user": map[string]interface{}{"id": 7}}),
+	)), "Expected no matching entry.")
+	require.Len(t, rt.errors, 1, "Expected a failure.")
+	assert.Equal(t, `no entry matched: level warn, fields {user={id=7}}
+observed 2 entries:
+  [0] info "starting" {attempt=1}
+      - level is info, want warn
+      - field "user" is missing
+  [1] warn "retrying" logger=client {user={id=42, name=alice}}
+      - field "user.id" is 42, want 7`, rt.errors[0], "Unexpected failure message.")
+}
+
+func TestAssertLoggedEmpty(t *testing.T) {
+	_, logs := newObservedLogger()
+	rt := &recordingT{}
+	assert.False(t, logs.AssertLogged(rt, MatchFunc("anything", func(LoggedEntry) bool { return true })), "Expected no matching entry.")
+	assert.Equal(t, []string{"no entry matched: anything\nobserved no entries"}, rt.errors, "Unexpected failure message.")
+}
+
+func TestAssertNotLogged(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("fine")
+	logger.Error("broken", zap.String("reason", "disk"))
+
+	rt := &recordingT{}
+	assert.True(t, logs.AssertNotLogged(rt, MatchLevel(zap.DPanicLevel)), "Expected no matching entry.")
+	assert.False(t, logs.AssertNotLogged(rt, MatchLevel(zap.ErrorLevel)), "Expected a matching entry.")
+	assert.Equal(t, []string{
+		"unexpected entries matched: level error\n" +
+			`  [1] error "broken" {reason=disk}`,
+	}, rt.errors, "Unexpected failure message.")
+}
+
+func TestAssertLoggedInOrder(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("a")
+	logger.Info("noise")
+	logger.Info("b")
+	logger.Info("c")
+
+	tests := []struct {
+		desc     string
+		messages []string
+		want     bool
+	}{
+		{"none", nil, true},
+		{"all", []string{"a", "noise", "b", "c"}, true},
+		{"subsequence", []string{"a", "c"}, true},
+		{"out of order", []string{"c", "a"}, false},
+		{"repeated", []string{"a", "a"}, false},
+		{"missing", []string{"a", "d"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			matchers := make([]Matcher, len(tt.messages))
+			for i, msg := range tt.messages {
+				matchers[i] = MatchMessage("^" + msg + "$")
+			}
+			rt := &recordingT{}
+			assert.Equal(t, tt.want, logs.AssertLoggedInOrder(rt, matchers...), "Unexpected assertion result.")
+			assert.Equal(t, !tt.want, len(rt.errors) == 1, "Expected a failure only if the assertion failed.")
+		})
+	}
+
+	rt := &recordingT{}
+	logs.AssertLoggedInOrder(rt, MatchMessage("^c$"), MatchMessage("^a$"))
+	require.Len(t, rt.errors, 1, "Expected a failure.")
+	assert.Contains(t, rt.errors[0], `no entry matched matcher 2 of 2 in order: message matching "^a$"`, "Unexpected failure message.")
+}
+
+func TestAssertLoggedInAnyOrder(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("a", zap.Int("n", 1))
+	logger.Info("b", zap.Int("n", 2))
+	logger.Warn("c", zap.Int("n", 1))
+
+	n1 := MatchFields(map[string]interface{}{"n": 1})
+	tests := []struct {
+		desc     string
+		matchers []Matcher
+		want     bool
+	}{
+		{"none", nil, true},
+		{"reversed", []Matcher{MatchMessage("c"), MatchMessage("b"), MatchMessage("a")}, true},
+		{"distinct entries", []Matcher{n1, n1}, true},
+		{"too many", []Matcher{n1, n1, n1}, false},
+		{
+			// A greedy assignment would give "a" to the first matcher and
+			// leave nothing for the second.
+			"needs reassignment",
+			[]Matcher{n1, MatchMessage("^a$")},
+			true,
+		},
+		{"missing", []Matcher{MatchMessage("d")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rt := &recordingT{}
+			assert.Equal(t, tt.want, logs.AssertLoggedInAnyOrder(rt, tt.matchers...), "Unexpected assertion result.")
+			assert.Equal(t, !tt.want, len(rt.errors) == 1, "Expected a failure only if the assertion failed.")
+		})
+	}
+
+	rt := &recordingT{}
+	logs.AssertLoggedInAnyOrder(rt, n1, MatchMessage("d"))
+	assert.Equal(t, []string{`no distinct entries matched 1 of 2 matchers:
+  message matching "d"
+observed 3 entries:
+  [0] info "a" {n=1}
+  [1] info "b" {n=2}
+  [2] warn "c" {n=1}`}, rt.errors, "Unexpected failure message.")
+}
+
+func TestWaitFor(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("before")
+
+	e, ok := logs.WaitFor(MatchMessage("before"), 0)
+	require.True(t, ok, "Expected to find an entry logged before waiting.")
+	assert.Equal(t, "before", e.Message, "Unexpected entry.")
+
+	_, ok = logs.WaitFor(MatchMessage("never"), 10*time.Millisecond)
+	assert.False(t, ok, "Expected to time out.")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			logger.Info("progress", zap.Int("i", i))
+		}
+	}()
+	e, ok = logs.WaitFor(MatchFields(map[string]interface{}{"i": 4}), time.Second)
+	<-done
+	require.True(t, ok, "Expected to find an entry logged concurrently.")
+	assert.Equal(t, int64(4), e.ContextMap()["i"], "Unexpected entry.")
+}
+
+func TestAssertEventuallyLogged(t *testing.T) {
+	logger, logs := newObservedLogger()
+
+	go logger.Warn("late")
+	rt := &recordingT{}
+	assert.True(t, logs.AssertEventuallyLogged(rt, MatchLevel(zap.WarnLevel), time.Second), "Expected the entry to be logged.")
+	assert.False(t, logs.AssertEventuallyLogged(rt, MatchLevel(zap.ErrorLevel), time.Millisecond), "Expected a timeout.")
+	assert.Equal(t, []string{"no entry matched within 1ms: level error\n" +
+		"observed 1 entries:\n" +
+		`  [0] warn "late"` + "\n" +
+		"      - level is warn, want error"}, rt.errors, "Unexpected failure message.")
+}
+
+func TestAssertReportsCaller(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.Info("hello")
+	m := MatchMessage("nope")
+	ct := &callerT{}
+
+	// Each assertion and here are called on the same line, so a failure
+	// reported at the call site has the same file and line as here.
+	assertions := []func() (bool, string){
+		func() (bool, string) { return logs.AssertLogged(ct, m), here() },
+		func() (bool, string) { return logs.AssertNotLogged(ct, MatchMessage("hello")), here() },
+		func() (bool, string) { return logs.AssertLoggedInOrder(ct, m), here() },
+		func() (bool, string) { return logs.AssertLoggedInAnyOrder(ct, m), here() },
+		func() (bool, string) { return logs.AssertEventuallyLogged(ct, m, time.Millisecond), here() },
+	}
+	var want []string
+	for _, assertion := range assertions {
+		ok, line := assertion()
+		require.False(t, ok, "Expected the assertion to fail.")
+		want = append(want, line)
+	}
+	assert.Equal(t, want, ct.callers, "Expected failures to be reported at the call site.")
+}
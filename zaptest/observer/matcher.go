@@ -0,0 +1,348 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// A Matcher decides whether a LoggedEntry is one that a test expects.
+// Matchers are used by FilterMatch, WaitFor, and the assertion methods on
+// ObservedLogs.
+type Matcher interface {
+	// Matches reports whether the entry matches.
+	Matches(LoggedEntry) bool
+
+	// String describes the entries that match, for use in failure messages.
+	String() string
+}
+
+// explainer is implemented by matchers that can describe why a particular
+// entry doesn't match.
+type explainer interface {
+	explain(LoggedEntry) []string
+}
+
+// MatchLevel matches entries logged at exactly the given level.
+func MatchLevel(lvl zapcore.Level) Matcher {
+	return levelMatcher(lvl)
+}
+
+type levelMatcher zapcore.Level
+
+func (m levelMatcher) Matches(e LoggedEntry) bool {
+	return e.Level == zapcore.Level(m)
+}
+
+func (m levelMatcher) String() string {
+	return "level " + zapcore.Level(m).String()
+}
+
+func (m levelMatcher) explain(e LoggedEntry) []string {
+	if m.Matches(e) {
+		return nil
+	}
+	return []string{fmt.Sprintf("level is %v, want %v", e.Level, zapcore.Level(m))}
+}
+
+// MatchMessage matches entries whose message matches the given regular
+// expression. Use ^ and $ to match the whole message. It panics if the
+// expression can't be compiled.
+func MatchMessage(pattern string) Matcher {
+	return messageMatcher{regexp.MustCompile(pattern)}
+}
+
+type messageMatcher struct{ re *regexp.Regexp }
+
+func (m messageMatcher) Matches(e LoggedEntry) bool {
+	return m.re.MatchString(e.Message)
+}
+
+func (m messageMatcher) String() string {
+	return fmt.Sprintf("message matching %q", m.re.String())
+}
+
+func (m messageMatcher) explain(e LoggedEntry) []string {
+	if m.Matches(e) {
+		return nil
+	}
+	return []string{fmt.Sprintf("message %q doesn't match %q", e.Message, m.re.String())}
+}
+
+// MatchFields matches entries whose context contains the given fields,
+// compared against the entry's ContextMap. Fields not mentioned in want are
+// ignored.
+//
+// Values in want are compared leniently so that tests needn't spell out the
+// exact types that fields are encoded as:
+//
+//   - a map[string]interface{} matches a nested object or namespace that
+//     contains at least the given fields;
+//   - a zapcore.ObjectMarshaler or zapcore.ArrayMarshaler matches a value
+//     that marshals to the same thing;
+//   - an error matches a string equal to its Error();
+//   - numbers match numbers of any type with the same value, so want can use
+//     42 for a field added with zap.Int64;
+//   - everything else is compared with reflect.DeepEqual.
+func MatchFields(want map[string]interface{}) Matcher {
+	return fieldsMatcher(want)
+}
+
+type fieldsMatcher map[string]interface{}
+
+func (m fieldsMatcher) Matches(e LoggedEntry) bool {
+	return len(m.explain(e)) == 0
+}
+
+func (m fieldsMatcher) String() string {
+	return "fields " + formatFields(m)
+}
+
+func (m fieldsMatcher) explain(e LoggedEntry) []string {
+	var diffs []string
+	diffFields(&diffs, "", m, e.ContextMap())
+	return diffs
+}
+
+// diffFields appends a description of each way in which got doesn't contain
+// want to diffs.
+func diffFields(diffs *[]string, prefix string, want, got map[string]interface{}) {
+	for _, key := range sortedKeys(want) {
+		path := prefix + key
+		gotVal, ok := got[key]
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("field %q is missing", path))
+			continue
+		}
+		diffValue(diffs, path, want[key], gotVal)
+	}
+}
+
+func diffValue(diffs *[]string, path string, want, got interface{}) {
+	want = normalizeWant(want)
+	if nested, ok := want.(map[string]interface{}); ok {
+		gotNested, ok := got.(map[string]interface{})
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("field %q is %v, want an object", path, got))
+			return
+		}
+		diffFields(diffs, path+".", nested, gotNested)
+		return
+	}
+	if !valuesEqual(want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("field %q is %v, want %v", path, got, want))
+	}
+}
+
+// normalizeWant converts marshalers in an expected value to the form in
+// which they appear in a ContextMap.
+func normalizeWant(want interface{}) interface{} {
+	switch v := want.(type) {
+	case zapcore.ObjectMarshaler:
+		enc := zapcore.NewMapObjectEncoder()
+		if err := enc.AddObject("v", v); err != nil {
+			return want
+		}
+		return enc.Fields["v"]
+	case zapcore.ArrayMarshaler:
+		enc := zapcore.NewMapObjectEncoder()
+		if err := enc.AddArray("v", v); err != nil {
+			return want
+		}
+		return enc.Fields["v"]
+	case error:
+		return v.Error()
+	}
+	return want
+}
+
+func valuesEqual(want, got interface{}) bool {
+	if wantSlice, ok := want.([]interface{}); ok {
+		gotSlice, ok := got.([]interface{})
+		if !ok || len(wantSlice) != len(gotSlice) {
+			return false
+		}
+		for i := range wantSlice {
+			var diffs []string
+			diffValue(&diffs, "", wantSlice[i], gotSlice[i])
+			if len(diffs) > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if eq, ok := numbersEqual(want, got); ok {
+		return eq
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+// numbersEqual compares two numbers of any type by value. The second result
+// is false if either isn't a number.
+func numbersEqual(a, b interface{}) (equal, ok bool) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	ak, bk := numberKind(av), numberKind(bv)
+	if ak == reflect.Invalid || bk == reflect.Invalid {
+		return false, false
+	}
+	if av.Type() != bv.Type() && av.Type().PkgPath() != "" && bv.Type().PkgPath() != "" {
+		// Don't conflate distinct named types, such as time.Duration and
+		// zapcore.Level.
+		return false, true
+	}
+	switch {
+	case ak == reflect.Float64 || bk == reflect.Float64:
+		af, bf := toFloat(av, ak), toFloat(bv, bk)
+		return af == bf || (math.IsNaN(af) && math.IsNaN(bf)), true
+	case ak == bk && ak == reflect.Int64:
+		return av.Int() == bv.Int(), true
+	case ak == bk && ak == reflect.Uint64:
+		return av.Uint() == bv.Uint(), true
+	case ak == reflect.Int64:
+		return av.Int() >= 0 && uint64(av.Int()) == bv.Uint(), true
+	default:
+		return bv.Int() >= 0 && uint64(bv.Int()) == av.Uint(), true
+	}
+}
+
+// numberKind returns Int64, Uint64, or Float64 for numbers and Invalid for
+// everything else.
+func numberKind(v reflect.Value) reflect.Kind {
+	if !v.IsValid() {
+		return reflect.Invalid
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+func toFloat(v reflect.Value, kind reflect.Kind) float64 {
+	switch kind {
+	case reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint64:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// MatchAll matches entries that match all of the given matchers. With no
+// matchers, it matches every entry.
+func MatchAll(matchers ...Matcher) Matcher {
+	return allMatcher(matchers)
+}
+
+type allMatcher []Matcher
+
+func (m allMatcher) Matches(e LoggedEntry) bool {
+	for _, sub := range m {
+		if !sub.Matches(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m allMatcher) String() string {
+	if len(m) == 0 {
+		return "any entry"
+	}
+	descs := make([]string, len(m))
+	for i, sub := range m {
+		descs[i] = sub.String()
+	}
+	return strings.Join(descs, ", ")
+}
+
+func (m allMatcher) explain(e LoggedEntry) []string {
+	var diffs []string
+	for _, sub := range m {
+		diffs = append(diffs, explain(sub, e)...)
+	}
+	return diffs
+}
+
+// MatchFunc matches entries for which fn returns true. The description is
+// used in failure messages.
+func MatchFunc(description string, fn func(LoggedEntry) bool) Matcher {
+	return funcMatcher{description, fn}
+}
+
+type funcMatcher struct {
+	desc string
+	fn   func(LoggedEntry) bool
+}
+
+func (m funcMatcher) Matches(e LoggedEntry) bool { return m.fn(e) }
+func (m funcMatcher) String() string             { return m.desc }
+
+// explain describes why e doesn't match m, or returns nil if it does.
+func explain(m Matcher, e LoggedEntry) []string {
+	if ex, ok := m.(explainer); ok {
+		return ex.explain(e)
+	}
+	if m.Matches(e) {
+		return nil
+	}
+	return []string{"not " + m.String()}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatFields formats a field map as {k=v, ...} with sorted keys.
+func formatFields(fields map[string]interface{}) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, key := range sortedKeys(fields) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(key)
+		sb.WriteByte('=')
+		if nested, ok := fields[key].(map[string]interface{}); ok {
+			sb.WriteString(formatFields(nested))
+		} else {
+			fmt.Fprintf(&sb, "%v", fields[key])
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zaptest/observer"
+)
+
+type user struct {
+	Name string
+	ID   int
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	enc.AddInt("id", u.ID)
+	return nil
+}
+
+type nanos int64
+
+func loggedEntry(lvl zapcore.Level, msg string, fields ...zapcore.Field) LoggedEntry {
+	return LoggedEntry{
+		Entry:   zapcore.Entry{Level: lvl, Message: msg},
+		Context: fields,
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	entry := loggedEntry(zap.WarnLevel, "request failed",
+		zap.String("path", "/users"),
+		zap.Int64("status", 503),
+		zap.Duration("latency", 5*time.Millisecond),
+		zap.Float64("nan", math.NaN()),
+		zap.Error(errors.New("boom")),
+		zap.Object("user", user{Name: "alice", ID: 42}),
+		zap.Ints("retries", []int{1, 2}),
+		zap.Namespace("extra"),
+		zap.Bool("cached", false),
+	)
+
+	tests := []struct {
+		desc    string
+		matcher Matcher
+		want    bool
+	}{
+		{"level", MatchLevel(zap.WarnLevel), true},
+		{"other level", MatchLevel(zap.ErrorLevel), false},
+		{"message", MatchMessage("^request"), true},
+		{"message mismatch", MatchMessage("^failed"), false},
+		{"no fields", MatchFields(nil), true},
+		{"string field", MatchFields(map[string]interface{}{"path": "/users"}), true},
+		{"untyped number", MatchFields(map[string]interface{}{"status": 503}), true},
+		{"float number", MatchFields(map[string]interface{}{"status": 503.0}), true},
+		{"wrong number", MatchFields(map[string]interface{}{"status": 500}), false},
+		{"unsigned number", MatchFields(map[string]interface{}{"status": uint(503)}), true},
+		{"duration", MatchFields(map[string]interface{}{"latency": 5 * time.Millisecond}), true},
+		{"distinct named types", MatchFields(map[string]interface{}{"latency": nanos(5000000)}), false},
+		{"NaN", MatchFields(map[string]interface{}{"nan": math.NaN()}), true},
+		{"error", MatchFields(map[string]interface{}{"error": errors.New("boom")}), true},
+		{"error string", MatchFields(map[string]interface{}{"error": "boom"}), true},
+		{"missing field", MatchFields(map[string]interface{}{"missing": "x"}), false},
+		{"partial object", MatchFields(map[string]interface{}{"user": map[string]interface{}{"id": 42}}), true},
+		{"object mismatch", MatchFields(map[string]interface{}{"user": map[string]interface{}{"id": 7}}), false},
+		{"object marshaler", MatchFields(map[string]interface{}{"user": user{Name: "alice", ID: 42}}), true},
+		{"object marshaler mismatch", MatchFields(map[string]interface{}{"user": user{Name: "bob", ID: 42}}), false},
+		{"not an object", MatchFields(map[string]interface{}{"path": map[string]interface{}{}}), false},
+		{"array", MatchFields(map[string]interface{}{"retries": []interface{}{1, 2}}), true},
+		{"array marshaler", MatchFields(map[string]interface{}{"retries": zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			enc.AppendInt(1)
+			enc.AppendInt(2)
+			return nil
+		})}), true},
+		{"short array", MatchFields(map[string]interface{}{"retries": []interface{}{1}}), false},
+		{"namespace", MatchFields(map[string]interface{}{"extra": map[string]interface{}{"cached": false}}), true},
+		{"all", MatchAll(MatchLevel(zap.WarnLevel), MatchMessage("failed"), MatchFields(map[string]interface{}{"status": 503})), true},
+		{"all mismatch", MatchAll(MatchLevel(zap.WarnLevel), MatchMessage("succeeded")), false},
+		{"all of nothing", MatchAll(), true},
+		{"func", MatchFunc("has context", func(e LoggedEntry) bool { return len(e.Context) > 0 }), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.matcher.Matches(entry), "Unexpected match result for %v.", tt.matcher)
+		})
+	}
+}
+
+func TestMatcherStrings(t *testing.T) {
+	tests := []struct {
+		matcher Matcher
+		want    string
+	}{
+		{MatchLevel(zap.InfoLevel), "level info"},
+		{MatchMessage("^hi$"), `message matching "^hi$"`},
+		{MatchFields(map[string]interface{}{"b": 2, "a": map[string]interface{}{"c": "d"}}), "fields {a={c=d}, b=2}"},
+		{MatchAll(), "any entry"},
+		{MatchAll(MatchLevel(zap.InfoLevel), MatchMessage("hi")), `level info, message matching "hi"`},
+		{MatchFunc("custom", nil), "custom"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.matcher.String(), "Unexpected matcher description.")
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	core, logs := New(zap.DebugLevel)
+	logger := zap.New(core)
+	logger.Info("a", zap.Int("n", 1))
+	logger.Warn("b", zap.Int("n", 2))
+	logger.Info("c", zap.Int("n", 2))
+
+	got := logs.FilterMatch(MatchAll(MatchLevel(zap.InfoLevel), MatchFields(map[string]interface{}{"n": 2}))).AllUntimed()
+	assert.Equal(t, []LoggedEntry{loggedEntry(zap.InfoLevel, "c", zap.Int("n", 2))}, got, "Unexpected filtered entries.")
+}
@@ -37,6 +37,9 @@ import (
 type ObservedLogs struct {
 	mu   sync.RWMutex
 	logs []LoggedEntry
+
+	// changed is closed when an entry is added, if anyone is waiting.
+	changed chan struct{}
 }
 
 // Len returns the number of items in the collection.
@@ -144,9 +147,55 @@ func (o *ObservedLogs) Filter(keep func(LoggedEntry) bool) *ObservedLogs {
 	return &ObservedLogs{logs: filtered}
 }
 
+// FilterMatch filters entries to those that match the given Matcher.
+func (o *ObservedLogs) FilterMatch(m Matcher) *ObservedLogs {
+	return o.Filter(m.Matches)
+}
+
+// WaitFor blocks until an entry matching m has been observed or the timeout
+// elapses, whichever comes first. It returns the first matching entry, or
+// false if none was logged in time. Entries observed before the call are
+// considered too.
+//
+// WaitFor is intended for tests of code that logs from other goroutines.
+func (o *ObservedLogs) WaitFor(m Matcher, timeout time.Duration) (LoggedEntry, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		logs, changed := o.watch()
+		for _, e := range logs {
+			if m.Matches(e) {
+				return e, true
+			}
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return LoggedEntry{}, false
+		}
+	}
+}
+
+// watch returns the entries observed so far and a channel that's closed when
+// another is added.
+func (o *ObservedLogs) watch() ([]LoggedEntry, <-chan struct{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.changed == nil {
+		o.changed = make(chan struct{})
+	}
+	return o.logs[:len(o.logs):len(o.logs)], o.changed
+}
+
 func (o *ObservedLogs) add(log LoggedEntry) {
 	o.mu.Lock()
 	o.logs = append(o.logs, log)
+	if o.changed != nil {
+		close(o.changed)
+		o.changed = nil
+	}
 	o.mu.Unlock()
 }
 